@@ -0,0 +1,46 @@
+// Command backfill-images generates the resized variants of product images that do not
+// have them yet. Run it once after deploying the imaging pipeline, and again whenever
+// IMAGE_VARIANTS gains a variant. It is safe to rerun.
+package main
+
+import (
+	"context"
+	"log"
+	"os"
+
+	"github.com/aldngrha/ecommerce-be/internal/imaging"
+	"github.com/aldngrha/ecommerce-be/internal/job"
+	"github.com/aldngrha/ecommerce-be/internal/repository"
+	"github.com/aldngrha/ecommerce-be/internal/storage"
+	"github.com/aldngrha/ecommerce-be/pkg/database"
+	"github.com/joho/godotenv"
+)
+
+func main() {
+	ctx := context.Background()
+	godotenv.Load()
+
+	db := database.ConnectionDB(ctx, os.Getenv("DB_URI"))
+	defer db.Close()
+
+	blobStore, err := storage.NewBlobStoreFromEnv()
+	if err != nil {
+		log.Fatalf("Error configuring storage: %v", err)
+	}
+	imageConfig, err := imaging.ConfigFromEnv()
+	if err != nil {
+		log.Fatalf("Error configuring image processing: %v", err)
+	}
+
+	backfilled, err := job.BackfillImageVariants(
+		ctx,
+		repository.NewUploadRepository(db),
+		blobStore,
+		imaging.NewPipeline(imageConfig),
+		imageConfig.VariantNames(),
+	)
+	if err != nil {
+		log.Fatalf("Error backfilling image variants after %d images: %v", backfilled, err)
+	}
+	log.Printf("Generated variants for %d images", backfilled)
+}
@@ -2,25 +2,41 @@ package main
 
 import (
 	"context"
+	"flag"
 	"log"
 	"net"
 	"os"
 	"time"
 
+	"github.com/aldngrha/ecommerce-be/internal/config"
 	grpcmiddleware2 "github.com/aldngrha/ecommerce-be/internal/grpcmiddleware"
 	"github.com/aldngrha/ecommerce-be/internal/handler"
+	"github.com/aldngrha/ecommerce-be/internal/imaging"
+	"github.com/aldngrha/ecommerce-be/internal/job"
+	"github.com/aldngrha/ecommerce-be/internal/mailer"
+	"github.com/aldngrha/ecommerce-be/internal/money"
+	paymentgateway "github.com/aldngrha/ecommerce-be/internal/payment"
 	"github.com/aldngrha/ecommerce-be/internal/repository"
 	"github.com/aldngrha/ecommerce-be/internal/service"
+	"github.com/aldngrha/ecommerce-be/internal/storage"
 	"github.com/aldngrha/ecommerce-be/pb/auth"
+	"github.com/aldngrha/ecommerce-be/pb/cart"
+	"github.com/aldngrha/ecommerce-be/pb/category"
+	"github.com/aldngrha/ecommerce-be/pb/inventory"
+	"github.com/aldngrha/ecommerce-be/pb/order"
+	"github.com/aldngrha/ecommerce-be/pb/payment"
 	"github.com/aldngrha/ecommerce-be/pb/product"
+	"github.com/aldngrha/ecommerce-be/pb/role"
 	"github.com/aldngrha/ecommerce-be/pkg/database"
 	"github.com/joho/godotenv"
-	gocache "github.com/patrickmn/go-cache"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/reflection"
 )
 
 func main() {
+	migrateOnStart := flag.Bool("migrate", false, "apply pending database migrations before serving")
+	flag.Parse()
+
 	ctx := context.Background()
 	godotenv.Load()
 
@@ -32,27 +48,116 @@ func main() {
 	db := database.ConnectionDB(ctx, os.Getenv("DB_URI"))
 	log.Println("Connected to database successfully")
 
-	cacheService := gocache.New(time.Hour*24, time.Hour)
+	transactionManager := repository.NewTransactionManager(db)
+
+	if *migrateOnStart {
+		migrator, err := database.NewMigrator(db)
+		if err != nil {
+			log.Panicf("Error loading migrations: %v", err)
+		}
+		count, err := migrator.Up(ctx)
+		if err != nil {
+			log.Panicf("Error applying migrations: %v", err)
+		}
+		log.Printf("Applied %d pending migrations", count)
+	}
+
+	revocationStore := repository.NewTokenRevocationStore(db)
+	go job.RunTokenRevocationSweeper(ctx, revocationStore, time.Hour)
+
+	roleRepository := repository.NewRoleRepository(db)
+	permissionResolver := service.NewPermissionResolver(roleRepository)
+
+	tokenAuthenticator := service.NewTokenAuthenticator(revocationStore, permissionResolver)
+	authMiddleware := grpcmiddleware2.NewAuthMiddleware(tokenAuthenticator)
 
-	authMiddleware := grpcmiddleware2.NewAuthMiddleware(cacheService)
+	idempotencyRepository := repository.NewIdempotencyRepository(db)
+	idempotencyMiddleware := grpcmiddleware2.NewIdempotencyMiddleware(idempotencyRepository, config.DurationFromEnv("IDEMPOTENCY_KEY_TTL", time.Hour*24))
+	go job.RunIdempotencyKeySweeper(ctx, idempotencyRepository, time.Hour)
 
 	authRepository := repository.NewAuthRepository(db)
-	authService := service.NewAuthService(authRepository, cacheService)
+	refreshTokenRepository := repository.NewRefreshTokenRepository(db)
+	authService := service.NewAuthService(
+		authRepository,
+		refreshTokenRepository,
+		revocationStore,
+		transactionManager,
+		mailer.NewMailerFromEnv(),
+		os.Getenv("REQUIRE_EMAIL_VERIFICATION") == "true",
+	)
 	authHandler := handler.NewAuthHandler(authService)
 
+	blobStore, err := storage.NewBlobStoreFromEnv()
+	if err != nil {
+		log.Panicf("Error configuring storage: %v", err)
+	}
+
+	imageConfig, err := imaging.ConfigFromEnv()
+	if err != nil {
+		log.Panicf("Error configuring image processing: %v", err)
+	}
+	storeCurrency, err := money.StoreCurrencyFromEnv()
+	if err != nil {
+		log.Panicf("Error configuring store currency: %v", err)
+	}
+	paymentGateway, err := paymentgateway.NewGatewayFromEnv()
+	if err != nil {
+		log.Panicf("Error configuring payment gateway: %v", err)
+	}
+
 	productRepository := repository.NewProductRepository(db)
-	productService := service.NewProductService(productRepository)
+	categoryRepository := repository.NewCategoryRepository(db)
+	variantRepository := repository.NewProductVariantRepository(db)
+	inventoryRepository := repository.NewInventoryRepository(db)
+	uploadRepository := repository.NewUploadRepository(db)
+	productService := service.NewProductService(productRepository, categoryRepository, variantRepository, inventoryRepository, uploadRepository, transactionManager, blobStore, imageConfig.VariantNames(), storeCurrency)
 	productHandler := handler.NewProductHandler(productService)
+	go job.RunUploadReaper(ctx, uploadRepository, blobStore, imageConfig.VariantNames(), config.DurationFromEnv("UPLOAD_GRACE_PERIOD", time.Hour*24), time.Hour)
+
+	categoryService := service.NewCategoryService(categoryRepository, transactionManager)
+	categoryHandler := handler.NewCategoryHandler(categoryService)
+
+	inventoryService := service.NewInventoryService(inventoryRepository, productRepository, variantRepository)
+	inventoryHandler := handler.NewInventoryHandler(inventoryService)
+
+	cartRepository := repository.NewCartRepository(db)
+	cartService := service.NewCartService(cartRepository, productRepository, variantRepository, blobStore, storeCurrency, imageConfig.ThumbnailVariant())
+	cartHandler := handler.NewCartHandler(cartService)
+
+	orderRepository := repository.NewOrderRepository(db)
+	orderService := service.NewOrderService(orderRepository, cartRepository, productRepository, variantRepository, inventoryRepository, transactionManager, storeCurrency)
+	orderHandler := handler.NewOrderHandler(orderService)
+	go job.RunReservationExpiry(ctx, orderService, time.Minute)
+
+	paymentRepository := repository.NewPaymentRepository(db)
+	paymentService := service.NewPaymentService(
+		paymentRepository,
+		orderRepository,
+		orderService,
+		paymentGateway,
+		transactionManager,
+	)
+	paymentHandler := handler.NewPaymentHandler(paymentService)
+
+	roleService := service.NewRoleService(roleRepository, authRepository, permissionResolver, transactionManager)
+	roleHandler := handler.NewRoleHandler(roleService)
 
 	serv := grpc.NewServer(
 		grpc.ChainUnaryInterceptor(
 			grpcmiddleware2.ErrorMiddleware,
 			authMiddleware.Middleware,
+			idempotencyMiddleware.Middleware,
 		),
 	)
 
 	auth.RegisterAuthServiceServer(serv, authHandler)
 	product.RegisterProductServiceServer(serv, productHandler)
+	category.RegisterCategoryServiceServer(serv, categoryHandler)
+	role.RegisterRoleServiceServer(serv, roleHandler)
+	cart.RegisterCartServiceServer(serv, cartHandler)
+	order.RegisterOrderServiceServer(serv, orderHandler)
+	inventory.RegisterInventoryServiceServer(serv, inventoryHandler)
+	payment.RegisterPaymentServiceServer(serv, paymentHandler)
 
 	if os.Getenv("ENVIRONMENT") == "dev" {
 		reflection.Register(serv)
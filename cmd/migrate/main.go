@@ -0,0 +1,95 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"strconv"
+
+	"github.com/aldngrha/ecommerce-be/pkg/database"
+	"github.com/joho/godotenv"
+)
+
+const usage = `Usage: migrate <command>
+
+Commands:
+  up            apply all pending migrations
+  down [steps]  roll back the last migration, or the last steps migrations
+  status        list migrations and when they were applied
+  to <version>  migrate up or down to version, 0 rolls back everything`
+
+func main() {
+	flag.Usage = func() {
+		fmt.Fprintln(os.Stderr, usage)
+	}
+	flag.Parse()
+	args := flag.Args()
+	if len(args) == 0 {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	ctx := context.Background()
+	godotenv.Load()
+
+	db := database.ConnectionDB(ctx, os.Getenv("DB_URI"))
+	defer db.Close()
+
+	migrator, err := database.NewMigrator(db)
+	if err != nil {
+		log.Fatalf("Error loading migrations: %v", err)
+	}
+
+	switch args[0] {
+	case "up":
+		count, err := migrator.Up(ctx)
+		if err != nil {
+			log.Fatalf("Error migrating up: %v", err)
+		}
+		log.Printf("Applied %d migrations", count)
+	case "down":
+		steps := 1
+		if len(args) > 1 {
+			steps, err = strconv.Atoi(args[1])
+			if err != nil || steps < 1 {
+				log.Fatalf("Invalid steps %q", args[1])
+			}
+		}
+		count, err := migrator.Down(ctx, steps)
+		if err != nil {
+			log.Fatalf("Error migrating down: %v", err)
+		}
+		log.Printf("Rolled back %d migrations", count)
+	case "to":
+		if len(args) < 2 {
+			flag.Usage()
+			os.Exit(2)
+		}
+		version, err := strconv.ParseInt(args[1], 10, 64)
+		if err != nil || version < 0 {
+			log.Fatalf("Invalid version %q", args[1])
+		}
+		count, err := migrator.To(ctx, version)
+		if err != nil {
+			log.Fatalf("Error migrating to %d: %v", version, err)
+		}
+		log.Printf("Ran %d migrations", count)
+	case "status":
+		statuses, err := migrator.Status(ctx)
+		if err != nil {
+			log.Fatalf("Error reading migration status: %v", err)
+		}
+		for _, status := range statuses {
+			appliedAt := "pending"
+			if status.AppliedAt != nil {
+				appliedAt = status.AppliedAt.Format("2006-01-02 15:04:05")
+			}
+			fmt.Printf("%06d  %-45s  %s\n", status.Version, status.Name, appliedAt)
+		}
+	default:
+		flag.Usage()
+		os.Exit(2)
+	}
+}
@@ -1,50 +1,130 @@
 package main
 
 import (
+	"context"
 	"log"
-	"mime"
-	"net/http"
 	"os"
-	"path/filepath"
+	"time"
 
+	"github.com/aldngrha/ecommerce-be/internal/config"
+	"github.com/aldngrha/ecommerce-be/internal/entity"
+	"github.com/aldngrha/ecommerce-be/internal/gateway"
+	"github.com/aldngrha/ecommerce-be/internal/grpcmiddleware"
 	"github.com/aldngrha/ecommerce-be/internal/handler"
+	"github.com/aldngrha/ecommerce-be/internal/imaging"
+	"github.com/aldngrha/ecommerce-be/internal/mailer"
+	"github.com/aldngrha/ecommerce-be/internal/money"
+	paymentgateway "github.com/aldngrha/ecommerce-be/internal/payment"
+	"github.com/aldngrha/ecommerce-be/internal/repository"
+	"github.com/aldngrha/ecommerce-be/internal/restmiddleware"
+	"github.com/aldngrha/ecommerce-be/internal/service"
+	"github.com/aldngrha/ecommerce-be/internal/storage"
+	"github.com/aldngrha/ecommerce-be/pkg/database"
 	"github.com/gofiber/fiber/v2"
 	"github.com/gofiber/fiber/v2/middleware/cors"
+	"github.com/joho/godotenv"
 )
 
-func handleGetFilename(c *fiber.Ctx) error {
-	fileNameParams := c.Params("filename")
-	filePath := filepath.Join("storage", "images", "products", fileNameParams)
+func main() {
+	ctx := context.Background()
+	godotenv.Load()
 
-	if _, err := os.Stat(filePath); err != nil {
-		if os.IsNotExist(err) {
-			return c.Status(http.StatusNotFound).SendString("File not found")
-		}
+	db := database.ConnectionDB(ctx, os.Getenv("DB_URI"))
+	log.Println("Connected to database successfully")
 
-		return c.Status(http.StatusInternalServerError).SendString("Internal server error")
-	}
+	transactionManager := repository.NewTransactionManager(db)
+
+	revocationStore := repository.NewTokenRevocationStore(db)
+	roleRepository := repository.NewRoleRepository(db)
+	permissionResolver := service.NewPermissionResolver(roleRepository)
+	tokenAuthenticator := service.NewTokenAuthenticator(revocationStore, permissionResolver)
+	authMiddleware := restmiddleware.NewAuthMiddleware(tokenAuthenticator)
 
-	// open file
-	file, err := os.Open(filePath)
+	blobStore, err := storage.NewBlobStoreFromEnv()
+	if err != nil {
+		log.Panicf("Error configuring storage: %v", err)
+	}
+	imageConfig, err := imaging.ConfigFromEnv()
+	if err != nil {
+		log.Panicf("Error configuring image processing: %v", err)
+	}
+	storeCurrency, err := money.StoreCurrencyFromEnv()
+	if err != nil {
+		log.Panicf("Error configuring store currency: %v", err)
+	}
+	paymentGateway, err := paymentgateway.NewGatewayFromEnv()
 	if err != nil {
-		log.Println(err)
-		return c.Status(http.StatusInternalServerError).SendString("Internal server error")
+		log.Panicf("Error configuring payment gateway: %v", err)
 	}
 
-	ext := filepath.Ext(filePath)
-	mimeType := mime.TypeByExtension(ext)
+	uploadLimits := service.UploadLimits{
+		MaxBytes:        config.Int64FromEnv("UPLOAD_MAX_BYTES", 5<<20),
+		DailyCountQuota: int(config.Int64FromEnv("UPLOAD_DAILY_COUNT_QUOTA", 200)),
+		DailyBytesQuota: config.Int64FromEnv("UPLOAD_DAILY_BYTES_QUOTA", 500<<20),
+	}
+	uploadRepository := repository.NewUploadRepository(db)
+	uploadService := service.NewUploadService(uploadRepository, blobStore, imaging.NewPipeline(imageConfig), uploadLimits)
+	productImageHandler := handler.NewProductImageHandler(uploadService, blobStore, uploadLimits.MaxBytes)
 
-	c.Set("Content-Type", mimeType)
-	return c.SendStream(file)
-}
+	authRepository := repository.NewAuthRepository(db)
+	authService := service.NewAuthService(
+		authRepository,
+		repository.NewRefreshTokenRepository(db),
+		revocationStore,
+		transactionManager,
+		mailer.NewMailerFromEnv(),
+		os.Getenv("REQUIRE_EMAIL_VERIFICATION") == "true",
+	)
 
-func main() {
+	productRepository := repository.NewProductRepository(db)
+	categoryRepository := repository.NewCategoryRepository(db)
+	variantRepository := repository.NewProductVariantRepository(db)
+	inventoryRepository := repository.NewInventoryRepository(db)
+	productService := service.NewProductService(productRepository, categoryRepository, variantRepository, inventoryRepository, uploadRepository, transactionManager, blobStore, imageConfig.VariantNames(), storeCurrency)
+
+	cartRepository := repository.NewCartRepository(db)
+	orderRepository := repository.NewOrderRepository(db)
+	orderService := service.NewOrderService(orderRepository, cartRepository, productRepository, variantRepository, inventoryRepository, transactionManager, storeCurrency)
 
-	app := fiber.New()
+	paymentService := service.NewPaymentService(
+		repository.NewPaymentRepository(db),
+		orderRepository,
+		orderService,
+		paymentGateway,
+		transactionManager,
+	)
+
+	// JSON routes for browser clients, running through the gRPC server's interceptors
+	apiGateway := gateway.New(
+		grpcmiddleware.ErrorMiddleware,
+		grpcmiddleware.NewAuthMiddleware(tokenAuthenticator).Middleware,
+		grpcmiddleware.NewIdempotencyMiddleware(repository.NewIdempotencyRepository(db), config.DurationFromEnv("IDEMPOTENCY_KEY_TTL", time.Hour*24)).Middleware,
+	)
+	gateway.RegisterAuthService(apiGateway, handler.NewAuthHandler(authService))
+	gateway.RegisterProductService(apiGateway, handler.NewProductHandler(productService))
+	gateway.RegisterCategoryService(apiGateway, handler.NewCategoryHandler(service.NewCategoryService(categoryRepository, transactionManager)))
+	gateway.RegisterInventoryService(apiGateway, handler.NewInventoryHandler(service.NewInventoryService(inventoryRepository, productRepository, variantRepository)))
+	gateway.RegisterCartService(apiGateway, handler.NewCartHandler(service.NewCartService(cartRepository, productRepository, variantRepository, blobStore, storeCurrency, imageConfig.ThumbnailVariant())))
+	gateway.RegisterOrderService(apiGateway, handler.NewOrderHandler(orderService))
+	gateway.RegisterPaymentService(apiGateway, handler.NewPaymentHandler(paymentService))
+	gateway.RegisterRoleService(apiGateway, handler.NewRoleHandler(service.NewRoleService(roleRepository, authRepository, permissionResolver, transactionManager)))
+
+	paymentWebhookHandler := handler.NewPaymentWebhookHandler(paymentService)
+
+	app := fiber.New(fiber.Config{
+		// room for the multipart envelope around the largest allowed image
+		BodyLimit: int(uploadLimits.MaxBytes) + 1<<20,
+	})
 
 	app.Use(cors.New())
-	app.Get("/storage/images/products/:filename", handleGetFilename)
-	app.Post("/products/upload", handler.UploadProductImageHandler)
+	app.Get("/storage/images/products/:filename", productImageHandler.Serve)
+	app.Post("/products/upload", authMiddleware.Require(entity.PermissionProductWrite), productImageHandler.Upload)
+	app.Post("/payments/webhook", paymentWebhookHandler.Handle)
+	apiGroup := app.Group("/api")
+	apiGateway.Mount(apiGroup)
+	if err = apiGateway.MountDocs(apiGroup, "ecommerce-be API", "1.0.0", "/api"); err != nil {
+		log.Panicf("Error generating API reference: %v", err)
+	}
 
 	app.Listen(":3000")
 
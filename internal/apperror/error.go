@@ -0,0 +1,131 @@
+package apperror
+
+import (
+	"fmt"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Domain is reported in google.rpc.ErrorInfo so clients know which service produced the reason.
+const Domain = "ecommerce-be"
+
+type FieldViolation struct {
+	Field       string
+	Description string
+}
+
+// Error is the domain error returned by services and middlewares. Code decides the gRPC
+// status, Reason is a stable UPPER_SNAKE_CASE identifier clients can switch on, and
+// Message is safe to show to the caller. Err keeps the underlying cause for logs only.
+type Error struct {
+	Code            codes.Code
+	Reason          string
+	Message         string
+	Metadata        map[string]string
+	FieldViolations []FieldViolation
+	Err             error
+}
+
+func (e *Error) Error() string {
+	if e.Err != nil {
+		return fmt.Sprintf("%s: %s: %v", e.Reason, e.Message, e.Err)
+	}
+
+	return fmt.Sprintf("%s: %s", e.Reason, e.Message)
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// GRPCStatus lets status.FromError and grpc-go convert the error without losing its details.
+func (e *Error) GRPCStatus() *status.Status {
+	st := status.New(e.Code, e.Message)
+
+	details := []*errdetails.ErrorInfo{{
+		Reason:   e.Reason,
+		Domain:   Domain,
+		Metadata: e.Metadata,
+	}}
+
+	if len(e.FieldViolations) > 0 {
+		badRequest := &errdetails.BadRequest{}
+		for _, violation := range e.FieldViolations {
+			badRequest.FieldViolations = append(badRequest.FieldViolations, &errdetails.BadRequest_FieldViolation{
+				Field:       violation.Field,
+				Description: violation.Description,
+			})
+		}
+
+		if withDetails, err := st.WithDetails(details[0], badRequest); err == nil {
+			return withDetails
+		}
+		return st
+	}
+
+	if withDetails, err := st.WithDetails(details[0]); err == nil {
+		return withDetails
+	}
+
+	return st
+}
+
+// WithMetadata returns the error with an extra ErrorInfo metadata entry.
+func (e *Error) WithMetadata(key string, value string) *Error {
+	metadata := make(map[string]string, len(e.Metadata)+1)
+	for k, v := range e.Metadata {
+		metadata[k] = v
+	}
+	metadata[key] = value
+	e.Metadata = metadata
+
+	return e
+}
+
+// Wrap attaches the underlying cause, which is logged but never sent to the client.
+func (e *Error) Wrap(err error) *Error {
+	e.Err = err
+
+	return e
+}
+
+func New(code codes.Code, reason string, message string) *Error {
+	return &Error{
+		Code:    code,
+		Reason:  reason,
+		Message: message,
+	}
+}
+
+func InvalidArgument(reason string, message string, violations ...FieldViolation) *Error {
+	err := New(codes.InvalidArgument, reason, message)
+	err.FieldViolations = violations
+
+	return err
+}
+
+func NotFound(reason string, message string) *Error {
+	return New(codes.NotFound, reason, message)
+}
+
+func AlreadyExists(reason string, message string) *Error {
+	return New(codes.AlreadyExists, reason, message)
+}
+
+func FailedPrecondition(reason string, message string) *Error {
+	return New(codes.FailedPrecondition, reason, message)
+}
+
+func Unauthenticated(reason string, message string) *Error {
+	return New(codes.Unauthenticated, reason, message)
+}
+
+func PermissionDenied(reason string, message string) *Error {
+	return New(codes.PermissionDenied, reason, message)
+}
+
+func Internal(reason string, message string) *Error {
+	return New(codes.Internal, reason, message)
+}
@@ -0,0 +1,21 @@
+package entity
+
+import "time"
+
+type Cart struct {
+	Id        string
+	UserId    string
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+type CartItem struct {
+	Id        string
+	CartId    string
+	ProductId string
+	// set when the product is sold in variants
+	VariantId *string
+	Quantity  int32
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
@@ -0,0 +1,15 @@
+package entity
+
+import "time"
+
+// Category is a node of the category tree. ParentId is nil for top level categories.
+type Category struct {
+	Id        string
+	ParentId  *string
+	Name      string
+	Slug      string
+	CreatedAt time.Time
+	CreatedBy string
+	UpdatedAt *time.Time
+	UpdatedBy *string
+}
@@ -0,0 +1,22 @@
+package entity
+
+import "time"
+
+const (
+	IdempotencyStatusInProgress = "in_progress"
+	IdempotencyStatusCompleted  = "completed"
+)
+
+// IdempotencyKey records the outcome of a request made with an idempotency-key header,
+// scoped by the calling user and RPC method.
+type IdempotencyKey struct {
+	UserId       string
+	Method       string
+	Key          string
+	RequestHash  string
+	Status       string
+	ResponseType *string
+	Response     []byte
+	CreatedAt    time.Time
+	ExpiresAt    time.Time
+}
@@ -0,0 +1,41 @@
+package entity
+
+import "time"
+
+const (
+	ReservationStatusActive    = "active"
+	ReservationStatusCommitted = "committed"
+	ReservationStatusReleased  = "released"
+	ReservationStatusRestocked = "restocked"
+)
+
+// Inventory is the stock level of a product, or of one of its variants when VariantId is
+// set. Reserved units belong to unpaid orders and are not available for new checkouts, but
+// are still physically on hand.
+type Inventory struct {
+	ProductId string
+	VariantId *string
+	OnHand    int32
+	Reserved  int32
+	UpdatedAt time.Time
+	UpdatedBy *string
+}
+
+func (i *Inventory) Available() int32 {
+	return i.OnHand - i.Reserved
+}
+
+// StockReservation holds units of a product for an order until it is paid (committed),
+// cancelled or left unpaid past ExpiresAt (released). Committed units of an order refunded
+// before it was fulfilled go back on hand (restocked).
+type StockReservation struct {
+	Id         string
+	OrderId    string
+	ProductId  string
+	VariantId  *string
+	Quantity   int32
+	Status     string
+	ExpiresAt  time.Time
+	CreatedAt  time.Time
+	ResolvedAt *time.Time
+}
@@ -0,0 +1,54 @@
+package jwt
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// EmailVerificationClaims is carried by the signed link sent after Register. It binds
+// the user to the address the link was sent to, so changing the email invalidates it.
+type EmailVerificationClaims struct {
+	jwt.RegisteredClaims
+	Email string `json:"email"`
+}
+
+// verification tokens use their own key so they can never be accepted as access tokens
+func emailVerificationKey() []byte {
+	return []byte(os.Getenv("JWT_SECRET") + ":email-verification")
+}
+
+func NewEmailVerificationToken(userId string, email string, ttl time.Duration) (string, error) {
+	now := time.Now()
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, EmailVerificationClaims{
+		RegisteredClaims: jwt.RegisteredClaims{
+			Subject:   userId,
+			IssuedAt:  jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(now.Add(ttl)),
+		},
+		Email: email,
+	})
+
+	return token.SignedString(emailVerificationKey())
+}
+
+func GetEmailVerificationClaims(tokenStr string) (*EmailVerificationClaims, error) {
+	token, err := jwt.ParseWithClaims(tokenStr, &EmailVerificationClaims{}, func(token *jwt.Token) (interface{}, error) {
+		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+			return nil, fmt.Errorf("unexpected signing method %v", token.Header["alg"])
+		}
+		return emailVerificationKey(), nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	claims, ok := token.Claims.(*EmailVerificationClaims)
+	if !ok || !token.Valid {
+		return nil, fmt.Errorf("verification token is not valid")
+	}
+
+	return claims, nil
+}
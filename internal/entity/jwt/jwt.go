@@ -3,10 +3,10 @@ package jwt
 import (
 	"context"
 	"fmt"
+	"github.com/aldngrha/ecommerce-be/internal/apperror"
 	"github.com/golang-jwt/jwt/v5"
-	"google.golang.org/grpc/codes"
-	"google.golang.org/grpc/status"
 	"os"
+	"slices"
 )
 
 type JwtEntityContextKey string
@@ -18,6 +18,12 @@ type JwtClaims struct {
 	Email    string `json:"email"`
 	FullName string `json:"full_name"`
 	Role     string `json:"role"`
+	// Permissions is resolved from the role by the auth middleware, it is never part of the token
+	Permissions []string `json:"-"`
+}
+
+func (jc *JwtClaims) HasPermission(permission string) bool {
+	return slices.Contains(jc.Permissions, permission)
 }
 
 func (jc *JwtClaims) SendToContext(ctx context.Context) context.Context {
@@ -35,25 +41,25 @@ func GetClaimsFromToken(jwtToken string) (*JwtClaims, error) {
 	})
 
 	if err != nil {
-		return nil, status.Errorf(codes.Unauthenticated, "invalid token: %v", err)
+		return nil, apperror.Unauthenticated("INVALID_TOKEN", "invalid token").Wrap(err)
 	}
 
 	if !tokenClaims.Valid {
-		return nil, status.Errorf(codes.Unauthenticated, "token is not valid")
+		return nil, apperror.Unauthenticated("INVALID_TOKEN", "token is not valid")
 	}
 
 	if claims, ok := tokenClaims.Claims.(*JwtClaims); ok {
 		return claims, nil
 	}
 
-	return nil, status.Errorf(codes.Unauthenticated, "token is not valid")
+	return nil, apperror.Unauthenticated("INVALID_TOKEN", "token is not valid")
 }
 
 func GetClaimsFromContext(ctx context.Context) (*JwtClaims, error) {
 	claims, ok := ctx.Value(JwtEntityContextKeyValue).(*JwtClaims)
 
 	if !ok {
-		return nil, status.Errorf(codes.Unauthenticated, "unauthenticated: no JWT claims found in context")
+		return nil, apperror.Unauthenticated("MISSING_CLAIMS", "unauthenticated: no JWT claims found in context")
 	}
 
 	return claims, nil
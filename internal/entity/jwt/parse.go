@@ -2,35 +2,43 @@ package jwt
 
 import (
 	"context"
-	"google.golang.org/grpc/codes"
+	"github.com/aldngrha/ecommerce-be/internal/apperror"
 	"google.golang.org/grpc/metadata"
-	"google.golang.org/grpc/status"
 	"strings"
 )
 
 func ParseTokenFromContext(ctx context.Context) (string, error) {
 	md, ok := metadata.FromIncomingContext(ctx)
 	if !ok {
-		return "", status.Errorf(codes.Unauthenticated, "no metadata found in context")
+		return "", apperror.Unauthenticated("MISSING_TOKEN", "no metadata found in context")
 	}
 
 	bearerToken, ok := md["authorization"]
 	if !ok {
-		return "", status.Errorf(codes.Unauthenticated, "no authorization token found in metadata")
+		return "", apperror.Unauthenticated("MISSING_TOKEN", "no authorization token found in metadata")
 	}
 
 	if len(bearerToken) == 0 {
-		return "", status.Errorf(codes.Unauthenticated, "authorization token is empty")
+		return "", apperror.Unauthenticated("MISSING_TOKEN", "authorization token is empty")
 	}
 
-	tokenSplit := strings.Split(bearerToken[0], " ")
+	return ParseBearerToken(bearerToken[0])
+}
+
+// ParseBearerToken extracts the token from an Authorization header value.
+func ParseBearerToken(header string) (string, error) {
+	if header == "" {
+		return "", apperror.Unauthenticated("MISSING_TOKEN", "no authorization token found")
+	}
+
+	tokenSplit := strings.Split(header, " ")
 
 	if len(tokenSplit) != 2 {
-		return "", status.Errorf(codes.Unauthenticated, "invalid authorization token format")
+		return "", apperror.Unauthenticated("MALFORMED_TOKEN", "invalid authorization token format")
 	}
 
 	if tokenSplit[0] != "Bearer" {
-		return "", status.Errorf(codes.Unauthenticated, "authorization token must start with Bearer")
+		return "", apperror.Unauthenticated("MALFORMED_TOKEN", "authorization token must start with Bearer")
 
 	}
 	return tokenSplit[1], nil
@@ -0,0 +1,76 @@
+package entity
+
+import (
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/aldngrha/ecommerce-be/internal/apperror"
+	"github.com/aldngrha/ecommerce-be/internal/money"
+)
+
+type OrderStatus string
+
+const (
+	OrderStatusPendingPayment OrderStatus = "pending_payment"
+	OrderStatusPaid           OrderStatus = "paid"
+	OrderStatusFulfilled      OrderStatus = "fulfilled"
+	OrderStatusDelivered      OrderStatus = "delivered"
+	OrderStatusCancelled      OrderStatus = "cancelled"
+	OrderStatusRefunded       OrderStatus = "refunded"
+)
+
+// orderTransitions is the order state machine. Statuses missing as keys are final. Only
+// unpaid orders can be cancelled, a paid order is voided by refunding it.
+var orderTransitions = map[OrderStatus][]OrderStatus{
+	OrderStatusPendingPayment: {OrderStatusPaid, OrderStatusCancelled},
+	OrderStatusPaid:           {OrderStatusFulfilled, OrderStatusRefunded},
+	OrderStatusFulfilled:      {OrderStatusDelivered, OrderStatusRefunded},
+	OrderStatusDelivered:      {OrderStatusRefunded},
+}
+
+func (s OrderStatus) CanTransitionTo(next OrderStatus) bool {
+	return slices.Contains(orderTransitions[s], next)
+}
+
+type Order struct {
+	Id           string
+	UserId       string
+	Status       OrderStatus
+	Total        money.Money
+	Items        []*OrderItem
+	CancelReason *string
+	CreatedAt    time.Time
+	CreatedBy    string
+	UpdatedAt    time.Time
+	UpdatedBy    *string
+}
+
+// TransitionTo moves the order to next, rejecting moves the state machine does not allow.
+func (o *Order) TransitionTo(next OrderStatus) error {
+	if !o.Status.CanTransitionTo(next) {
+		return apperror.FailedPrecondition(
+			"ILLEGAL_ORDER_TRANSITION",
+			fmt.Sprintf("order cannot move from %s to %s", o.Status, next),
+		).WithMetadata("from", string(o.Status)).WithMetadata("to", string(next))
+	}
+
+	o.Status = next
+
+	return nil
+}
+
+// OrderItem snapshots the product name, variant and unit price at checkout, so later
+// product edits never change what the customer bought.
+type OrderItem struct {
+	Id          string
+	OrderId     string
+	ProductId   string
+	ProductName string
+	VariantId   *string
+	Sku         *string
+	VariantName *string
+	UnitPrice   money.Money
+	Quantity    int32
+	Subtotal    money.Money
+}
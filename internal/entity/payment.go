@@ -0,0 +1,35 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/aldngrha/ecommerce-be/internal/money"
+)
+
+type PaymentIntentStatus string
+
+const (
+	PaymentIntentStatusPending    PaymentIntentStatus = "pending"
+	PaymentIntentStatusAuthorized PaymentIntentStatus = "authorized"
+	PaymentIntentStatusCaptured   PaymentIntentStatus = "captured"
+	PaymentIntentStatusFailed     PaymentIntentStatus = "failed"
+	PaymentIntentStatusRefunded   PaymentIntentStatus = "refunded"
+	// the refund was requested from the provider, whose outcome may not be known yet
+	PaymentIntentStatusRefundPending PaymentIntentStatus = "refund_pending"
+)
+
+// PaymentIntent is one attempt to pay an order through a payment provider. Failed attempts
+// are kept, so an order can have several intents but at most one that is not failed.
+type PaymentIntent struct {
+	Id                string
+	OrderId           string
+	Amount            money.Money
+	Status            PaymentIntentStatus
+	Provider          string
+	ProviderReference *string
+	FailureReason     *string
+	CreatedAt         time.Time
+	CreatedBy         string
+	UpdatedAt         time.Time
+	UpdatedBy         *string
+}
@@ -0,0 +1,17 @@
+package entity
+
+const (
+	PermissionProductWrite       = "product:write"
+	PermissionProductDelete      = "product:delete"
+	PermissionProductReadDeleted = "product:read_deleted"
+	PermissionOrderRefund        = "order:refund"
+	PermissionOrderManage        = "order:manage"
+	PermissionInventoryManage    = "inventory:manage"
+	PermissionRoleManage         = "role:manage"
+	PermissionCategoryManage     = "category:manage"
+)
+
+type Permission struct {
+	Code        string
+	Description string
+}
@@ -1,12 +1,16 @@
 package entity
 
-import "time"
+import (
+	"time"
+
+	"github.com/aldngrha/ecommerce-be/internal/money"
+)
 
 type Product struct {
 	Id            string
 	Name          string
 	Description   string
-	Price         float64
+	Price         money.Money
 	ImageFileName string
 	CreatedAt     time.Time
 	CreatedBy     string
@@ -0,0 +1,68 @@
+package entity
+
+import (
+	"strings"
+	"time"
+
+	"github.com/aldngrha/ecommerce-be/internal/money"
+)
+
+// ProductOption is an option type of a product, such as "Size" or "Color".
+type ProductOption struct {
+	Id        string
+	ProductId string
+	Name      string
+	Position  int32
+	Values    []*ProductOptionValue
+}
+
+type ProductOptionValue struct {
+	Id       string
+	OptionId string
+	Value    string
+	Position int32
+}
+
+// ProductVariant is a sellable combination of option values with its own SKU and stock.
+// Price and ImageFileName are overrides; when nil the product's own value applies.
+type ProductVariant struct {
+	Id            string
+	ProductId     string
+	Sku           string
+	Price         *money.Money
+	ImageFileName *string
+	Position      int32
+	// one value per option, in option order
+	OptionValues []*ProductOptionValue
+	CreatedAt    time.Time
+	CreatedBy    string
+	UpdatedAt    *time.Time
+	UpdatedBy    *string
+	IsDeleted    bool
+}
+
+func (v *ProductVariant) EffectivePrice(product *Product) money.Money {
+	if v.Price != nil {
+		return *v.Price
+	}
+
+	return product.Price
+}
+
+func (v *ProductVariant) EffectiveImageFileName(product *Product) string {
+	if v.ImageFileName != nil {
+		return *v.ImageFileName
+	}
+
+	return product.ImageFileName
+}
+
+// Name joins the option values, e.g. "M / Red".
+func (v *ProductVariant) Name() string {
+	values := make([]string, 0, len(v.OptionValues))
+	for _, optionValue := range v.OptionValues {
+		values = append(values, optionValue.Value)
+	}
+
+	return strings.Join(values, " / ")
+}
@@ -0,0 +1,16 @@
+package entity
+
+import "time"
+
+// RefreshToken is a single link in a rotation chain. Every token issued from the same
+// login shares a FamilyId, so reusing any consumed token can revoke the whole chain.
+type RefreshToken struct {
+	Id        string
+	UserId    string
+	FamilyId  string
+	TokenHash string
+	ExpiresAt time.Time
+	CreatedAt time.Time
+	UsedAt    *time.Time
+	RevokedAt *time.Time
+}
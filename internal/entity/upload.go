@@ -0,0 +1,25 @@
+package entity
+
+import "time"
+
+const (
+	// uploaded but not referenced by any product yet
+	UploadStatusPending  = "pending"
+	UploadStatusAttached = "attached"
+	// no longer referenced since ReleasedAt, for example replaced by EditProduct
+	UploadStatusOrphaned = "orphaned"
+	UploadStatusDeleted  = "deleted"
+)
+
+// Upload records a file a user uploaded, for quotas and cleanup.
+type Upload struct {
+	Id          string
+	UserId      string
+	FileName    string
+	ContentType string
+	SizeBytes   int64
+	Status      string
+	CreatedAt   time.Time
+	ReleasedAt  *time.Time
+	DeletedAt   *time.Time
+}
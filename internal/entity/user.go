@@ -8,29 +8,44 @@ const (
 )
 
 type Role struct {
-	Id        string
-	Name      string
-	Code      string
-	CreatedAt time.Time
-	CreatedBy *string
-	UpdatedAt time.Time
-	UpdatedBy *string
-	DeletedAt *time.Time
-	DeletedBy *string
-	IsDeleted bool
+	Id          string
+	Name        string
+	Code        string
+	Permissions []string
+	CreatedAt   time.Time
+	CreatedBy   *string
+	UpdatedAt   time.Time
+	UpdatedBy   *string
+	DeletedAt   *time.Time
+	DeletedBy   *string
+	IsDeleted   bool
 }
 
 type User struct {
+	Id              string
+	FullName        string
+	Email           string
+	Password        string
+	RoleCode        string
+	EmailVerifiedAt *time.Time
+	CreatedAt       time.Time
+	CreatedBy       *string
+	UpdatedAt       time.Time
+	UpdatedBy       *string
+	DeletedAt       *time.Time
+	DeletedBy       *string
+	IsDeleted       bool
+}
+
+func (u *User) IsEmailVerified() bool {
+	return u.EmailVerifiedAt != nil
+}
+
+type PasswordResetToken struct {
 	Id        string
-	FullName  string
-	Email     string
-	Password  string
-	RoleCode  string
+	UserId    string
+	TokenHash string
+	ExpiresAt time.Time
 	CreatedAt time.Time
-	CreatedBy *string
-	UpdatedAt time.Time
-	UpdatedBy *string
-	DeletedAt *time.Time
-	DeletedBy *string
-	IsDeleted bool
+	UsedAt    *time.Time
 }
@@ -0,0 +1,20 @@
+package gateway
+
+import (
+	"github.com/aldngrha/ecommerce-be/pb/auth"
+	"github.com/gofiber/fiber/v2"
+)
+
+// RegisterAuthService exposes AuthService under /auth.
+func RegisterAuthService(g *Gateway, server auth.AuthServiceServer) {
+	handle(g, fiber.MethodPost, "/auth/register", auth.AuthService_Register_FullMethodName, server.Register)
+	handle(g, fiber.MethodPost, "/auth/login", auth.AuthService_Login_FullMethodName, server.Login)
+	handle(g, fiber.MethodPost, "/auth/logout", auth.AuthService_Logout_FullMethodName, server.Logout)
+	handle(g, fiber.MethodPost, "/auth/change-password", auth.AuthService_ChangePassword_FullMethodName, server.ChangePassword)
+	handle(g, fiber.MethodGet, "/auth/profile", auth.AuthService_GetProfile_FullMethodName, server.GetProfile)
+	handle(g, fiber.MethodPost, "/auth/refresh-token", auth.AuthService_RefreshToken_FullMethodName, server.RefreshToken)
+	handle(g, fiber.MethodPost, "/auth/password-reset/request", auth.AuthService_RequestPasswordReset_FullMethodName, server.RequestPasswordReset)
+	handle(g, fiber.MethodPost, "/auth/password-reset/confirm", auth.AuthService_ConfirmPasswordReset_FullMethodName, server.ConfirmPasswordReset)
+	handle(g, fiber.MethodPost, "/auth/verify-email", auth.AuthService_VerifyEmail_FullMethodName, server.VerifyEmail)
+	handle(g, fiber.MethodPost, "/auth/resend-verification", auth.AuthService_ResendVerification_FullMethodName, server.ResendVerification)
+}
@@ -0,0 +1,15 @@
+package gateway
+
+import (
+	"github.com/aldngrha/ecommerce-be/pb/cart"
+	"github.com/gofiber/fiber/v2"
+)
+
+// RegisterCartService exposes CartService under /cart.
+func RegisterCartService(g *Gateway, server cart.CartServiceServer) {
+	handle(g, fiber.MethodGet, "/cart", cart.CartService_GetCart_FullMethodName, server.GetCart)
+	handle(g, fiber.MethodDelete, "/cart", cart.CartService_ClearCart_FullMethodName, server.ClearCart)
+	handle(g, fiber.MethodPost, "/cart/items", cart.CartService_AddItem_FullMethodName, server.AddItem)
+	handle(g, fiber.MethodPut, "/cart/items/:product_id", cart.CartService_UpdateQuantity_FullMethodName, server.UpdateQuantity)
+	handle(g, fiber.MethodDelete, "/cart/items/:product_id", cart.CartService_RemoveItem_FullMethodName, server.RemoveItem)
+}
@@ -0,0 +1,14 @@
+package gateway
+
+import (
+	"github.com/aldngrha/ecommerce-be/pb/category"
+	"github.com/gofiber/fiber/v2"
+)
+
+// RegisterCategoryService exposes CategoryService under /categories.
+func RegisterCategoryService(g *Gateway, server category.CategoryServiceServer) {
+	handle(g, fiber.MethodGet, "/categories", category.CategoryService_ListCategories_FullMethodName, server.ListCategories)
+	handle(g, fiber.MethodPost, "/categories", category.CategoryService_CreateCategory_FullMethodName, server.CreateCategory)
+	handle(g, fiber.MethodPut, "/categories/:id", category.CategoryService_EditCategory_FullMethodName, server.EditCategory)
+	handle(g, fiber.MethodDelete, "/categories/:id", category.CategoryService_DeleteCategory_FullMethodName, server.DeleteCategory)
+}
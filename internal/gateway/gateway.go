@@ -0,0 +1,188 @@
+package gateway
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"net/http"
+
+	"github.com/aldngrha/ecommerce-be/internal/grpcmiddleware"
+	"github.com/aldngrha/ecommerce-be/internal/restmiddleware"
+	"github.com/aldngrha/ecommerce-be/pb/common"
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// forwardedHeaders are copied from the HTTP request into incoming gRPC metadata, so the
+// interceptors see the same values they would over gRPC.
+var forwardedHeaders = []string{
+	"authorization",
+	grpcmiddleware.IdempotencyKeyHeader,
+	grpcmiddleware.CorrelationIdHeader,
+}
+
+var (
+	unmarshalOptions = protojson.UnmarshalOptions{DiscardUnknown: true}
+	marshalOptions   = protojson.MarshalOptions{EmitUnpopulated: true}
+)
+
+// Route maps an HTTP endpoint onto a gRPC method. Path parameters use Fiber syntax and are
+// named after the request field they fill, for example "/products/:id".
+type Route struct {
+	Method     string
+	Path       string
+	FullMethod string
+	// Body is true when the request message is read from a JSON body, otherwise it is
+	// built from the query string.
+	Body     bool
+	request  protoreflect.MessageDescriptor
+	response protoreflect.MessageDescriptor
+	handler  fiber.Handler
+}
+
+// Gateway exposes gRPC service implementations as JSON endpoints. Every call goes through
+// the same interceptors as the gRPC server, so (auth.policy), idempotency and error
+// handling behave identically on both transports.
+type Gateway struct {
+	interceptors []grpc.UnaryServerInterceptor
+	routes       []*Route
+}
+
+func (g *Gateway) Routes() []*Route {
+	return g.routes
+}
+
+// Mount registers every route on router.
+func (g *Gateway) Mount(router fiber.Router) {
+	for _, route := range g.routes {
+		router.Add(route.Method, route.Path, route.handler)
+	}
+}
+
+// handle adds a route calling method of a gRPC service implementation.
+func handle[Req proto.Message, Res proto.Message](g *Gateway, httpMethod string, path string, fullMethod string, method func(context.Context, Req) (Res, error)) {
+	var zeroReq Req
+	var zeroRes Res
+	route := &Route{
+		Method:     httpMethod,
+		Path:       path,
+		FullMethod: fullMethod,
+		Body:       httpMethod == fiber.MethodPost || httpMethod == fiber.MethodPut || httpMethod == fiber.MethodPatch,
+		request:    zeroReq.ProtoReflect().Descriptor(),
+		response:   zeroRes.ProtoReflect().Descriptor(),
+	}
+
+	route.handler = func(c *fiber.Ctx) error {
+		correlationId := c.Get(grpcmiddleware.CorrelationIdHeader)
+		if correlationId == "" || len(correlationId) > 128 {
+			correlationId = uuid.NewString()
+			c.Request().Header.Set(grpcmiddleware.CorrelationIdHeader, correlationId)
+		}
+		c.Set(grpcmiddleware.CorrelationIdHeader, correlationId)
+
+		req := zeroReq.ProtoReflect().New().Interface().(Req)
+
+		if route.Body && len(c.Body()) > 0 {
+			if err := unmarshalOptions.Unmarshal(c.Body(), req); err != nil {
+				return writeBase(c, http.StatusBadRequest, "Invalid request body")
+			}
+		}
+		if !route.Body {
+			var err error
+			c.Context().QueryArgs().VisitAll(func(key, value []byte) {
+				if err == nil {
+					err = setField(req.ProtoReflect(), string(key), string(value))
+				}
+			})
+			if err != nil {
+				return writeBase(c, http.StatusBadRequest, err.Error())
+			}
+		}
+		for name, value := range c.AllParams() {
+			if err := setField(req.ProtoReflect(), name, value); err != nil {
+				return writeBase(c, http.StatusBadRequest, err.Error())
+			}
+		}
+
+		md := metadata.MD{}
+		for _, header := range forwardedHeaders {
+			if value := c.Get(header); value != "" {
+				md.Set(header, value)
+			}
+		}
+		ctx := metadata.NewIncomingContext(c.UserContext(), md)
+
+		info := &grpc.UnaryServerInfo{FullMethod: fullMethod}
+		call := chain(g.interceptors, info, func(ctx context.Context, req any) (any, error) {
+			return method(ctx, req.(Req))
+		})
+
+		res, err := call(ctx, req)
+		if err != nil {
+			st := status.Convert(err)
+			return writeBase(c, restmiddleware.HttpStatusFromCode(st.Code()), st.Message())
+		}
+
+		return writeResponse(c, res.(proto.Message))
+	}
+
+	g.routes = append(g.routes, route)
+}
+
+func chain(interceptors []grpc.UnaryServerInterceptor, info *grpc.UnaryServerInfo, final grpc.UnaryHandler) grpc.UnaryHandler {
+	handler := final
+	for i := len(interceptors) - 1; i >= 0; i-- {
+		interceptor, next := interceptors[i], handler
+		handler = func(ctx context.Context, req any) (any, error) {
+			return interceptor(ctx, req, info, next)
+		}
+	}
+
+	return handler
+}
+
+// writeResponse sends the response message, using the status code of its BaseResponse.
+func writeResponse(c *fiber.Ctx, res proto.Message) error {
+	statusCode := http.StatusOK
+	if withBase, ok := res.(interface{ GetBase() *common.BaseResponse }); ok && withBase.GetBase().GetStatusCode() != 0 {
+		statusCode = int(withBase.GetBase().GetStatusCode())
+	}
+
+	body, err := marshalOptions.Marshal(res)
+	if err != nil {
+		log.Printf("%s %s failed to encode response: %v", c.Method(), c.Path(), err)
+		return writeBase(c, http.StatusInternalServerError, "Internal server error")
+	}
+
+	c.Set(fiber.HeaderContentType, fiber.MIMEApplicationJSON)
+	return c.Status(statusCode).Send(body)
+}
+
+// writeBase answers with only a BaseResponse, for failures that happen before or instead
+// of a service response.
+func writeBase(c *fiber.Ctx, statusCode int, message string) error {
+	base, err := marshalOptions.Marshal(&common.BaseResponse{
+		StatusCode: int64(statusCode),
+		Message:    message,
+		IsError:    true,
+	})
+	if err != nil {
+		return err
+	}
+
+	return c.Status(statusCode).JSON(fiber.Map{
+		"base": json.RawMessage(base),
+	})
+}
+
+func New(interceptors ...grpc.UnaryServerInterceptor) *Gateway {
+	return &Gateway{
+		interceptors: interceptors,
+	}
+}
@@ -0,0 +1,236 @@
+package gateway
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/aldngrha/ecommerce-be/internal/apperror"
+	"github.com/aldngrha/ecommerce-be/internal/grpcmiddleware"
+	"github.com/aldngrha/ecommerce-be/pb/common"
+	"github.com/aldngrha/ecommerce-be/pb/product"
+	"github.com/aldngrha/ecommerce-be/pb/role"
+	"github.com/gofiber/fiber/v2"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+func TestSetField(t *testing.T) {
+	tests := []struct {
+		name      string
+		message   proto.Message
+		params    [][2]string
+		want      proto.Message
+		wantError string
+	}{
+		{
+			name:    "enum by name",
+			message: &product.ListProductsRequest{},
+			params:  [][2]string{{"sort_by", "PRODUCT_SORT_BY_PRICE"}},
+			want:    &product.ListProductsRequest{SortBy: product.ProductSortBy_PRODUCT_SORT_BY_PRICE},
+		},
+		{
+			name:    "enum by number and json name",
+			message: &product.ListProductsRequest{},
+			params:  [][2]string{{"sortDirection", "2"}},
+			want:    &product.ListProductsRequest{SortDirection: product.SortDirection_SORT_DIRECTION_DESC},
+		},
+		{
+			name:      "unknown enum name",
+			message:   &product.ListProductsRequest{},
+			params:    [][2]string{{"sort_by", "PRICE"}},
+			wantError: `invalid value for parameter "sort_by"`,
+		},
+		{
+			name:    "repeated field appends",
+			message: &role.CreateRoleRequest{},
+			params:  [][2]string{{"permissions", "product:write"}, {"permissions", "order:manage"}},
+			want:    &role.CreateRoleRequest{Permissions: []string{"product:write", "order:manage"}},
+		},
+		{
+			name:    "int64 beyond float precision",
+			message: &product.ListProductsRequest{},
+			params:  [][2]string{{"min_price", "9007199254740993"}},
+			want:    &product.ListProductsRequest{MinPrice: proto.Int64(9007199254740993)},
+		},
+		{
+			name:    "max uint64",
+			message: &wrapperspb.UInt64Value{},
+			params:  [][2]string{{"value", "18446744073709551615"}},
+			want:    wrapperspb.UInt64(math.MaxUint64),
+		},
+		{
+			name:      "int32 out of range",
+			message:   &product.ListProductsRequest{},
+			params:    [][2]string{{"limit", "3000000000"}},
+			wantError: `invalid value for parameter "limit"`,
+		},
+		{
+			name:      "not a number",
+			message:   &product.ListProductsRequest{},
+			params:    [][2]string{{"max_price", "ten"}},
+			wantError: `invalid value for parameter "max_price"`,
+		},
+		{
+			name:      "unknown parameter",
+			message:   &product.ListProductsRequest{},
+			params:    [][2]string{{"page", "1"}},
+			wantError: `unknown parameter "page"`,
+		},
+		{
+			name:      "message field",
+			message:   &product.EditProductRequest{},
+			params:    [][2]string{{"price", "100"}},
+			wantError: `parameter "price" cannot be set from the URL`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var err error
+			for _, param := range tt.params {
+				if err = setField(tt.message.ProtoReflect(), param[0], param[1]); err != nil {
+					break
+				}
+			}
+			if tt.wantError != "" {
+				if err == nil || err.Error() != tt.wantError {
+					t.Fatalf("setField() error = %v, want %s", err, tt.wantError)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("setField() returned error %v", err)
+			}
+			if !proto.Equal(tt.message, tt.want) {
+				t.Errorf("setField() = %v, want %v", tt.message, tt.want)
+			}
+		})
+	}
+}
+
+// call sends a request to a gateway holding only the routes registered by register, behind
+// the same error interceptor as production.
+func call(t *testing.T, register func(g *Gateway), method string, target string, body string) (int, map[string]any) {
+	t.Helper()
+
+	g := New(grpcmiddleware.ErrorMiddleware)
+	register(g)
+	app := fiber.New()
+	g.Mount(app)
+
+	req := httptest.NewRequest(method, target, strings.NewReader(body))
+	req.Header.Set(fiber.HeaderContentType, fiber.MIMEApplicationJSON)
+	res, err := app.Test(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer res.Body.Close()
+
+	raw, err := io.ReadAll(res.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var decoded map[string]any
+	if err = json.Unmarshal(raw, &decoded); err != nil {
+		t.Fatalf("response %q is not JSON: %v", raw, err)
+	}
+
+	return res.StatusCode, decoded
+}
+
+func TestHandleBuildsRequestFromQuery(t *testing.T) {
+	var got *product.ListProductsRequest
+	register := func(g *Gateway) {
+		handle(g, fiber.MethodGet, "/products", product.ProductService_ListProducts_FullMethodName,
+			func(ctx context.Context, req *product.ListProductsRequest) (*product.ListProductsResponse, error) {
+				got = req
+				return &product.ListProductsResponse{}, nil
+			})
+	}
+
+	statusCode, _ := call(t, register, fiber.MethodGet, "/products?sort_by=PRODUCT_SORT_BY_NAME&limit=5&max_price=9007199254740993", "")
+	if statusCode != http.StatusOK {
+		t.Fatalf("status = %d, want 200", statusCode)
+	}
+	want := &product.ListProductsRequest{SortBy: product.ProductSortBy_PRODUCT_SORT_BY_NAME, Limit: 5, MaxPrice: proto.Int64(9007199254740993)}
+	if !proto.Equal(got, want) {
+		t.Errorf("request = %v, want %v", got, want)
+	}
+
+	got = nil
+	statusCode, body := call(t, register, fiber.MethodGet, "/products?page=2", "")
+	if statusCode != http.StatusBadRequest || got != nil {
+		t.Fatalf("status = %d, service called = %v, want 400 without a call", statusCode, got != nil)
+	}
+	if message := body["base"].(map[string]any)["message"]; message != `unknown parameter "page"` {
+		t.Errorf("message = %v, want the unknown parameter", message)
+	}
+}
+
+func TestHandlePathParameterOverridesBody(t *testing.T) {
+	var got *product.EditProductRequest
+	register := func(g *Gateway) {
+		handle(g, fiber.MethodPut, "/products/:id", product.ProductService_EditProduct_FullMethodName,
+			func(ctx context.Context, req *product.EditProductRequest) (*product.EditProductResponse, error) {
+				got = req
+				return &product.EditProductResponse{Id: req.Id}, nil
+			})
+	}
+
+	statusCode, body := call(t, register, fiber.MethodPut, "/products/from-path", `{"id": "from-body", "name": "Shirt"}`)
+	if statusCode != http.StatusOK {
+		t.Fatalf("status = %d, want 200", statusCode)
+	}
+	if got.Id != "from-path" || got.Name != "Shirt" {
+		t.Errorf("request = %v, want id from the path and name from the body", got)
+	}
+	if body["id"] != "from-path" {
+		t.Errorf("response id = %v, want from-path", body["id"])
+	}
+
+	statusCode, _ = call(t, register, fiber.MethodPut, "/products/from-path", `{"name": `)
+	if statusCode != http.StatusBadRequest {
+		t.Errorf("status for a malformed body = %d, want 400", statusCode)
+	}
+}
+
+func TestHandleResponseStatus(t *testing.T) {
+	tests := []struct {
+		name       string
+		response   *product.DeleteProductResponse
+		err        error
+		wantStatus int
+	}{
+		{name: "defaults to ok", response: &product.DeleteProductResponse{}, wantStatus: http.StatusOK},
+		{name: "uses the base status code", response: &product.DeleteProductResponse{Base: &common.BaseResponse{StatusCode: http.StatusAccepted}}, wantStatus: http.StatusAccepted},
+		{name: "maps not found", err: apperror.NotFound("PRODUCT_NOT_FOUND", "product not found"), wantStatus: http.StatusNotFound},
+		{name: "maps failed precondition", err: apperror.FailedPrecondition("PRODUCT_IN_USE", "product is in use"), wantStatus: http.StatusBadRequest},
+		{name: "maps already exists", err: apperror.AlreadyExists("SKU_TAKEN", "sku is already taken"), wantStatus: http.StatusConflict},
+		{name: "hides unexpected errors", err: io.ErrUnexpectedEOF, wantStatus: http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			register := func(g *Gateway) {
+				handle(g, fiber.MethodDelete, "/products/:id", product.ProductService_DeleteProduct_FullMethodName,
+					func(ctx context.Context, req *product.DeleteProductRequest) (*product.DeleteProductResponse, error) {
+						return tt.response, tt.err
+					})
+			}
+
+			statusCode, body := call(t, register, fiber.MethodDelete, "/products/1", "")
+			if statusCode != tt.wantStatus {
+				t.Errorf("status = %d, want %d", statusCode, tt.wantStatus)
+			}
+			if tt.err != nil && body["base"].(map[string]any)["isError"] != true {
+				t.Errorf("body = %v, want an error base", body)
+			}
+		})
+	}
+}
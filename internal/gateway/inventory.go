@@ -0,0 +1,14 @@
+package gateway
+
+import (
+	"github.com/aldngrha/ecommerce-be/pb/inventory"
+	"github.com/gofiber/fiber/v2"
+)
+
+// RegisterInventoryService exposes InventoryService under /inventory. variant_id goes in
+// the query string or body, next to the product in the path.
+func RegisterInventoryService(g *Gateway, server inventory.InventoryServiceServer) {
+	handle(g, fiber.MethodGet, "/inventory/:product_id", inventory.InventoryService_GetStock_FullMethodName, server.GetStock)
+	handle(g, fiber.MethodPut, "/inventory/:product_id", inventory.InventoryService_SetStock_FullMethodName, server.SetStock)
+	handle(g, fiber.MethodPost, "/inventory/:product_id/adjust", inventory.InventoryService_AdjustStock_FullMethodName, server.AdjustStock)
+}
@@ -0,0 +1,328 @@
+package gateway
+
+import (
+	"encoding/json"
+	"regexp"
+	"slices"
+	"strings"
+
+	"buf.build/gen/go/bufbuild/protovalidate/protocolbuffers/go/buf/validate"
+	"github.com/aldngrha/ecommerce-be/internal/grpcmiddleware"
+	"github.com/aldngrha/ecommerce-be/pb/common"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+type openAPIDocument struct {
+	OpenAPI    string                           `json:"openapi"`
+	Info       openAPIInfo                      `json:"info"`
+	Servers    []openAPIServer                  `json:"servers,omitempty"`
+	Paths      map[string]map[string]*operation `json:"paths"`
+	Components components                       `json:"components"`
+}
+
+type openAPIInfo struct {
+	Title   string `json:"title"`
+	Version string `json:"version"`
+}
+
+type openAPIServer struct {
+	Url string `json:"url"`
+}
+
+type components struct {
+	Schemas         map[string]*schema         `json:"schemas"`
+	SecuritySchemes map[string]*securityScheme `json:"securitySchemes"`
+}
+
+type securityScheme struct {
+	Type         string `json:"type"`
+	Scheme       string `json:"scheme"`
+	BearerFormat string `json:"bearerFormat,omitempty"`
+}
+
+type operation struct {
+	OperationId string                `json:"operationId"`
+	Summary     string                `json:"summary"`
+	Description string                `json:"description,omitempty"`
+	Tags        []string              `json:"tags"`
+	Parameters  []*parameter          `json:"parameters,omitempty"`
+	RequestBody *requestBody          `json:"requestBody,omitempty"`
+	Responses   map[string]*response  `json:"responses"`
+	Security    []map[string][]string `json:"security"`
+	GrpcMethod  string                `json:"x-grpc-method"`
+}
+
+type parameter struct {
+	Name     string  `json:"name"`
+	In       string  `json:"in"`
+	Required bool    `json:"required,omitempty"`
+	Schema   *schema `json:"schema"`
+}
+
+type requestBody struct {
+	Required bool                  `json:"required"`
+	Content  map[string]*mediaType `json:"content"`
+}
+
+type response struct {
+	Description string                `json:"description"`
+	Content     map[string]*mediaType `json:"content,omitempty"`
+}
+
+type mediaType struct {
+	Schema *schema `json:"schema"`
+}
+
+// schema is the subset of the OpenAPI 3.0 schema object the protos need.
+type schema struct {
+	Ref                  string             `json:"$ref,omitempty"`
+	Type                 string             `json:"type,omitempty"`
+	Format               string             `json:"format,omitempty"`
+	Description          string             `json:"description,omitempty"`
+	Enum                 []any              `json:"enum,omitempty"`
+	Items                *schema            `json:"items,omitempty"`
+	Properties           map[string]*schema `json:"properties,omitempty"`
+	AdditionalProperties *schema            `json:"additionalProperties,omitempty"`
+	Required             []string           `json:"required,omitempty"`
+	MinLength            *uint64            `json:"minLength,omitempty"`
+	MaxLength            *uint64            `json:"maxLength,omitempty"`
+	Pattern              string             `json:"pattern,omitempty"`
+	Minimum              *float64           `json:"minimum,omitempty"`
+	Maximum              *float64           `json:"maximum,omitempty"`
+	ExclusiveMinimum     bool               `json:"exclusiveMinimum,omitempty"`
+	ExclusiveMaximum     bool               `json:"exclusiveMaximum,omitempty"`
+	MinItems             *uint64            `json:"minItems,omitempty"`
+	MaxItems             *uint64            `json:"maxItems,omitempty"`
+	UniqueItems          bool               `json:"uniqueItems,omitempty"`
+}
+
+const bearerSchemeName = "bearerAuth"
+
+var pathParamPattern = regexp.MustCompile(`:(\w+)`)
+
+// OpenAPI describes every route as an OpenAPI 3.0 document. Schemas are built from the
+// registered proto descriptors, in the JSON form the gateway speaks, and carry the
+// buf.validate rules of each field, so the document cannot drift from CheckValidations.
+// serverUrl is the prefix the gateway is mounted under.
+func (g *Gateway) OpenAPI(title string, version string, serverUrl string) ([]byte, error) {
+	document := &openAPIDocument{
+		OpenAPI: "3.0.3",
+		Info:    openAPIInfo{Title: title, Version: version},
+		Servers: []openAPIServer{{Url: serverUrl}},
+		Paths:   make(map[string]map[string]*operation),
+		Components: components{
+			Schemas: make(map[string]*schema),
+			SecuritySchemes: map[string]*securityScheme{
+				bearerSchemeName: {Type: "http", Scheme: "bearer", BearerFormat: "JWT"},
+			},
+		},
+	}
+
+	for _, route := range g.routes {
+		path := pathParamPattern.ReplaceAllString(route.Path, "{$1}")
+		if document.Paths[path] == nil {
+			document.Paths[path] = make(map[string]*operation)
+		}
+		document.Paths[path][strings.ToLower(route.Method)] = describeRoute(document.Components.Schemas, route)
+	}
+
+	return json.MarshalIndent(document, "", "  ")
+}
+
+func describeRoute(schemas map[string]*schema, route *Route) *operation {
+	serviceName, methodName, _ := strings.Cut(strings.TrimPrefix(route.FullMethod, "/"), "/")
+
+	op := &operation{
+		OperationId: methodName,
+		Summary:     methodName,
+		Tags:        []string{serviceName},
+		Responses: map[string]*response{
+			"200": {
+				Description: "The response; base.statusCode and base.isError report business errors, which are also sent with that HTTP status",
+				Content:     jsonContent(messageRef(schemas, route.response)),
+			},
+			"default": {
+				Description: "Authentication, permission or server error",
+				Content:     jsonContent(errorSchema(schemas)),
+			},
+		},
+		Security:   []map[string][]string{},
+		GrpcMethod: route.FullMethod,
+	}
+
+	policy := grpcmiddleware.PolicyForMethod(route.FullMethod)
+	if !policy.Public {
+		op.Security = []map[string][]string{{bearerSchemeName: {}}}
+
+		requirements := make([]string, 0, 2)
+		if len(policy.Roles) > 0 {
+			requirements = append(requirements, "Roles: "+strings.Join(policy.Roles, ", "))
+		}
+		if len(policy.Permissions) > 0 {
+			requirements = append(requirements, "Permissions: "+strings.Join(policy.Permissions, ", "))
+		}
+		op.Description = strings.Join(requirements, ". ")
+	}
+
+	pathParams := make(map[string]bool)
+	for _, match := range pathParamPattern.FindAllStringSubmatch(route.Path, -1) {
+		pathParams[match[1]] = true
+		field := route.request.Fields().ByName(protoreflect.Name(match[1]))
+		op.Parameters = append(op.Parameters, &parameter{
+			Name:     match[1],
+			In:       "path",
+			Required: true,
+			Schema:   fieldSchema(schemas, field),
+		})
+	}
+
+	if route.Body {
+		op.RequestBody = &requestBody{
+			Required: true,
+			Content:  jsonContent(messageRef(schemas, route.request)),
+		}
+		return op
+	}
+
+	fields := route.request.Fields()
+	for i := 0; i < fields.Len(); i++ {
+		field := fields.Get(i)
+		if pathParams[string(field.Name())] || field.Kind() == protoreflect.MessageKind || field.IsMap() {
+			continue
+		}
+		op.Parameters = append(op.Parameters, &parameter{
+			Name:   field.JSONName(),
+			In:     "query",
+			Schema: fieldSchema(schemas, field),
+		})
+	}
+
+	return op
+}
+
+func jsonContent(s *schema) map[string]*mediaType {
+	return map[string]*mediaType{
+		"application/json": {Schema: s},
+	}
+}
+
+// errorSchema is the body writeBase sends when a call fails before the service answers.
+func errorSchema(schemas map[string]*schema) *schema {
+	return &schema{
+		Type: "object",
+		Properties: map[string]*schema{
+			"base": messageRef(schemas, (&common.BaseResponse{}).ProtoReflect().Descriptor()),
+		},
+	}
+}
+
+// messageRef adds the message and everything it references to schemas and returns a
+// reference to it.
+func messageRef(schemas map[string]*schema, message protoreflect.MessageDescriptor) *schema {
+	if wellKnown := wellKnownSchema(message); wellKnown != nil {
+		return wellKnown
+	}
+
+	name := string(message.FullName())
+	ref := &schema{Ref: "#/components/schemas/" + name}
+	if _, ok := schemas[name]; ok {
+		return ref
+	}
+
+	messageSchema := &schema{
+		Type:       "object",
+		Properties: make(map[string]*schema),
+	}
+	// registered before the fields so recursive messages terminate
+	schemas[name] = messageSchema
+
+	fields := message.Fields()
+	for i := 0; i < fields.Len(); i++ {
+		field := fields.Get(i)
+		messageSchema.Properties[field.JSONName()] = fieldSchema(schemas, field)
+		if rules := fieldRules(field); rules != nil && rules.GetRequired() {
+			messageSchema.Required = append(messageSchema.Required, field.JSONName())
+		}
+	}
+
+	return ref
+}
+
+func wellKnownSchema(message protoreflect.MessageDescriptor) *schema {
+	switch message.FullName() {
+	case "google.protobuf.Timestamp":
+		return &schema{Type: "string", Format: "date-time"}
+	case "google.protobuf.Duration":
+		return &schema{Type: "string", Description: `Duration in seconds with an "s" suffix, e.g. "1.5s"`}
+	}
+
+	return nil
+}
+
+func fieldSchema(schemas map[string]*schema, field protoreflect.FieldDescriptor) *schema {
+	rules := fieldRules(field)
+
+	if field.IsMap() {
+		return &schema{
+			Type:                 "object",
+			AdditionalProperties: singularSchema(schemas, field.MapValue(), nil),
+		}
+	}
+
+	if field.IsList() {
+		list := &schema{
+			Type:  "array",
+			Items: singularSchema(schemas, field, rules.GetRepeated().GetItems()),
+		}
+		applyRepeatedRules(list, rules)
+		return list
+	}
+
+	return singularSchema(schemas, field, rules)
+}
+
+func singularSchema(schemas map[string]*schema, field protoreflect.FieldDescriptor, rules *validate.FieldRules) *schema {
+	var s *schema
+	switch field.Kind() {
+	case protoreflect.MessageKind, protoreflect.GroupKind:
+		return messageRef(schemas, field.Message())
+	case protoreflect.StringKind:
+		s = &schema{Type: "string"}
+	case protoreflect.BytesKind:
+		s = &schema{Type: "string", Format: "byte"}
+	case protoreflect.BoolKind:
+		s = &schema{Type: "boolean"}
+	case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind:
+		s = &schema{Type: "integer", Format: "int32"}
+	case protoreflect.Uint32Kind, protoreflect.Fixed32Kind:
+		s = &schema{Type: "integer", Format: "int64", Minimum: floatPtr(0)}
+	case protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind,
+		protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		// protojson writes 64 bit integers as strings
+		s = &schema{Type: "string", Format: "int64"}
+	case protoreflect.FloatKind:
+		s = &schema{Type: "number", Format: "float"}
+	case protoreflect.DoubleKind:
+		s = &schema{Type: "number", Format: "double"}
+	case protoreflect.EnumKind:
+		s = &schema{Type: "string"}
+		in, notIn := rules.GetEnum().GetIn(), rules.GetEnum().GetNotIn()
+		values := field.Enum().Values()
+		for i := 0; i < values.Len(); i++ {
+			number := int32(values.Get(i).Number())
+			if (len(in) > 0 && !slices.Contains(in, number)) || slices.Contains(notIn, number) {
+				continue
+			}
+			s.Enum = append(s.Enum, string(values.Get(i).Name()))
+		}
+	default:
+		s = &schema{}
+	}
+
+	applyScalarRules(s, rules)
+	return s
+}
+
+func floatPtr(value float64) *float64 {
+	return &value
+}
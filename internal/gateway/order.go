@@ -0,0 +1,15 @@
+package gateway
+
+import (
+	"github.com/aldngrha/ecommerce-be/pb/order"
+	"github.com/gofiber/fiber/v2"
+)
+
+// RegisterOrderService exposes OrderService under /orders.
+func RegisterOrderService(g *Gateway, server order.OrderServiceServer) {
+	handle(g, fiber.MethodPost, "/orders/checkout", order.OrderService_Checkout_FullMethodName, server.Checkout)
+	handle(g, fiber.MethodGet, "/orders", order.OrderService_ListMyOrders_FullMethodName, server.ListMyOrders)
+	handle(g, fiber.MethodGet, "/orders/:id", order.OrderService_GetOrder_FullMethodName, server.GetOrder)
+	handle(g, fiber.MethodPost, "/orders/:id/cancel", order.OrderService_CancelOrder_FullMethodName, server.CancelOrder)
+	handle(g, fiber.MethodPut, "/orders/:id/status", order.OrderService_UpdateOrderStatus_FullMethodName, server.UpdateOrderStatus)
+}
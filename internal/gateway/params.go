@@ -0,0 +1,76 @@
+package gateway
+
+import (
+	"fmt"
+	"strconv"
+
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// setField fills a scalar request field from a path or query parameter. The field is
+// matched by its proto or JSON name; repeated fields may be given several times.
+func setField(message protoreflect.Message, name string, value string) error {
+	fields := message.Descriptor().Fields()
+	field := fields.ByName(protoreflect.Name(name))
+	if field == nil {
+		field = fields.ByJSONName(name)
+	}
+	if field == nil {
+		return fmt.Errorf("unknown parameter %q", name)
+	}
+	if field.IsMap() || field.Kind() == protoreflect.MessageKind || field.Kind() == protoreflect.GroupKind {
+		return fmt.Errorf("parameter %q cannot be set from the URL", name)
+	}
+
+	parsed, err := parseScalar(field, value)
+	if err != nil {
+		return fmt.Errorf("invalid value for parameter %q", name)
+	}
+
+	if field.IsList() {
+		message.Mutable(field).List().Append(parsed)
+		return nil
+	}
+
+	message.Set(field, parsed)
+	return nil
+}
+
+func parseScalar(field protoreflect.FieldDescriptor, value string) (protoreflect.Value, error) {
+	switch field.Kind() {
+	case protoreflect.StringKind:
+		return protoreflect.ValueOfString(value), nil
+	case protoreflect.BytesKind:
+		return protoreflect.ValueOfBytes([]byte(value)), nil
+	case protoreflect.BoolKind:
+		parsed, err := strconv.ParseBool(value)
+		return protoreflect.ValueOfBool(parsed), err
+	case protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Sfixed32Kind:
+		parsed, err := strconv.ParseInt(value, 10, 32)
+		return protoreflect.ValueOfInt32(int32(parsed)), err
+	case protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Sfixed64Kind:
+		parsed, err := strconv.ParseInt(value, 10, 64)
+		return protoreflect.ValueOfInt64(parsed), err
+	case protoreflect.Uint32Kind, protoreflect.Fixed32Kind:
+		parsed, err := strconv.ParseUint(value, 10, 32)
+		return protoreflect.ValueOfUint32(uint32(parsed)), err
+	case protoreflect.Uint64Kind, protoreflect.Fixed64Kind:
+		parsed, err := strconv.ParseUint(value, 10, 64)
+		return protoreflect.ValueOfUint64(parsed), err
+	case protoreflect.FloatKind:
+		parsed, err := strconv.ParseFloat(value, 32)
+		return protoreflect.ValueOfFloat32(float32(parsed)), err
+	case protoreflect.DoubleKind:
+		parsed, err := strconv.ParseFloat(value, 64)
+		return protoreflect.ValueOfFloat64(parsed), err
+	case protoreflect.EnumKind:
+		// accept the value name, e.g. PRODUCT_SORT_BY_PRICE, or its number
+		if enumValue := field.Enum().Values().ByName(protoreflect.Name(value)); enumValue != nil {
+			return protoreflect.ValueOfEnum(enumValue.Number()), nil
+		}
+		parsed, err := strconv.ParseInt(value, 10, 32)
+		return protoreflect.ValueOfEnum(protoreflect.EnumNumber(parsed)), err
+	}
+
+	return protoreflect.Value{}, fmt.Errorf("unsupported field kind %s", field.Kind())
+}
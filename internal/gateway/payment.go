@@ -0,0 +1,12 @@
+package gateway
+
+import (
+	"github.com/aldngrha/ecommerce-be/pb/payment"
+	"github.com/gofiber/fiber/v2"
+)
+
+// RegisterPaymentService exposes PaymentService under the order it pays for.
+func RegisterPaymentService(g *Gateway, server payment.PaymentServiceServer) {
+	handle(g, fiber.MethodPost, "/orders/:order_id/pay", payment.PaymentService_PayOrder_FullMethodName, server.PayOrder)
+	handle(g, fiber.MethodPost, "/orders/:order_id/refund", payment.PaymentService_RefundOrder_FullMethodName, server.RefundOrder)
+}
@@ -0,0 +1,19 @@
+package gateway
+
+import (
+	"github.com/aldngrha/ecommerce-be/pb/product"
+	"github.com/gofiber/fiber/v2"
+)
+
+// RegisterProductService exposes ProductService under /products.
+func RegisterProductService(g *Gateway, server product.ProductServiceServer) {
+	handle(g, fiber.MethodGet, "/products", product.ProductService_ListProducts_FullMethodName, server.ListProducts)
+	// before /products/:id so "deleted" is not taken for an id
+	handle(g, fiber.MethodGet, "/products/deleted", product.ProductService_ListDeletedProducts_FullMethodName, server.ListDeletedProducts)
+	handle(g, fiber.MethodGet, "/products/:id", product.ProductService_DetailProduct_FullMethodName, server.DetailProduct)
+	handle(g, fiber.MethodPost, "/products", product.ProductService_CreateProduct_FullMethodName, server.CreateProduct)
+	handle(g, fiber.MethodPut, "/products/:id", product.ProductService_EditProduct_FullMethodName, server.EditProduct)
+	handle(g, fiber.MethodDelete, "/products/:id", product.ProductService_DeleteProduct_FullMethodName, server.DeleteProduct)
+	handle(g, fiber.MethodPost, "/products/:id/restore", product.ProductService_RestoreProduct_FullMethodName, server.RestoreProduct)
+	handle(g, fiber.MethodPut, "/products/:product_id/variants", product.ProductService_SetProductVariants_FullMethodName, server.SetProductVariants)
+}
@@ -0,0 +1,15 @@
+package gateway
+
+import (
+	"github.com/aldngrha/ecommerce-be/pb/role"
+	"github.com/gofiber/fiber/v2"
+)
+
+// RegisterRoleService exposes RoleService under /roles, /permissions and /users.
+func RegisterRoleService(g *Gateway, server role.RoleServiceServer) {
+	handle(g, fiber.MethodGet, "/roles", role.RoleService_ListRoles_FullMethodName, server.ListRoles)
+	handle(g, fiber.MethodPost, "/roles", role.RoleService_CreateRole_FullMethodName, server.CreateRole)
+	handle(g, fiber.MethodPut, "/roles/:role_code/permissions", role.RoleService_SetRolePermissions_FullMethodName, server.SetRolePermissions)
+	handle(g, fiber.MethodGet, "/permissions", role.RoleService_ListPermissions_FullMethodName, server.ListPermissions)
+	handle(g, fiber.MethodPut, "/users/:user_id/role", role.RoleService_AssignRole_FullMethodName, server.AssignRole)
+}
@@ -0,0 +1,159 @@
+package gateway
+
+import (
+	"fmt"
+
+	"buf.build/gen/go/bufbuild/protovalidate/protocolbuffers/go/buf/validate"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// fieldRules returns the (buf.validate.field) option of a field, or nil.
+func fieldRules(field protoreflect.FieldDescriptor) *validate.FieldRules {
+	if field.Options() == nil {
+		return nil
+	}
+
+	rules, _ := proto.GetExtension(field.Options(), validate.E_Field).(*validate.FieldRules)
+	return rules
+}
+
+// ruleValue reads a rule by its proto name when it is set, e.g. "min_len" or "gte".
+func ruleValue(rules protoreflect.Message, name string) (protoreflect.Value, protoreflect.FieldDescriptor, bool) {
+	field := rules.Descriptor().Fields().ByName(protoreflect.Name(name))
+	if field == nil || !rules.Has(field) {
+		return protoreflect.Value{}, nil, false
+	}
+
+	return rules.Get(field), field, true
+}
+
+func ruleUint(rules protoreflect.Message, name string) *uint64 {
+	value, _, ok := ruleValue(rules, name)
+	if !ok {
+		return nil
+	}
+
+	parsed := value.Uint()
+	return &parsed
+}
+
+func ruleNumber(rules protoreflect.Message, name string) *float64 {
+	value, field, ok := ruleValue(rules, name)
+	if !ok {
+		return nil
+	}
+
+	var parsed float64
+	switch field.Kind() {
+	case protoreflect.FloatKind, protoreflect.DoubleKind:
+		parsed = value.Float()
+	case protoreflect.Uint32Kind, protoreflect.Uint64Kind, protoreflect.Fixed32Kind, protoreflect.Fixed64Kind:
+		parsed = float64(value.Uint())
+	default:
+		parsed = float64(value.Int())
+	}
+
+	return &parsed
+}
+
+// applyScalarRules copies the string and numeric rules of a singular field onto its schema.
+func applyScalarRules(s *schema, rules *validate.FieldRules) {
+	if rules == nil {
+		return
+	}
+
+	message := rules.ProtoReflect()
+	typeField := message.WhichOneof(message.Descriptor().Oneofs().ByName("type"))
+	if typeField == nil || typeField.Message() == nil {
+		return
+	}
+	typeRules := message.Get(typeField).Message()
+
+	switch typeField.Name() {
+	case "string":
+		applyStringRules(s, typeRules)
+	case "float", "double", "int32", "int64", "uint32", "uint64", "sint32", "sint64", "fixed32", "fixed64", "sfixed32", "sfixed64":
+		applyNumericRules(s, typeRules)
+	}
+}
+
+func applyStringRules(s *schema, rules protoreflect.Message) {
+	s.MinLength = ruleUint(rules, "min_len")
+	s.MaxLength = ruleUint(rules, "max_len")
+	if length := ruleUint(rules, "len"); length != nil {
+		s.MinLength, s.MaxLength = length, length
+	}
+	if pattern, _, ok := ruleValue(rules, "pattern"); ok {
+		s.Pattern = pattern.String()
+	}
+
+	for _, format := range []string{"email", "uuid", "uri", "hostname", "ipv4", "ipv6"} {
+		if value, _, ok := ruleValue(rules, format); ok && value.Bool() {
+			s.Format = format
+		}
+	}
+
+	if in, _, ok := ruleValue(rules, "in"); ok {
+		for i := 0; i < in.List().Len(); i++ {
+			s.Enum = append(s.Enum, in.List().Get(i).String())
+		}
+	}
+}
+
+func applyNumericRules(s *schema, rules protoreflect.Message) {
+	minimum, exclusiveMinimum := ruleNumber(rules, "gte"), false
+	if gt := ruleNumber(rules, "gt"); gt != nil {
+		minimum, exclusiveMinimum = gt, true
+	}
+	maximum, exclusiveMaximum := ruleNumber(rules, "lte"), false
+	if lt := ruleNumber(rules, "lt"); lt != nil {
+		maximum, exclusiveMaximum = lt, true
+	}
+	if constant := ruleNumber(rules, "const"); constant != nil {
+		minimum, maximum = constant, constant
+	}
+
+	// 64 bit integers are strings in JSON, where minimum and maximum do not apply
+	if s.Type == "string" {
+		if minimum != nil {
+			s.Description = appendSentence(s.Description, fmt.Sprintf("Must be %s %v", comparison(">", exclusiveMinimum), *minimum))
+		}
+		if maximum != nil {
+			s.Description = appendSentence(s.Description, fmt.Sprintf("Must be %s %v", comparison("<", exclusiveMaximum), *maximum))
+		}
+		return
+	}
+
+	s.Minimum, s.ExclusiveMinimum = minimum, exclusiveMinimum
+	s.Maximum, s.ExclusiveMaximum = maximum, exclusiveMaximum
+}
+
+func applyRepeatedRules(s *schema, rules *validate.FieldRules) {
+	if rules.GetRepeated() == nil {
+		return
+	}
+
+	repeated := rules.GetRepeated().ProtoReflect()
+	s.MinItems = ruleUint(repeated, "min_items")
+	s.MaxItems = ruleUint(repeated, "max_items")
+	if unique, _, ok := ruleValue(repeated, "unique"); ok {
+		s.UniqueItems = unique.Bool()
+	}
+}
+
+func comparison(operator string, exclusive bool) string {
+	if exclusive {
+		return operator
+	}
+
+	return operator + "="
+}
+
+func appendSentence(text string, sentence string) string {
+	if text == "" {
+		return sentence
+	}
+
+	return text + ". " + sentence
+}
@@ -3,20 +3,24 @@ package grpcmiddleware
 import (
 	"context"
 
+	"github.com/aldngrha/ecommerce-be/internal/apperror"
 	jwtentity "github.com/aldngrha/ecommerce-be/internal/entity/jwt"
-	gocache "github.com/patrickmn/go-cache"
 	"google.golang.org/grpc"
-	"google.golang.org/grpc/codes"
-	"google.golang.org/grpc/status"
 )
 
+// TokenAuthenticator verifies an access token and returns its claims with the permissions
+// of its role filled in.
+type TokenAuthenticator interface {
+	Authenticate(ctx context.Context, token string) (*jwtentity.JwtClaims, error)
+}
+
 type authMiddleware struct {
-	cacheService *gocache.Cache
+	tokenAuthenticator TokenAuthenticator
 }
 
 func (am *authMiddleware) Middleware(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp any, err error) {
-	if info.FullMethod == "/auth.AuthService/Login" || info.FullMethod == "/auth.AuthService/Register" || info.FullMethod == "/product.ProductService/DetailProduct" {
-
+	policy := PolicyForMethod(info.FullMethod)
+	if policy.Public {
 		return handler(ctx, req)
 	}
 
@@ -25,16 +29,23 @@ func (am *authMiddleware) Middleware(ctx context.Context, req any, info *grpc.Un
 		return nil, err
 	}
 
-	_, ok := am.cacheService.Get(tokenStr)
-	if ok {
-		return nil, status.Errorf(codes.PermissionDenied, "token has been logged out")
-	}
-
-	claims, err := jwtentity.GetClaimsFromToken(tokenStr)
+	claims, err := am.tokenAuthenticator.Authenticate(ctx, tokenStr)
 	if err != nil {
 		return nil, err
 	}
 
+	if !roleAllowed(policy, claims.Role) {
+		return nil, apperror.PermissionDenied("ROLE_NOT_ALLOWED", "your role is not allowed to call this method").
+			WithMetadata("method", info.FullMethod).
+			WithMetadata("role", claims.Role)
+	}
+
+	if !permissionsGranted(policy, claims.Permissions) {
+		return nil, apperror.PermissionDenied("MISSING_PERMISSION", "your role lacks the permissions required to call this method").
+			WithMetadata("method", info.FullMethod).
+			WithMetadata("role", claims.Role)
+	}
+
 	ctx = claims.SendToContext(ctx)
 
 	res, err := handler(ctx, req)
@@ -42,6 +53,8 @@ func (am *authMiddleware) Middleware(ctx context.Context, req any, info *grpc.Un
 	return res, err
 }
 
-func NewAuthMiddleware(cacheService *gocache.Cache) *authMiddleware {
-	return &authMiddleware{cacheService: cacheService}
+func NewAuthMiddleware(tokenAuthenticator TokenAuthenticator) *authMiddleware {
+	return &authMiddleware{
+		tokenAuthenticator: tokenAuthenticator,
+	}
 }
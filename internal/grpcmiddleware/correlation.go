@@ -0,0 +1,30 @@
+package grpcmiddleware
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"google.golang.org/grpc/metadata"
+)
+
+// CorrelationIdHeader is read from incoming metadata when the caller already has an id,
+// and is always sent back as a response header.
+const CorrelationIdHeader = "x-correlation-id"
+
+type correlationIdContextKey struct{}
+
+func correlationIdFromMetadata(ctx context.Context) string {
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if values := md.Get(CorrelationIdHeader); len(values) > 0 && values[0] != "" && len(values[0]) <= 128 {
+			return values[0]
+		}
+	}
+
+	return uuid.NewString()
+}
+
+func CorrelationIdFromContext(ctx context.Context) string {
+	correlationId, _ := ctx.Value(correlationIdContextKey{}).(string)
+
+	return correlationId
+}
@@ -2,33 +2,74 @@ package grpcmiddleware
 
 import (
 	"context"
+	"errors"
+	"log"
+	"runtime/debug"
+
+	"github.com/aldngrha/ecommerce-be/internal/apperror"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/status"
-	"log"
-	"runtime/debug"
 )
 
 func ErrorMiddleware(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp any, err error) {
+	correlationId := correlationIdFromMetadata(ctx)
+	ctx = context.WithValue(ctx, correlationIdContextKey{}, correlationId)
+	_ = grpc.SetHeader(ctx, metadata.Pairs(CorrelationIdHeader, correlationId))
+
 	defer func() {
 		if recovered := recover(); recovered != nil {
-			log.Printf("Recovered from panic: %v", recovered)
-			debug.PrintStack()
-			err = status.Errorf(codes.Internal, "Internal server error")
+			log.Printf("[%s] %s recovered from panic: %v\n%s", correlationId, info.FullMethod, recovered, debug.Stack())
+			resp = nil
+			err = internalError(correlationId)
 		}
 	}()
+
 	res, err := handler(ctx, req)
 	if err != nil {
-		log.Println(err)
+		return nil, toStatusError(err, info.FullMethod, correlationId)
+	}
+
+	return res, err
+}
 
-		if st, ok := status.FromError(err); ok {
-			if st.Code() == codes.Unauthenticated {
-				return nil, err
-			}
+// toStatusError maps any handler error to the status sent to the client. Domain errors
+// and statuses with a deliberate code keep their code and details, anything else is
+// logged and hidden behind Internal.
+func toStatusError(err error, fullMethod string, correlationId string) error {
+	var appErr *apperror.Error
+	if errors.As(err, &appErr) {
+		if appErr.Code == codes.Internal || appErr.Code == codes.Unknown {
+			log.Printf("[%s] %s failed: %v", correlationId, fullMethod, err)
+			return internalError(correlationId)
 		}
 
-		return nil, status.Errorf(codes.Internal, "Internal server error")
+		if appErr.Err != nil {
+			log.Printf("[%s] %s returned %s: %v", correlationId, fullMethod, appErr.Code, err)
+		}
+		return appErr.WithMetadata("correlation_id", correlationId).GRPCStatus().Err()
 	}
 
-	return res, err
+	switch {
+	case errors.Is(err, context.Canceled):
+		return status.Error(codes.Canceled, "request was cancelled")
+	case errors.Is(err, context.DeadlineExceeded):
+		return status.Error(codes.DeadlineExceeded, "request deadline exceeded")
+	}
+
+	if st, ok := status.FromError(err); ok && st.Code() != codes.Unknown && st.Code() != codes.Internal {
+		return err
+	}
+
+	log.Printf("[%s] %s failed: %v", correlationId, fullMethod, err)
+
+	return internalError(correlationId)
+}
+
+func internalError(correlationId string) error {
+	return apperror.Internal("INTERNAL", "Internal server error").
+		WithMetadata("correlation_id", correlationId).
+		GRPCStatus().
+		Err()
 }
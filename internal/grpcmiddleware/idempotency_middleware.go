@@ -0,0 +1,175 @@
+package grpcmiddleware
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"log"
+	"time"
+
+	"github.com/aldngrha/ecommerce-be/internal/apperror"
+	"github.com/aldngrha/ecommerce-be/internal/entity"
+	jwtentity "github.com/aldngrha/ecommerce-be/internal/entity/jwt"
+	"github.com/aldngrha/ecommerce-be/internal/repository"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+)
+
+const (
+	IdempotencyKeyHeader    = "idempotency-key"
+	maxIdempotencyKeyLength = 255
+	// idempotencyLease is how long a key stays claimed by a request that has not finished.
+	// A retry after that takes the key over, so a call lost mid-flight does not block its
+	// key for the whole ttl.
+	idempotencyLease = time.Minute
+)
+
+type idempotencyMiddleware struct {
+	idempotencyRepository repository.IIdempotencyRepository
+	ttl                   time.Duration
+	lease                 time.Duration
+}
+
+// Middleware replays the stored response when an authenticated caller retries a request
+// with the same idempotency-key header. It must run after the auth middleware because
+// keys are scoped by the caller. Calls without the header, or without claims, pass through.
+func (im *idempotencyMiddleware) Middleware(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp any, err error) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	keys := md.Get(IdempotencyKeyHeader)
+	if len(keys) == 0 || keys[0] == "" {
+		return handler(ctx, req)
+	}
+
+	claims, err := jwtentity.GetClaimsFromContext(ctx)
+	if err != nil {
+		return handler(ctx, req)
+	}
+
+	key := keys[0]
+	if len(key) > maxIdempotencyKeyLength {
+		return nil, apperror.InvalidArgument("INVALID_IDEMPOTENCY_KEY", "idempotency key is too long", apperror.FieldViolation{
+			Field:       IdempotencyKeyHeader,
+			Description: "must be at most 255 characters",
+		})
+	}
+
+	message, ok := req.(proto.Message)
+	if !ok {
+		return handler(ctx, req)
+	}
+
+	requestHash, err := hashRequest(message)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	record := &entity.IdempotencyKey{
+		UserId:      claims.Subject,
+		Method:      info.FullMethod,
+		Key:         key,
+		RequestHash: requestHash,
+		Status:      entity.IdempotencyStatusInProgress,
+		CreatedAt:   now,
+		ExpiresAt:   now.Add(im.lease),
+	}
+
+	claimed, err := im.claim(ctx, record)
+	if err != nil {
+		return nil, err
+	}
+
+	if !claimed {
+		existing, err := im.idempotencyRepository.GetIdempotencyKey(ctx, record.UserId, record.Method, record.Key)
+		if err != nil {
+			return nil, err
+		}
+		if existing == nil {
+			return nil, apperror.New(codes.Aborted, "IDEMPOTENCY_KEY_CONFLICT", "request with this idempotency key changed concurrently, please retry")
+		}
+
+		return replay(existing, requestHash)
+	}
+
+	res, err := handler(ctx, req)
+	if err != nil {
+		// failed calls are not remembered, so the client can retry with the same key
+		if deleteErr := im.idempotencyRepository.DeleteIdempotencyKey(context.WithoutCancel(ctx), record.UserId, record.Method, record.Key); deleteErr != nil {
+			log.Printf("failed to release idempotency key %s: %v", record.Key, deleteErr)
+		}
+		return nil, err
+	}
+
+	if resMessage, ok := res.(proto.Message); ok {
+		raw, err := proto.Marshal(resMessage)
+		if err != nil {
+			return nil, err
+		}
+
+		responseType := string(proto.MessageName(resMessage))
+		record.ResponseType = &responseType
+		record.Response = raw
+		record.ExpiresAt = time.Now().Add(im.ttl)
+
+		if err = im.idempotencyRepository.CompleteIdempotencyKey(context.WithoutCancel(ctx), record); err != nil {
+			log.Printf("failed to store idempotent response for key %s: %v", record.Key, err)
+		}
+	}
+
+	return res, nil
+}
+
+// claim inserts the in-progress record, taking over an expired record with the same key.
+func (im *idempotencyMiddleware) claim(ctx context.Context, record *entity.IdempotencyKey) (bool, error) {
+	claimed, err := im.idempotencyRepository.TryInsertIdempotencyKey(ctx, record)
+	if err != nil || claimed {
+		return claimed, err
+	}
+
+	return im.idempotencyRepository.TakeOverIdempotencyKey(ctx, record)
+}
+
+func replay(existing *entity.IdempotencyKey, requestHash string) (any, error) {
+	if existing.RequestHash != requestHash {
+		return nil, apperror.InvalidArgument("IDEMPOTENCY_KEY_REUSED", "idempotency key was already used with a different request")
+	}
+
+	if existing.Status != entity.IdempotencyStatusCompleted || existing.ResponseType == nil {
+		return nil, apperror.New(codes.Aborted, "IDEMPOTENCY_KEY_IN_PROGRESS", "a request with this idempotency key is still in progress")
+	}
+
+	messageType, err := protoregistry.GlobalTypes.FindMessageByName(protoreflect.FullName(*existing.ResponseType))
+	if err != nil {
+		return nil, err
+	}
+
+	res := messageType.New().Interface()
+	if err = proto.Unmarshal(existing.Response, res); err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+func hashRequest(message proto.Message) (string, error) {
+	raw, err := proto.MarshalOptions{Deterministic: true}.Marshal(message)
+	if err != nil {
+		return "", err
+	}
+
+	sum := sha256.Sum256(raw)
+
+	return hex.EncodeToString(sum[:]), nil
+}
+
+func NewIdempotencyMiddleware(idempotencyRepository repository.IIdempotencyRepository, ttl time.Duration) *idempotencyMiddleware {
+	return &idempotencyMiddleware{
+		idempotencyRepository: idempotencyRepository,
+		ttl:                   ttl,
+		lease:                 idempotencyLease,
+	}
+}
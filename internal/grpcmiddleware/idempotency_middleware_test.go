@@ -0,0 +1,261 @@
+package grpcmiddleware
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/aldngrha/ecommerce-be/internal/apperror"
+	"github.com/aldngrha/ecommerce-be/internal/entity"
+	jwtentity "github.com/aldngrha/ecommerce-be/internal/entity/jwt"
+	"github.com/golang-jwt/jwt/v5"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+// fakeIdempotencyRepository keeps keys in memory with the semantics of the SQL queries.
+type fakeIdempotencyRepository struct {
+	mu   sync.Mutex
+	keys map[string]entity.IdempotencyKey
+}
+
+func newFakeIdempotencyRepository() *fakeIdempotencyRepository {
+	return &fakeIdempotencyRepository{keys: map[string]entity.IdempotencyKey{}}
+}
+
+func fakeKey(userId string, method string, key string) string {
+	return userId + "|" + method + "|" + key
+}
+
+func (r *fakeIdempotencyRepository) TryInsertIdempotencyKey(ctx context.Context, key *entity.IdempotencyKey) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	id := fakeKey(key.UserId, key.Method, key.Key)
+	if _, ok := r.keys[id]; ok {
+		return false, nil
+	}
+	r.keys[id] = *key
+
+	return true, nil
+}
+
+func (r *fakeIdempotencyRepository) TakeOverIdempotencyKey(ctx context.Context, key *entity.IdempotencyKey) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	id := fakeKey(key.UserId, key.Method, key.Key)
+	existing, ok := r.keys[id]
+	if !ok || existing.ExpiresAt.After(key.CreatedAt) {
+		return false, nil
+	}
+	r.keys[id] = *key
+
+	return true, nil
+}
+
+func (r *fakeIdempotencyRepository) GetIdempotencyKey(ctx context.Context, userId string, method string, key string) (*entity.IdempotencyKey, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	existing, ok := r.keys[fakeKey(userId, method, key)]
+	if !ok {
+		return nil, nil
+	}
+
+	return &existing, nil
+}
+
+func (r *fakeIdempotencyRepository) CompleteIdempotencyKey(ctx context.Context, key *entity.IdempotencyKey) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	id := fakeKey(key.UserId, key.Method, key.Key)
+	existing, ok := r.keys[id]
+	if !ok || existing.RequestHash != key.RequestHash || existing.Status != entity.IdempotencyStatusInProgress {
+		return nil
+	}
+	existing.Status = entity.IdempotencyStatusCompleted
+	existing.ResponseType = key.ResponseType
+	existing.Response = key.Response
+	existing.ExpiresAt = key.ExpiresAt
+	r.keys[id] = existing
+
+	return nil
+}
+
+func (r *fakeIdempotencyRepository) DeleteIdempotencyKey(ctx context.Context, userId string, method string, key string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delete(r.keys, fakeKey(userId, method, key))
+
+	return nil
+}
+
+func (r *fakeIdempotencyRepository) DeleteExpiredIdempotencyKeys(ctx context.Context, now time.Time) (int64, error) {
+	return 0, nil
+}
+
+const testMethod = "/order.OrderService/Checkout"
+
+func idempotentContext(key string) context.Context {
+	claims := &jwtentity.JwtClaims{RegisteredClaims: jwt.RegisteredClaims{Subject: "user-1"}}
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs(IdempotencyKeyHeader, key))
+
+	return claims.SendToContext(ctx)
+}
+
+// countingHandler answers with the request's value followed by the number of calls so far.
+func countingHandler(calls *int) grpc.UnaryHandler {
+	return func(ctx context.Context, req any) (any, error) {
+		*calls++
+		return wrapperspb.String(req.(*wrapperspb.StringValue).GetValue() + "-" + strconv.Itoa(*calls)), nil
+	}
+}
+
+func assertAppError(t *testing.T, err error, code codes.Code, reason string) {
+	t.Helper()
+
+	var appErr *apperror.Error
+	if !errors.As(err, &appErr) || appErr.Code != code || appErr.Reason != reason {
+		t.Fatalf("error = %v, want %s %s", err, code, reason)
+	}
+}
+
+func TestIdempotencyMiddlewareReplaysCompletedRequest(t *testing.T) {
+	repo := newFakeIdempotencyRepository()
+	middleware := NewIdempotencyMiddleware(repo, time.Hour)
+	info := &grpc.UnaryServerInfo{FullMethod: testMethod}
+	calls := 0
+
+	first, err := middleware.Middleware(idempotentContext("key-1"), wrapperspb.String("order"), info, countingHandler(&calls))
+	if err != nil {
+		t.Fatalf("first call returned error %v", err)
+	}
+	second, err := middleware.Middleware(idempotentContext("key-1"), wrapperspb.String("order"), info, countingHandler(&calls))
+	if err != nil {
+		t.Fatalf("retry returned error %v", err)
+	}
+
+	if calls != 1 {
+		t.Errorf("handler called %d times, want 1", calls)
+	}
+	if !proto.Equal(first.(proto.Message), second.(proto.Message)) {
+		t.Errorf("retry = %v, want the stored response %v", second, first)
+	}
+
+	stored, _ := repo.GetIdempotencyKey(context.Background(), "user-1", testMethod, "key-1")
+	if stored.Status != entity.IdempotencyStatusCompleted || stored.ExpiresAt.Before(time.Now().Add(time.Hour-time.Minute)) {
+		t.Errorf("stored key = %s until %s, want completed for the ttl", stored.Status, stored.ExpiresAt)
+	}
+}
+
+func TestIdempotencyMiddlewareRejectsDifferentRequestWithSameKey(t *testing.T) {
+	middleware := NewIdempotencyMiddleware(newFakeIdempotencyRepository(), time.Hour)
+	info := &grpc.UnaryServerInfo{FullMethod: testMethod}
+	calls := 0
+
+	if _, err := middleware.Middleware(idempotentContext("key-1"), wrapperspb.String("order"), info, countingHandler(&calls)); err != nil {
+		t.Fatalf("first call returned error %v", err)
+	}
+	_, err := middleware.Middleware(idempotentContext("key-1"), wrapperspb.String("another order"), info, countingHandler(&calls))
+
+	assertAppError(t, err, codes.InvalidArgument, "IDEMPOTENCY_KEY_REUSED")
+	if calls != 1 {
+		t.Errorf("handler called %d times, want 1", calls)
+	}
+}
+
+func TestIdempotencyMiddlewareHoldsInProgressKeyForLease(t *testing.T) {
+	repo := newFakeIdempotencyRepository()
+	middleware := NewIdempotencyMiddleware(repo, time.Hour)
+	info := &grpc.UnaryServerInfo{FullMethod: testMethod}
+	calls := 0
+
+	_, err := middleware.Middleware(idempotentContext("key-1"), wrapperspb.String("order"), info, func(ctx context.Context, req any) (any, error) {
+		stored, _ := repo.GetIdempotencyKey(ctx, "user-1", testMethod, "key-1")
+		if stored.ExpiresAt.After(time.Now().Add(idempotencyLease)) {
+			t.Errorf("in-progress key expires at %s, want within the lease", stored.ExpiresAt)
+		}
+
+		_, err := middleware.Middleware(idempotentContext("key-1"), req, info, countingHandler(&calls))
+		assertAppError(t, err, codes.Aborted, "IDEMPOTENCY_KEY_IN_PROGRESS")
+
+		return wrapperspb.String("done"), nil
+	})
+	if err != nil {
+		t.Fatalf("first call returned error %v", err)
+	}
+	if calls != 0 {
+		t.Errorf("concurrent retry ran the handler %d times, want 0", calls)
+	}
+}
+
+func TestIdempotencyMiddlewareTakesOverExpiredKey(t *testing.T) {
+	tests := []struct {
+		name   string
+		status string
+	}{
+		{name: "abandoned in progress", status: entity.IdempotencyStatusInProgress},
+		{name: "completed past the ttl", status: entity.IdempotencyStatusCompleted},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := newFakeIdempotencyRepository()
+			repo.TryInsertIdempotencyKey(context.Background(), &entity.IdempotencyKey{
+				UserId:      "user-1",
+				Method:      testMethod,
+				Key:         "key-1",
+				RequestHash: "stale",
+				Status:      tt.status,
+				CreatedAt:   time.Now().Add(-2 * time.Hour),
+				ExpiresAt:   time.Now().Add(-time.Second),
+			})
+			middleware := NewIdempotencyMiddleware(repo, time.Hour)
+			info := &grpc.UnaryServerInfo{FullMethod: testMethod}
+			calls := 0
+
+			res, err := middleware.Middleware(idempotentContext("key-1"), wrapperspb.String("order"), info, countingHandler(&calls))
+			if err != nil {
+				t.Fatalf("call returned error %v", err)
+			}
+			if calls != 1 || res.(*wrapperspb.StringValue).GetValue() != "order-1" {
+				t.Errorf("response = %v after %d calls, want a fresh response", res, calls)
+			}
+
+			stored, _ := repo.GetIdempotencyKey(context.Background(), "user-1", testMethod, "key-1")
+			if stored.Status != entity.IdempotencyStatusCompleted || stored.RequestHash == "stale" {
+				t.Errorf("stored key = %+v, want the new request completed", stored)
+			}
+		})
+	}
+}
+
+func TestIdempotencyMiddlewareReleasesKeyOfFailedCall(t *testing.T) {
+	middleware := NewIdempotencyMiddleware(newFakeIdempotencyRepository(), time.Hour)
+	info := &grpc.UnaryServerInfo{FullMethod: testMethod}
+	failure := apperror.FailedPrecondition("CART_EMPTY", "cart is empty")
+
+	_, err := middleware.Middleware(idempotentContext("key-1"), wrapperspb.String("order"), info, func(ctx context.Context, req any) (any, error) {
+		return nil, failure
+	})
+	if !errors.Is(err, failure) {
+		t.Fatalf("error = %v, want the handler's error", err)
+	}
+
+	calls := 0
+	if _, err = middleware.Middleware(idempotentContext("key-1"), wrapperspb.String("order"), info, countingHandler(&calls)); err != nil {
+		t.Fatalf("retry returned error %v", err)
+	}
+	if calls != 1 {
+		t.Errorf("retry ran the handler %d times, want 1", calls)
+	}
+}
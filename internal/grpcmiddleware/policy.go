@@ -0,0 +1,67 @@
+package grpcmiddleware
+
+import (
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/aldngrha/ecommerce-be/pb/auth"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+)
+
+// defaultPolicy applies to RPCs that do not declare (auth.policy): authenticated, any role.
+var defaultPolicy = &auth.Policy{}
+
+var policyCache sync.Map
+
+// PolicyForMethod reads the (auth.policy) option of a gRPC full method name such as
+// "/product.ProductService/CreateProduct" from the registered proto descriptors.
+func PolicyForMethod(fullMethod string) *auth.Policy {
+	if cached, ok := policyCache.Load(fullMethod); ok {
+		return cached.(*auth.Policy)
+	}
+
+	policy := lookupPolicy(fullMethod)
+	policyCache.Store(fullMethod, policy)
+
+	return policy
+}
+
+func lookupPolicy(fullMethod string) *auth.Policy {
+	name := strings.Replace(strings.TrimPrefix(fullMethod, "/"), "/", ".", 1)
+
+	descriptor, err := protoregistry.GlobalFiles.FindDescriptorByName(protoreflect.FullName(name))
+	if err != nil {
+		return defaultPolicy
+	}
+
+	method, ok := descriptor.(protoreflect.MethodDescriptor)
+	if !ok || method.Options() == nil {
+		return defaultPolicy
+	}
+
+	policy, ok := proto.GetExtension(method.Options(), auth.E_Policy).(*auth.Policy)
+	if !ok || policy == nil {
+		return defaultPolicy
+	}
+
+	return policy
+}
+
+// roleAllowed reports whether role satisfies the policy's role list.
+func roleAllowed(policy *auth.Policy, role string) bool {
+	return len(policy.Roles) == 0 || slices.Contains(policy.Roles, role)
+}
+
+// permissionsGranted reports whether every permission required by the policy is granted.
+func permissionsGranted(policy *auth.Policy, granted []string) bool {
+	for _, required := range policy.Permissions {
+		if !slices.Contains(granted, required) {
+			return false
+		}
+	}
+
+	return true
+}
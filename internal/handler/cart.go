@@ -0,0 +1,98 @@
+package handler
+
+import (
+	"context"
+
+	"github.com/aldngrha/ecommerce-be/internal/service"
+	"github.com/aldngrha/ecommerce-be/internal/utils"
+	"github.com/aldngrha/ecommerce-be/pb/cart"
+)
+
+type cartHandler struct {
+	cart.UnimplementedCartServiceServer
+	cartService service.ICartService
+}
+
+func (ch *cartHandler) AddItem(ctx context.Context, req *cart.AddItemRequest) (*cart.AddItemResponse, error) {
+	validationErrors, err := utils.CheckValidations(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if validationErrors != nil {
+		return &cart.AddItemResponse{
+			Base: utils.ValidationErrorResponse(validationErrors),
+		}, nil
+	}
+
+	res, err := ch.cartService.AddItem(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+func (ch *cartHandler) UpdateQuantity(ctx context.Context, req *cart.UpdateQuantityRequest) (*cart.UpdateQuantityResponse, error) {
+	validationErrors, err := utils.CheckValidations(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if validationErrors != nil {
+		return &cart.UpdateQuantityResponse{
+			Base: utils.ValidationErrorResponse(validationErrors),
+		}, nil
+	}
+
+	res, err := ch.cartService.UpdateQuantity(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+func (ch *cartHandler) RemoveItem(ctx context.Context, req *cart.RemoveItemRequest) (*cart.RemoveItemResponse, error) {
+	validationErrors, err := utils.CheckValidations(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if validationErrors != nil {
+		return &cart.RemoveItemResponse{
+			Base: utils.ValidationErrorResponse(validationErrors),
+		}, nil
+	}
+
+	res, err := ch.cartService.RemoveItem(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+func (ch *cartHandler) GetCart(ctx context.Context, req *cart.GetCartRequest) (*cart.GetCartResponse, error) {
+	res, err := ch.cartService.GetCart(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+func (ch *cartHandler) ClearCart(ctx context.Context, req *cart.ClearCartRequest) (*cart.ClearCartResponse, error) {
+	res, err := ch.cartService.ClearCart(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+func NewCartHandler(cartService service.ICartService) *cartHandler {
+	return &cartHandler{
+		cartService: cartService,
+	}
+}
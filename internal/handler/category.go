@@ -0,0 +1,100 @@
+package handler
+
+import (
+	"context"
+
+	"github.com/aldngrha/ecommerce-be/internal/service"
+	"github.com/aldngrha/ecommerce-be/internal/utils"
+	"github.com/aldngrha/ecommerce-be/pb/category"
+)
+
+type categoryHandler struct {
+	category.UnimplementedCategoryServiceServer
+	categoryService service.ICategoryService
+}
+
+func (cgh *categoryHandler) CreateCategory(ctx context.Context, req *category.CreateCategoryRequest) (*category.CreateCategoryResponse, error) {
+	validationErrors, err := utils.CheckValidations(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if validationErrors != nil {
+		return &category.CreateCategoryResponse{
+			Base: utils.ValidationErrorResponse(validationErrors),
+		}, nil
+	}
+
+	res, err := cgh.categoryService.CreateCategory(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+func (cgh *categoryHandler) EditCategory(ctx context.Context, req *category.EditCategoryRequest) (*category.EditCategoryResponse, error) {
+	validationErrors, err := utils.CheckValidations(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if validationErrors != nil {
+		return &category.EditCategoryResponse{
+			Base: utils.ValidationErrorResponse(validationErrors),
+		}, nil
+	}
+
+	res, err := cgh.categoryService.EditCategory(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+func (cgh *categoryHandler) DeleteCategory(ctx context.Context, req *category.DeleteCategoryRequest) (*category.DeleteCategoryResponse, error) {
+	validationErrors, err := utils.CheckValidations(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if validationErrors != nil {
+		return &category.DeleteCategoryResponse{
+			Base: utils.ValidationErrorResponse(validationErrors),
+		}, nil
+	}
+
+	res, err := cgh.categoryService.DeleteCategory(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+func (cgh *categoryHandler) ListCategories(ctx context.Context, req *category.ListCategoriesRequest) (*category.ListCategoriesResponse, error) {
+	validationErrors, err := utils.CheckValidations(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if validationErrors != nil {
+		return &category.ListCategoriesResponse{
+			Base: utils.ValidationErrorResponse(validationErrors),
+		}, nil
+	}
+
+	res, err := cgh.categoryService.ListCategories(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+func NewCategoryHandler(categoryService service.ICategoryService) *categoryHandler {
+	return &categoryHandler{
+		categoryService: categoryService,
+	}
+}
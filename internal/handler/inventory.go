@@ -0,0 +1,80 @@
+package handler
+
+import (
+	"context"
+
+	"github.com/aldngrha/ecommerce-be/internal/service"
+	"github.com/aldngrha/ecommerce-be/internal/utils"
+	"github.com/aldngrha/ecommerce-be/pb/inventory"
+)
+
+type inventoryHandler struct {
+	inventory.UnimplementedInventoryServiceServer
+	inventoryService service.IInventoryService
+}
+
+func (ih *inventoryHandler) GetStock(ctx context.Context, req *inventory.GetStockRequest) (*inventory.GetStockResponse, error) {
+	validationErrors, err := utils.CheckValidations(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if validationErrors != nil {
+		return &inventory.GetStockResponse{
+			Base: utils.ValidationErrorResponse(validationErrors),
+		}, nil
+	}
+
+	res, err := ih.inventoryService.GetStock(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+func (ih *inventoryHandler) SetStock(ctx context.Context, req *inventory.SetStockRequest) (*inventory.SetStockResponse, error) {
+	validationErrors, err := utils.CheckValidations(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if validationErrors != nil {
+		return &inventory.SetStockResponse{
+			Base: utils.ValidationErrorResponse(validationErrors),
+		}, nil
+	}
+
+	res, err := ih.inventoryService.SetStock(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+func (ih *inventoryHandler) AdjustStock(ctx context.Context, req *inventory.AdjustStockRequest) (*inventory.AdjustStockResponse, error) {
+	validationErrors, err := utils.CheckValidations(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if validationErrors != nil {
+		return &inventory.AdjustStockResponse{
+			Base: utils.ValidationErrorResponse(validationErrors),
+		}, nil
+	}
+
+	res, err := ih.inventoryService.AdjustStock(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+func NewInventoryHandler(inventoryService service.IInventoryService) *inventoryHandler {
+	return &inventoryHandler{
+		inventoryService: inventoryService,
+	}
+}
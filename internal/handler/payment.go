@@ -0,0 +1,60 @@
+package handler
+
+import (
+	"context"
+
+	"github.com/aldngrha/ecommerce-be/internal/service"
+	"github.com/aldngrha/ecommerce-be/internal/utils"
+	"github.com/aldngrha/ecommerce-be/pb/payment"
+)
+
+type paymentHandler struct {
+	payment.UnimplementedPaymentServiceServer
+	paymentService service.IPaymentService
+}
+
+func (pyh *paymentHandler) PayOrder(ctx context.Context, req *payment.PayOrderRequest) (*payment.PayOrderResponse, error) {
+	validationErrors, err := utils.CheckValidations(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if validationErrors != nil {
+		return &payment.PayOrderResponse{
+			Base: utils.ValidationErrorResponse(validationErrors),
+		}, nil
+	}
+
+	res, err := pyh.paymentService.PayOrder(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+func (pyh *paymentHandler) RefundOrder(ctx context.Context, req *payment.RefundOrderRequest) (*payment.RefundOrderResponse, error) {
+	validationErrors, err := utils.CheckValidations(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if validationErrors != nil {
+		return &payment.RefundOrderResponse{
+			Base: utils.ValidationErrorResponse(validationErrors),
+		}, nil
+	}
+
+	res, err := pyh.paymentService.RefundOrder(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+func NewPaymentHandler(paymentService service.IPaymentService) *paymentHandler {
+	return &paymentHandler{
+		paymentService: paymentService,
+	}
+}
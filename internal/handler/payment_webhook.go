@@ -0,0 +1,45 @@
+package handler
+
+import (
+	"errors"
+	"log"
+
+	paymentgateway "github.com/aldngrha/ecommerce-be/internal/payment"
+	"github.com/aldngrha/ecommerce-be/internal/service"
+	"github.com/gofiber/fiber/v2"
+)
+
+type paymentWebhookHandler struct {
+	paymentService service.IPaymentService
+}
+
+// Handle receives provider webhooks. Anything but a 2xx makes the provider retry, so only
+// unverifiable payloads are rejected outright.
+func (wh *paymentWebhookHandler) Handle(c *fiber.Ctx) error {
+	err := wh.paymentService.HandleWebhook(c.UserContext(), c.Body(), c.Get(paymentgateway.WebhookSignatureHeader))
+	if err != nil {
+		if errors.Is(err, paymentgateway.ErrInvalidWebhookSignature) {
+			return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+				"success": false,
+				"message": "Invalid webhook signature",
+			})
+		}
+
+		log.Printf("Failed to handle payment webhook: %v", err)
+		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
+			"success": false,
+			"message": "Failed to handle webhook",
+		})
+	}
+
+	return c.JSON(fiber.Map{
+		"success": true,
+		"message": "Webhook received",
+	})
+}
+
+func NewPaymentWebhookHandler(paymentService service.IPaymentService) *paymentWebhookHandler {
+	return &paymentWebhookHandler{
+		paymentService: paymentService,
+	}
+}
@@ -76,6 +76,106 @@ func (ph *productHandler) EditProduct(ctx context.Context, request *product.Edit
 	return res, nil
 }
 
+func (ph *productHandler) ListProducts(ctx context.Context, request *product.ListProductsRequest) (*product.ListProductsResponse, error) {
+	validationErrors, err := utils.CheckValidations(request)
+	if err != nil {
+		return nil, err
+	}
+
+	if validationErrors != nil {
+		return &product.ListProductsResponse{
+			Base: utils.ValidationErrorResponse(validationErrors),
+		}, nil
+	}
+
+	res, err := ph.productServive.ListProducts(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+func (ph *productHandler) DeleteProduct(ctx context.Context, request *product.DeleteProductRequest) (*product.DeleteProductResponse, error) {
+	validationErrors, err := utils.CheckValidations(request)
+	if err != nil {
+		return nil, err
+	}
+
+	if validationErrors != nil {
+		return &product.DeleteProductResponse{
+			Base: utils.ValidationErrorResponse(validationErrors),
+		}, nil
+	}
+
+	res, err := ph.productServive.DeleteProduct(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+func (ph *productHandler) RestoreProduct(ctx context.Context, request *product.RestoreProductRequest) (*product.RestoreProductResponse, error) {
+	validationErrors, err := utils.CheckValidations(request)
+	if err != nil {
+		return nil, err
+	}
+
+	if validationErrors != nil {
+		return &product.RestoreProductResponse{
+			Base: utils.ValidationErrorResponse(validationErrors),
+		}, nil
+	}
+
+	res, err := ph.productServive.RestoreProduct(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+func (ph *productHandler) ListDeletedProducts(ctx context.Context, request *product.ListDeletedProductsRequest) (*product.ListDeletedProductsResponse, error) {
+	validationErrors, err := utils.CheckValidations(request)
+	if err != nil {
+		return nil, err
+	}
+
+	if validationErrors != nil {
+		return &product.ListDeletedProductsResponse{
+			Base: utils.ValidationErrorResponse(validationErrors),
+		}, nil
+	}
+
+	res, err := ph.productServive.ListDeletedProducts(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
+func (ph *productHandler) SetProductVariants(ctx context.Context, request *product.SetProductVariantsRequest) (*product.SetProductVariantsResponse, error) {
+	validationErrors, err := utils.CheckValidations(request)
+	if err != nil {
+		return nil, err
+	}
+
+	if validationErrors != nil {
+		return &product.SetProductVariantsResponse{
+			Base: utils.ValidationErrorResponse(validationErrors),
+		}, nil
+	}
+
+	res, err := ph.productServive.SetProductVariants(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+
+	return res, nil
+}
+
 func NewProductHandler(productService service.IProductService) *productHandler {
 	return &productHandler{
 		productServive: productService,
@@ -1,14 +1,26 @@
 package handler
 
 import (
+	"errors"
 	"fmt"
+	"io"
+	"log"
+	"mime"
 	"path/filepath"
-	"time"
 
+	"github.com/aldngrha/ecommerce-be/internal/restmiddleware"
+	"github.com/aldngrha/ecommerce-be/internal/service"
+	"github.com/aldngrha/ecommerce-be/internal/storage"
 	"github.com/gofiber/fiber/v2"
 )
 
-func UploadProductImageHandler(c *fiber.Ctx) error {
+type productImageHandler struct {
+	uploadService service.IUploadService
+	blobStore     storage.BlobStore
+	maxBytes      int64
+}
+
+func (pih *productImageHandler) Upload(c *fiber.Ctx) error {
 	file, err := c.FormFile("image")
 	if err != nil {
 		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
@@ -17,44 +29,30 @@ func UploadProductImageHandler(c *fiber.Ctx) error {
 		})
 	}
 
-	ext := filepath.Ext(file.Filename)
-	allowedExts := map[string]bool{
-		".jpg":  true,
-		".jpeg": true,
-		".png":  true,
-		".webp": true,
-	}
-
-	if !allowedExts[ext] {
-		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
+	if file.Size > pih.maxBytes {
+		return c.Status(fiber.StatusRequestEntityTooLarge).JSON(fiber.Map{
 			"success": false,
-			"message": "Invalid image format. Only .jpg, .jpeg, .png, .webp are allowed",
+			"message": fmt.Sprintf("Image must not be larger than %d bytes", pih.maxBytes),
 		})
 	}
 
-	contentType := file.Header.Get("Content-Type")
-	allowedContentType := map[string]bool{
-		"image/jpg":  true,
-		"image/png":  true,
-		"image/webp": true,
-	}
-
-	if !allowedContentType[contentType] {
+	reader, err := file.Open()
+	if err != nil {
 		return c.Status(fiber.StatusBadRequest).JSON(fiber.Map{
 			"success": false,
-			"message": "Invalid image content type. Only image/jpg, image/webp are allowed",
+			"message": "Image data not found",
 		})
 	}
+	defer reader.Close()
 
-	timestamp := time.Now().UnixNano()
-	filename := fmt.Sprintf("product_%d%s", timestamp, filepath.Ext(file.Filename))
-	uploadPath := "./storage/images/products/" + filename
-	err = c.SaveFile(file, uploadPath)
+	content, err := io.ReadAll(io.LimitReader(reader, pih.maxBytes+1))
 	if err != nil {
-		return c.Status(fiber.StatusInternalServerError).JSON(fiber.Map{
-			"success": false,
-			"message": "Failed to save image",
-		})
+		return restmiddleware.WriteError(c, err)
+	}
+
+	filename, err := pih.uploadService.UploadProductImage(c.UserContext(), content)
+	if err != nil {
+		return restmiddleware.WriteError(c, err)
 	}
 
 	return c.JSON(fiber.Map{
@@ -63,3 +61,30 @@ func UploadProductImageHandler(c *fiber.Ctx) error {
 		"data":    filename,
 	})
 }
+
+// Serve streams a product image from the blob store. With S3 clients usually get the
+// bucket URL instead, this route keeps working for the local disk store.
+func (pih *productImageHandler) Serve(c *fiber.Ctx) error {
+	fileName := c.Params("filename")
+
+	content, err := pih.blobStore.Get(c.UserContext(), storage.ProductImageKey(fileName))
+	if err != nil {
+		if errors.Is(err, storage.ErrNotFound) {
+			return c.Status(fiber.StatusNotFound).SendString("File not found")
+		}
+
+		log.Println(err)
+		return c.Status(fiber.StatusInternalServerError).SendString("Internal server error")
+	}
+
+	c.Set("Content-Type", mime.TypeByExtension(filepath.Ext(fileName)))
+	return c.SendStream(content)
+}
+
+func NewProductImageHandler(uploadService service.IUploadService, blobStore storage.BlobStore, maxBytes int64) *productImageHandler {
+	return &productImageHandler{
+		uploadService: uploadService,
+		blobStore:     blobStore,
+		maxBytes:      maxBytes,
+	}
+}
@@ -0,0 +1,118 @@
+package imaging
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+)
+
+const (
+	defaultMaxDimension = 4096
+	defaultVariants     = "thumbnail:200x200,medium:800x800"
+	defaultJpegQuality  = 85
+)
+
+// Variant is a resized copy that fits in MaxWidth x MaxHeight.
+type Variant struct {
+	Name      string
+	MaxWidth  int
+	MaxHeight int
+}
+
+type Config struct {
+	// uploads larger than this are rejected rather than scaled down
+	MaxWidth    int
+	MaxHeight   int
+	Variants    []Variant
+	JpegQuality int
+}
+
+// VariantNames lists the original and the configured variants, in order.
+func (c Config) VariantNames() []string {
+	names := []string{OriginalVariant}
+	for _, variant := range c.Variants {
+		names = append(names, variant.Name)
+	}
+
+	return names
+}
+
+// ThumbnailVariant names the smallest configured variant, the one to show in lists. It is
+// the original when no variants are configured.
+func (c Config) ThumbnailVariant() string {
+	name := OriginalVariant
+	smallest := 0
+	for _, variant := range c.Variants {
+		if area := variant.MaxWidth * variant.MaxHeight; smallest == 0 || area < smallest {
+			name, smallest = variant.Name, area
+		}
+	}
+
+	return name
+}
+
+// ParseVariants reads a list such as "thumbnail:200x200,medium:800x800".
+func ParseVariants(spec string) ([]Variant, error) {
+	variants := make([]Variant, 0)
+	seen := map[string]bool{OriginalVariant: true}
+	for _, part := range strings.Split(spec, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+
+		name, size, ok := strings.Cut(part, ":")
+		widthStr, heightStr, okSize := strings.Cut(size, "x")
+		width, errWidth := strconv.Atoi(widthStr)
+		height, errHeight := strconv.Atoi(heightStr)
+		if !ok || !okSize || errWidth != nil || errHeight != nil || width < 1 || height < 1 || name == "" {
+			return nil, fmt.Errorf("invalid image variant %q, expected name:WIDTHxHEIGHT", part)
+		}
+		if seen[name] {
+			return nil, fmt.Errorf("duplicate image variant %q", name)
+		}
+		seen[name] = true
+
+		variants = append(variants, Variant{Name: name, MaxWidth: width, MaxHeight: height})
+	}
+
+	return variants, nil
+}
+
+// ConfigFromEnv reads IMAGE_MAX_DIMENSION, IMAGE_VARIANTS and IMAGE_JPEG_QUALITY.
+func ConfigFromEnv() (Config, error) {
+	config := Config{
+		MaxWidth:    defaultMaxDimension,
+		MaxHeight:   defaultMaxDimension,
+		JpegQuality: defaultJpegQuality,
+	}
+
+	if value := os.Getenv("IMAGE_MAX_DIMENSION"); value != "" {
+		maxDimension, err := strconv.Atoi(value)
+		if err != nil || maxDimension < 1 {
+			return Config{}, fmt.Errorf("invalid IMAGE_MAX_DIMENSION %q", value)
+		}
+		config.MaxWidth, config.MaxHeight = maxDimension, maxDimension
+	}
+
+	if value := os.Getenv("IMAGE_JPEG_QUALITY"); value != "" {
+		quality, err := strconv.Atoi(value)
+		if err != nil || quality < 1 || quality > 100 {
+			return Config{}, fmt.Errorf("invalid IMAGE_JPEG_QUALITY %q", value)
+		}
+		config.JpegQuality = quality
+	}
+
+	spec, ok := os.LookupEnv("IMAGE_VARIANTS")
+	if !ok {
+		spec = defaultVariants
+	}
+	variants, err := ParseVariants(spec)
+	if err != nil {
+		return Config{}, err
+	}
+	config.Variants = variants
+
+	return config, nil
+}
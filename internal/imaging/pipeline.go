@@ -0,0 +1,137 @@
+package imaging
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"image"
+	"image/jpeg"
+	"image/png"
+	"io"
+
+	"golang.org/x/image/draw"
+	_ "golang.org/x/image/webp"
+)
+
+// OriginalVariant names the full size image, re-encoded like the other variants.
+const OriginalVariant = "original"
+
+var ErrUnsupportedFormat = errors.New("unsupported image format, only jpeg, png and webp are allowed")
+
+// DimensionsError rejects images larger than the configured maximum.
+type DimensionsError struct {
+	Width     int
+	Height    int
+	MaxWidth  int
+	MaxHeight int
+}
+
+func (e *DimensionsError) Error() string {
+	return fmt.Sprintf("image is %dx%d, the maximum is %dx%d", e.Width, e.Height, e.MaxWidth, e.MaxHeight)
+}
+
+type ProcessedImage struct {
+	Variant     string
+	Content     []byte
+	ContentType string
+	// Extension includes the dot, such as ".jpg"
+	Extension string
+	Width     int
+	Height    int
+}
+
+// Pipeline validates uploaded images by decoding them and re-encodes them into the
+// original and every configured variant. Re-encoding drops all metadata, EXIF and GPS
+// included; the EXIF orientation is applied to the pixels first.
+type Pipeline struct {
+	config Config
+}
+
+func (p *Pipeline) Process(content io.Reader) ([]ProcessedImage, error) {
+	data, err := io.ReadAll(content)
+	if err != nil {
+		return nil, err
+	}
+
+	// check the header before decoding, so huge images are refused without allocating them
+	imageConfig, format, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil || (format != "jpeg" && format != "png" && format != "webp") {
+		return nil, ErrUnsupportedFormat
+	}
+	if imageConfig.Width > p.config.MaxWidth || imageConfig.Height > p.config.MaxHeight {
+		return nil, &DimensionsError{
+			Width:     imageConfig.Width,
+			Height:    imageConfig.Height,
+			MaxWidth:  p.config.MaxWidth,
+			MaxHeight: p.config.MaxHeight,
+		}
+	}
+
+	img, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, ErrUnsupportedFormat
+	}
+
+	if format == "jpeg" {
+		img = applyOrientation(img, jpegOrientation(data))
+	}
+
+	// png keeps its transparency, everything else becomes jpeg
+	encode := func(w io.Writer, img image.Image) error {
+		return jpeg.Encode(w, img, &jpeg.Options{Quality: p.config.JpegQuality})
+	}
+	contentType, extension := "image/jpeg", ".jpg"
+	if format == "png" {
+		encode = png.Encode
+		contentType, extension = "image/png", ".png"
+	}
+
+	variants := append([]Variant{{Name: OriginalVariant}}, p.config.Variants...)
+	images := make([]ProcessedImage, 0, len(variants))
+	for _, variant := range variants {
+		resized := img
+		if variant.Name != OriginalVariant {
+			resized = fit(img, variant.MaxWidth, variant.MaxHeight)
+		}
+
+		var buf bytes.Buffer
+		if err = encode(&buf, resized); err != nil {
+			return nil, err
+		}
+
+		images = append(images, ProcessedImage{
+			Variant:     variant.Name,
+			Content:     buf.Bytes(),
+			ContentType: contentType,
+			Extension:   extension,
+			Width:       resized.Bounds().Dx(),
+			Height:      resized.Bounds().Dy(),
+		})
+	}
+
+	return images, nil
+}
+
+// fit scales img down to fit in maxWidth x maxHeight keeping its aspect ratio. Smaller
+// images are returned as they are.
+func fit(img image.Image, maxWidth int, maxHeight int) image.Image {
+	width, height := img.Bounds().Dx(), img.Bounds().Dy()
+	if width <= maxWidth && height <= maxHeight {
+		return img
+	}
+
+	scale := min(float64(maxWidth)/float64(width), float64(maxHeight)/float64(height))
+	targetWidth := max(1, int(float64(width)*scale+0.5))
+	targetHeight := max(1, int(float64(height)*scale+0.5))
+
+	resized := image.NewRGBA(image.Rect(0, 0, targetWidth, targetHeight))
+	draw.CatmullRom.Scale(resized, resized.Bounds(), img, img.Bounds(), draw.Src, nil)
+
+	return resized
+}
+
+func NewPipeline(config Config) *Pipeline {
+	return &Pipeline{
+		config: config,
+	}
+}
@@ -0,0 +1,25 @@
+package job
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/aldngrha/ecommerce-be/internal/repository"
+)
+
+// RunIdempotencyKeySweeper deletes stored idempotent responses past their TTL.
+func RunIdempotencyKeySweeper(ctx context.Context, idempotencyRepository repository.IIdempotencyRepository, interval time.Duration) {
+	runPeriodically(ctx, "idempotency key sweeper", interval, func(ctx context.Context) error {
+		deleted, err := idempotencyRepository.DeleteExpiredIdempotencyKeys(ctx, time.Now())
+		if err != nil {
+			return err
+		}
+
+		if deleted > 0 {
+			log.Printf("Idempotency key sweeper removed %d expired keys", deleted)
+		}
+
+		return nil
+	})
+}
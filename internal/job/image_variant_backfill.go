@@ -0,0 +1,78 @@
+package job
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"log"
+
+	"github.com/aldngrha/ecommerce-be/internal/imaging"
+	"github.com/aldngrha/ecommerce-be/internal/repository"
+	"github.com/aldngrha/ecommerce-be/internal/storage"
+)
+
+// BackfillImageVariants generates the missing resized variants of every referenced product
+// image, such as images uploaded before the imaging pipeline existed. Originals are never
+// rewritten. Images the pipeline cannot process are logged and skipped. It returns the
+// number of images that got new variants.
+func BackfillImageVariants(ctx context.Context, uploadRepository repository.IUploadRepository, blobStore storage.BlobStore, pipeline *imaging.Pipeline, variants []string) (int, error) {
+	fileNames, err := uploadRepository.ListReferencedImages(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	backfilled := 0
+	for _, fileName := range fileNames {
+		missing := make(map[string]bool)
+		for _, variant := range variants {
+			if variant == imaging.OriginalVariant {
+				continue
+			}
+			exists, err := blobStore.Exists(ctx, storage.ProductImageVariantKey(fileName, variant))
+			if err != nil {
+				return backfilled, err
+			}
+			if !exists {
+				missing[variant] = true
+			}
+		}
+		if len(missing) == 0 {
+			continue
+		}
+
+		original, err := blobStore.Get(ctx, storage.ProductImageKey(fileName))
+		if errors.Is(err, storage.ErrNotFound) {
+			log.Printf("Image variant backfill skipped %s: the original is missing", fileName)
+			continue
+		}
+		if err != nil {
+			return backfilled, err
+		}
+
+		var content bytes.Buffer
+		_, err = content.ReadFrom(original)
+		original.Close()
+		if err != nil {
+			return backfilled, err
+		}
+
+		images, err := pipeline.Process(&content)
+		if err != nil {
+			log.Printf("Image variant backfill skipped %s: %v", fileName, err)
+			continue
+		}
+
+		for _, image := range images {
+			if !missing[image.Variant] {
+				continue
+			}
+			err = blobStore.Put(ctx, storage.ProductImageVariantKey(fileName, image.Variant), bytes.NewReader(image.Content), image.ContentType)
+			if err != nil {
+				return backfilled, err
+			}
+		}
+		backfilled++
+	}
+
+	return backfilled, nil
+}
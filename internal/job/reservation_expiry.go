@@ -0,0 +1,26 @@
+package job
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/aldngrha/ecommerce-be/internal/service"
+)
+
+// RunReservationExpiry cancels unpaid orders whose stock reservations have expired so the
+// stock becomes available again.
+func RunReservationExpiry(ctx context.Context, orderService service.IOrderService, interval time.Duration) {
+	runPeriodically(ctx, "reservation expiry", interval, func(ctx context.Context) error {
+		cancelled, err := orderService.ExpireUnpaidOrders(ctx, time.Now())
+		if err != nil {
+			return err
+		}
+
+		if cancelled > 0 {
+			log.Printf("Reservation expiry cancelled %d unpaid orders", cancelled)
+		}
+
+		return nil
+	})
+}
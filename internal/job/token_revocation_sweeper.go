@@ -0,0 +1,26 @@
+package job
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/aldngrha/ecommerce-be/internal/repository"
+)
+
+// RunTokenRevocationSweeper removes revocation entries whose tokens have already
+// expired. It blocks until ctx is cancelled, so start it in its own goroutine.
+func RunTokenRevocationSweeper(ctx context.Context, store repository.TokenRevocationStore, interval time.Duration) {
+	runPeriodically(ctx, "token revocation sweeper", interval, func(ctx context.Context) error {
+		deleted, err := store.DeleteExpired(ctx, time.Now())
+		if err != nil {
+			return err
+		}
+
+		if deleted > 0 {
+			log.Printf("Token revocation sweeper removed %d expired entries", deleted)
+		}
+
+		return nil
+	})
+}
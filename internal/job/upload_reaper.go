@@ -0,0 +1,48 @@
+package job
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/aldngrha/ecommerce-be/internal/repository"
+	"github.com/aldngrha/ecommerce-be/internal/storage"
+)
+
+const uploadReaperBatchSize = 100
+
+// RunUploadReaper deletes product images no product references: uploads never attached
+// to a product within gracePeriod, and images a product stopped using longer ago than that.
+// Each upload is claimed before its files are removed, so an image attached to a product
+// in the meantime is never deleted.
+func RunUploadReaper(ctx context.Context, uploadRepository repository.IUploadRepository, blobStore storage.BlobStore, imageVariants []string, gracePeriod time.Duration, interval time.Duration) {
+	runPeriodically(ctx, "upload reaper", interval, func(ctx context.Context) error {
+		now := time.Now()
+		fileNames, err := uploadRepository.ListReapableUploads(ctx, now.Add(-gracePeriod), uploadReaperBatchSize)
+		if err != nil {
+			return err
+		}
+
+		deleted := 0
+		for _, fileName := range fileNames {
+			claimed, err := uploadRepository.ClaimUploadForDeletion(ctx, fileName, now)
+			if err != nil {
+				return err
+			}
+			if !claimed {
+				continue
+			}
+
+			if err = storage.DeleteProductImage(ctx, blobStore, fileName, imageVariants); err != nil {
+				return err
+			}
+			deleted++
+		}
+
+		if deleted > 0 {
+			log.Printf("Upload reaper removed %d unused images", deleted)
+		}
+
+		return nil
+	})
+}
@@ -0,0 +1,23 @@
+package mailer
+
+import (
+	"log"
+	"os"
+)
+
+// NewMailerFromEnv picks the mail transport from MAIL_DRIVER, falling back to logging mails locally.
+func NewMailerFromEnv() Mailer {
+	if os.Getenv("MAIL_DRIVER") == "smtp" {
+		return NewSMTPMailer(
+			os.Getenv("SMTP_HOST"),
+			os.Getenv("SMTP_PORT"),
+			os.Getenv("SMTP_USERNAME"),
+			os.Getenv("SMTP_PASSWORD"),
+			os.Getenv("MAIL_FROM"),
+		)
+	}
+
+	log.Println("WARNING: MAIL_DRIVER is not smtp, emails are only logged and password reset links end up in the logs")
+
+	return NewLogMailer(os.Getenv("MAIL_LOG_DIR"))
+}
@@ -0,0 +1,37 @@
+package mailer
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// logMailer is the local development stand-in. It logs every message, links included,
+// and when dir is set also writes it to dir as a .eml file so links can be copied out.
+type logMailer struct {
+	dir string
+}
+
+func (m *logMailer) Send(ctx context.Context, message Message) error {
+	log.Printf("Mail to %s: %s\n%s", message.To, message.Subject, message.Body)
+
+	if m.dir == "" {
+		return nil
+	}
+
+	if err := os.MkdirAll(m.dir, 0755); err != nil {
+		return err
+	}
+
+	filename := fmt.Sprintf("mail_%d.eml", time.Now().UnixNano())
+	content := fmt.Sprintf("To: %s\r\nSubject: %s\r\n\r\n%s", message.To, message.Subject, message.Body)
+
+	return os.WriteFile(filepath.Join(m.dir, filename), []byte(content), 0644)
+}
+
+func NewLogMailer(dir string) *logMailer {
+	return &logMailer{dir: dir}
+}
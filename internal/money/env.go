@@ -0,0 +1,16 @@
+package money
+
+import "os"
+
+const defaultStoreCurrency = "IDR"
+
+// StoreCurrencyFromEnv returns STORE_CURRENCY, the currency every price is set in,
+// defaulting to IDR.
+func StoreCurrencyFromEnv() (string, error) {
+	currency := os.Getenv("STORE_CURRENCY")
+	if currency == "" {
+		return defaultStoreCurrency, nil
+	}
+
+	return NormalizeCurrency(currency)
+}
@@ -0,0 +1,73 @@
+package money
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+)
+
+// ErrCurrencyMismatch is returned when amounts in different currencies are combined.
+var ErrCurrencyMismatch = errors.New("currency mismatch")
+
+// supportedCurrencies lists the ISO 4217 currencies the store can sell in. All of them have
+// a minor unit of a hundredth, which the 000016 migrations rely on, so adding a currency
+// with another exponent needs those revisited.
+var supportedCurrencies = map[string]bool{
+	"IDR": true,
+	"SGD": true,
+	"MYR": true,
+	"USD": true,
+	"EUR": true,
+}
+
+// Money is an exact amount in the minor unit of an ISO 4217 currency, so 150000 IDR is
+// Rp 1,500.00. Amounts are never floating point, arithmetic on them cannot drift.
+type Money struct {
+	Amount   int64
+	Currency string
+}
+
+func New(amount int64, currency string) Money {
+	return Money{Amount: amount, Currency: currency}
+}
+
+func Zero(currency string) Money {
+	return Money{Currency: currency}
+}
+
+func IsSupportedCurrency(currency string) bool {
+	return supportedCurrencies[currency]
+}
+
+// Add returns m + other. Both must be in the same currency.
+func (m Money) Add(other Money) (Money, error) {
+	if m.Currency != other.Currency {
+		return Money{}, fmt.Errorf("%w: %s and %s", ErrCurrencyMismatch, m.Currency, other.Currency)
+	}
+	if (other.Amount > 0 && m.Amount > math.MaxInt64-other.Amount) || (other.Amount < 0 && m.Amount < math.MinInt64-other.Amount) {
+		return Money{}, errors.New("money amount overflows")
+	}
+
+	return Money{Amount: m.Amount + other.Amount, Currency: m.Currency}, nil
+}
+
+// Multiply returns m times quantity, e.g. the subtotal of a line.
+func (m Money) Multiply(quantity int64) (Money, error) {
+	// MinInt64 * -1 wraps back to MinInt64, which the division check cannot catch
+	if (m.Amount == math.MinInt64 && quantity == -1) || (quantity != 0 && (m.Amount*quantity)/quantity != m.Amount) {
+		return Money{}, errors.New("money amount overflows")
+	}
+
+	return Money{Amount: m.Amount * quantity, Currency: m.Currency}, nil
+}
+
+// NormalizeCurrency upper cases a currency code and checks it is supported.
+func NormalizeCurrency(currency string) (string, error) {
+	currency = strings.ToUpper(strings.TrimSpace(currency))
+	if !IsSupportedCurrency(currency) {
+		return "", fmt.Errorf("unsupported currency %q", currency)
+	}
+
+	return currency, nil
+}
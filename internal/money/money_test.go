@@ -0,0 +1,97 @@
+package money
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+func TestAdd(t *testing.T) {
+	tests := []struct {
+		name      string
+		m         Money
+		other     Money
+		want      Money
+		wantError bool
+	}{
+		{name: "sums amounts", m: New(150000, "IDR"), other: New(250, "IDR"), want: New(150250, "IDR")},
+		{name: "adds negative amounts", m: New(100, "IDR"), other: New(-300, "IDR"), want: New(-200, "IDR")},
+		{name: "reaches max", m: New(math.MaxInt64-1, "IDR"), other: New(1, "IDR"), want: New(math.MaxInt64, "IDR")},
+		{name: "overflows above max", m: New(math.MaxInt64, "IDR"), other: New(1, "IDR"), wantError: true},
+		{name: "reaches min", m: New(math.MinInt64+1, "IDR"), other: New(-1, "IDR"), want: New(math.MinInt64, "IDR")},
+		{name: "overflows below min", m: New(math.MinInt64, "IDR"), other: New(-1, "IDR"), wantError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.m.Add(tt.other)
+			if tt.wantError {
+				if err == nil {
+					t.Fatalf("Add() = %v, want an overflow error", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Add() returned error %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("Add() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAddRejectsCurrencyMismatch(t *testing.T) {
+	_, err := New(100, "IDR").Add(New(100, "USD"))
+	if !errors.Is(err, ErrCurrencyMismatch) {
+		t.Fatalf("Add() error = %v, want ErrCurrencyMismatch", err)
+	}
+}
+
+func TestMultiply(t *testing.T) {
+	tests := []struct {
+		name      string
+		m         Money
+		quantity  int64
+		want      Money
+		wantError bool
+	}{
+		{name: "multiplies", m: New(1999, "USD"), quantity: 3, want: New(5997, "USD")},
+		{name: "by zero", m: New(math.MaxInt64, "USD"), quantity: 0, want: New(0, "USD")},
+		{name: "by negative", m: New(250, "USD"), quantity: -2, want: New(-500, "USD")},
+		{name: "reaches max", m: New(math.MaxInt64/2, "USD"), quantity: 2, want: New(math.MaxInt64-1, "USD")},
+		{name: "overflows above max", m: New(math.MaxInt64/2+1, "USD"), quantity: 2, wantError: true},
+		{name: "overflows below min", m: New(math.MinInt64/2-1, "USD"), quantity: 2, wantError: true},
+		{name: "overflows negating min", m: New(math.MinInt64, "USD"), quantity: -1, wantError: true},
+		{name: "overflows by min quantity", m: New(-1, "USD"), quantity: math.MinInt64, wantError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.m.Multiply(tt.quantity)
+			if tt.wantError {
+				if err == nil {
+					t.Fatalf("Multiply() = %v, want an overflow error", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Multiply() returned error %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("Multiply() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNormalizeCurrency(t *testing.T) {
+	got, err := NormalizeCurrency(" idr ")
+	if err != nil || got != "IDR" {
+		t.Errorf("NormalizeCurrency(\" idr \") = %q, %v, want \"IDR\", nil", got, err)
+	}
+
+	if _, err = NormalizeCurrency("JPY"); err == nil {
+		t.Error("NormalizeCurrency(\"JPY\") returned no error, want unsupported currency")
+	}
+}
@@ -0,0 +1,20 @@
+package payment
+
+import (
+	"fmt"
+	"os"
+)
+
+// NewGatewayFromEnv picks the payment provider from PAYMENT_GATEWAY. The fake gateway
+// accepts test cards as real payments, so it is only built when asked for by name and
+// there is no default.
+func NewGatewayFromEnv() (PaymentGateway, error) {
+	switch driver := os.Getenv("PAYMENT_GATEWAY"); driver {
+	case "fake":
+		return NewFakeGateway(os.Getenv("PAYMENT_WEBHOOK_SECRET")), nil
+	case "":
+		return nil, fmt.Errorf("PAYMENT_GATEWAY is not set")
+	default:
+		return nil, fmt.Errorf("unsupported PAYMENT_GATEWAY %q", driver)
+	}
+}
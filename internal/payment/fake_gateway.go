@@ -0,0 +1,109 @@
+package payment
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/aldngrha/ecommerce-be/internal/money"
+)
+
+// Test card numbers understood by the fake gateway. Any other number is declined as
+// "invalid_number".
+const (
+	FakeCardSuccess           = "4242424242424242"
+	FakeCardDeclined          = "4000000000000002"
+	FakeCardInsufficientFunds = "4000000000009995"
+	FakeCardCaptureFails      = "4000000000000341"
+	FakeCardProcessingError   = "4000000000000119"
+)
+
+const fakeReferencePrefix = "fake_"
+
+// fakeGateway is a deterministic stand-in for local development and tests. It keeps no
+// state: the outcome of every call follows from the card number, which is encoded in the
+// provider reference so captures and refunds behave the same after a restart.
+type fakeGateway struct {
+	webhookSecret string
+}
+
+func (g *fakeGateway) Name() string {
+	return "fake"
+}
+
+func (g *fakeGateway) Authorize(ctx context.Context, request AuthorizeRequest) (*Authorization, error) {
+	if request.Amount.Amount <= 0 {
+		return nil, &DeclinedError{Reason: "invalid_amount"}
+	}
+
+	switch request.Card.Number {
+	case FakeCardSuccess, FakeCardCaptureFails:
+	case FakeCardDeclined:
+		return nil, &DeclinedError{Reason: "card_declined"}
+	case FakeCardInsufficientFunds:
+		return nil, &DeclinedError{Reason: "insufficient_funds"}
+	case FakeCardProcessingError:
+		return nil, errors.New("fake gateway: processing error")
+	default:
+		return nil, &DeclinedError{Reason: "invalid_number"}
+	}
+
+	return &Authorization{
+		ProviderReference: fmt.Sprintf("%s%s_%s", fakeReferencePrefix, request.Card.Number[len(request.Card.Number)-4:], request.IntentId),
+	}, nil
+}
+
+func (g *fakeGateway) Capture(ctx context.Context, providerReference string, amount money.Money) error {
+	last4, err := fakeReferenceCard(providerReference)
+	if err != nil {
+		return err
+	}
+
+	if last4 == FakeCardCaptureFails[len(FakeCardCaptureFails)-4:] {
+		return &DeclinedError{Reason: "capture_failed"}
+	}
+
+	return nil
+}
+
+// Refund keeps no state, so repeating it with the same idempotencyKey trivially succeeds.
+func (g *fakeGateway) Refund(ctx context.Context, providerReference string, idempotencyKey string, amount money.Money) error {
+	if _, err := fakeReferenceCard(providerReference); err != nil {
+		return err
+	}
+	if amount.Amount <= 0 {
+		return &DeclinedError{Reason: "invalid_amount"}
+	}
+
+	return nil
+}
+
+func (g *fakeGateway) VerifyWebhook(payload []byte, signature string) (*WebhookEvent, error) {
+	if err := verifyWebhookSignature(g.webhookSecret, payload, signature, time.Now()); err != nil {
+		return nil, err
+	}
+
+	var event WebhookEvent
+	if err := json.Unmarshal(payload, &event); err != nil {
+		return nil, fmt.Errorf("decode webhook: %w", err)
+	}
+
+	return &event, nil
+}
+
+func fakeReferenceCard(providerReference string) (string, error) {
+	rest, ok := strings.CutPrefix(providerReference, fakeReferencePrefix)
+	last4, _, found := strings.Cut(rest, "_")
+	if !ok || !found {
+		return "", fmt.Errorf("fake gateway: unknown payment %q", providerReference)
+	}
+
+	return last4, nil
+}
+
+func NewFakeGateway(webhookSecret string) PaymentGateway {
+	return &fakeGateway{webhookSecret: webhookSecret}
+}
@@ -0,0 +1,106 @@
+package payment
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/aldngrha/ecommerce-be/internal/money"
+)
+
+func TestFakeGatewayCards(t *testing.T) {
+	tests := []struct {
+		card              string
+		wantAuthorizeFail string
+		wantCaptureFail   string
+		wantProcessingErr bool
+	}{
+		{card: FakeCardSuccess},
+		{card: FakeCardCaptureFails, wantCaptureFail: "capture_failed"},
+		{card: FakeCardDeclined, wantAuthorizeFail: "card_declined"},
+		{card: FakeCardInsufficientFunds, wantAuthorizeFail: "insufficient_funds"},
+		{card: FakeCardProcessingError, wantProcessingErr: true},
+		{card: "4111111111111111", wantAuthorizeFail: "invalid_number"},
+	}
+
+	ctx := context.Background()
+	gateway := NewFakeGateway("whsec_test")
+	amount := money.New(150000, "IDR")
+
+	for _, tt := range tests {
+		t.Run(tt.card, func(t *testing.T) {
+			authorization, err := gateway.Authorize(ctx, AuthorizeRequest{IntentId: "intent_1", Amount: amount, Card: Card{Number: tt.card}})
+
+			var declinedErr *DeclinedError
+			switch {
+			case tt.wantProcessingErr:
+				if err == nil || errors.As(err, &declinedErr) {
+					t.Fatalf("Authorize() error = %v, want a processing error", err)
+				}
+				return
+			case tt.wantAuthorizeFail != "":
+				if !errors.As(err, &declinedErr) || declinedErr.Reason != tt.wantAuthorizeFail {
+					t.Fatalf("Authorize() error = %v, want decline %s", err, tt.wantAuthorizeFail)
+				}
+				return
+			case err != nil:
+				t.Fatalf("Authorize() returned error %v", err)
+			}
+
+			err = gateway.Capture(ctx, authorization.ProviderReference, amount)
+			if tt.wantCaptureFail != "" {
+				if !errors.As(err, &declinedErr) || declinedErr.Reason != tt.wantCaptureFail {
+					t.Fatalf("Capture() error = %v, want decline %s", err, tt.wantCaptureFail)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Capture() returned error %v", err)
+			}
+
+			// a retried refund with the same key must succeed as well
+			for range 2 {
+				if err = gateway.Refund(ctx, authorization.ProviderReference, "intent_1", amount); err != nil {
+					t.Fatalf("Refund() returned error %v", err)
+				}
+			}
+		})
+	}
+}
+
+func TestFakeGatewayRejectsInvalidAmountsAndReferences(t *testing.T) {
+	ctx := context.Background()
+	gateway := NewFakeGateway("whsec_test")
+
+	var declinedErr *DeclinedError
+	_, err := gateway.Authorize(ctx, AuthorizeRequest{IntentId: "intent_1", Amount: money.Zero("IDR"), Card: Card{Number: FakeCardSuccess}})
+	if !errors.As(err, &declinedErr) || declinedErr.Reason != "invalid_amount" {
+		t.Errorf("Authorize() with zero amount error = %v, want decline invalid_amount", err)
+	}
+
+	if err = gateway.Capture(ctx, "ch_unknown", money.New(100, "IDR")); err == nil {
+		t.Error("Capture() of an unknown reference returned no error")
+	}
+	if err = gateway.Refund(ctx, "ch_unknown", "intent_1", money.New(100, "IDR")); err == nil {
+		t.Error("Refund() of an unknown reference returned no error")
+	}
+}
+
+func TestFakeGatewayVerifyWebhook(t *testing.T) {
+	gateway := NewFakeGateway("whsec_test")
+	payload := []byte(`{"id":"evt_1","type":"payment.captured","provider_reference":"fake_4242_intent_1"}`)
+
+	event, err := gateway.VerifyWebhook(payload, SignWebhook("whsec_test", payload, time.Now()))
+	if err != nil {
+		t.Fatalf("VerifyWebhook() returned error %v", err)
+	}
+	if event.Id != "evt_1" || event.Type != WebhookEventCaptured || event.ProviderReference != "fake_4242_intent_1" {
+		t.Errorf("VerifyWebhook() = %+v, want the signed event", event)
+	}
+
+	_, err = gateway.VerifyWebhook(payload, SignWebhook("whsec_other", payload, time.Now()))
+	if !errors.Is(err, ErrInvalidWebhookSignature) {
+		t.Errorf("VerifyWebhook() with a wrong secret error = %v, want ErrInvalidWebhookSignature", err)
+	}
+}
@@ -0,0 +1,66 @@
+package payment
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/aldngrha/ecommerce-be/internal/money"
+)
+
+// ErrInvalidWebhookSignature is returned by VerifyWebhook when the payload was not signed
+// by the provider or the signature is too old.
+var ErrInvalidWebhookSignature = errors.New("invalid webhook signature")
+
+// DeclinedError is a payment the provider refused, as opposed to a failure to reach it.
+// Reason is a short machine readable code such as "insufficient_funds".
+type DeclinedError struct {
+	Reason string
+}
+
+func (e *DeclinedError) Error() string {
+	return fmt.Sprintf("payment declined: %s", e.Reason)
+}
+
+type Card struct {
+	Number   string
+	ExpMonth int32
+	ExpYear  int32
+	Cvc      string
+}
+
+type AuthorizeRequest struct {
+	// IntentId is our payment intent id, sent as the provider's idempotency key.
+	IntentId string
+	Amount   money.Money
+	Card     Card
+}
+
+type Authorization struct {
+	ProviderReference string
+}
+
+const (
+	WebhookEventCaptured = "payment.captured"
+	WebhookEventFailed   = "payment.failed"
+	WebhookEventRefunded = "payment.refunded"
+)
+
+type WebhookEvent struct {
+	Id                string `json:"id"`
+	Type              string `json:"type"`
+	ProviderReference string `json:"provider_reference"`
+	FailureReason     string `json:"failure_reason,omitempty"`
+}
+
+// PaymentGateway is a card payment provider. Authorize holds the amount on the card,
+// Capture takes it and Refund gives it back. Refund is idempotent on idempotencyKey, so a
+// refund retried after an unknown outcome is paid out once. Provider side changes that happen outside
+// our calls arrive as webhooks, checked with VerifyWebhook before they are trusted.
+type PaymentGateway interface {
+	Name() string
+	Authorize(ctx context.Context, request AuthorizeRequest) (*Authorization, error)
+	Capture(ctx context.Context, providerReference string, amount money.Money) error
+	Refund(ctx context.Context, providerReference string, idempotencyKey string, amount money.Money) error
+	VerifyWebhook(payload []byte, signature string) (*WebhookEvent, error)
+}
@@ -0,0 +1,64 @@
+package payment
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// WebhookSignatureHeader carries "t=<unix seconds>,v1=<hex hmac-sha256 of t.payload>".
+const WebhookSignatureHeader = "X-Payment-Signature"
+
+// webhookTolerance bounds how old a signed webhook may be, which limits replays.
+const webhookTolerance = 5 * time.Minute
+
+func SignWebhook(secret string, payload []byte, at time.Time) string {
+	timestamp := strconv.FormatInt(at.Unix(), 10)
+	return fmt.Sprintf("t=%s,v1=%s", timestamp, webhookMac(secret, timestamp, payload))
+}
+
+func verifyWebhookSignature(secret string, payload []byte, signature string, now time.Time) error {
+	if secret == "" {
+		return ErrInvalidWebhookSignature
+	}
+
+	var timestamp, mac string
+	for _, part := range strings.Split(signature, ",") {
+		key, value, _ := strings.Cut(strings.TrimSpace(part), "=")
+		switch key {
+		case "t":
+			timestamp = value
+		case "v1":
+			mac = value
+		}
+	}
+
+	unix, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil || mac == "" {
+		return ErrInvalidWebhookSignature
+	}
+
+	age := now.Sub(time.Unix(unix, 0))
+	if age > webhookTolerance || age < -webhookTolerance {
+		return ErrInvalidWebhookSignature
+	}
+
+	if !hmac.Equal([]byte(mac), []byte(webhookMac(secret, timestamp, payload))) {
+		return ErrInvalidWebhookSignature
+	}
+
+	return nil
+}
+
+func webhookMac(secret string, timestamp string, payload []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(payload)
+
+	return hex.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,47 @@
+package payment
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestVerifyWebhookSignature(t *testing.T) {
+	const secret = "whsec_test"
+	payload := []byte(`{"id":"evt_1","type":"payment.captured","provider_reference":"fake_4242_intent"}`)
+	signedAt := time.Unix(1_700_000_000, 0)
+
+	tests := []struct {
+		name      string
+		secret    string
+		payload   []byte
+		signature string
+		now       time.Time
+		wantError bool
+	}{
+		{name: "valid", secret: secret, payload: payload, signature: SignWebhook(secret, payload, signedAt), now: signedAt},
+		{name: "within tolerance", secret: secret, payload: payload, signature: SignWebhook(secret, payload, signedAt), now: signedAt.Add(webhookTolerance)},
+		{name: "tampered payload", secret: secret, payload: []byte(`{"id":"evt_1","type":"payment.refunded"}`), signature: SignWebhook(secret, payload, signedAt), now: signedAt, wantError: true},
+		{name: "stale timestamp", secret: secret, payload: payload, signature: SignWebhook(secret, payload, signedAt), now: signedAt.Add(webhookTolerance + time.Second), wantError: true},
+		{name: "timestamp from the future", secret: secret, payload: payload, signature: SignWebhook(secret, payload, signedAt.Add(webhookTolerance+time.Second)), now: signedAt, wantError: true},
+		{name: "wrong secret", secret: secret, payload: payload, signature: SignWebhook("whsec_other", payload, signedAt), now: signedAt, wantError: true},
+		{name: "empty secret", secret: "", payload: payload, signature: SignWebhook("", payload, signedAt), now: signedAt, wantError: true},
+		{name: "missing mac", secret: secret, payload: payload, signature: "t=1700000000", now: signedAt, wantError: true},
+		{name: "malformed timestamp", secret: secret, payload: payload, signature: "t=soon,v1=abc", now: signedAt, wantError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := verifyWebhookSignature(tt.secret, tt.payload, tt.signature, tt.now)
+			if tt.wantError {
+				if !errors.Is(err, ErrInvalidWebhookSignature) {
+					t.Fatalf("verifyWebhookSignature() error = %v, want ErrInvalidWebhookSignature", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("verifyWebhookSignature() returned error %v", err)
+			}
+		})
+	}
+}
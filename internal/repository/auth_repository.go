@@ -10,8 +10,15 @@ import (
 
 type IAuthRepository interface {
 	GetUserByEmail(ctx context.Context, email string) (*entity.User, error)
+	GetUserById(ctx context.Context, id string) (*entity.User, error)
 	InsertUser(ctx context.Context, user *entity.User) error
 	UpdateUserPassword(ctx context.Context, userId string, hashedNewPassword string, updatedBy string) error
+	MarkEmailVerified(ctx context.Context, userId string, verifiedAt time.Time) error
+	UpdateUserRole(ctx context.Context, userId string, roleCode string, updatedBy string) error
+	InsertPasswordResetToken(ctx context.Context, resetToken *entity.PasswordResetToken) error
+	GetPasswordResetTokenByHash(ctx context.Context, tokenHash string) (*entity.PasswordResetToken, error)
+	MarkPasswordResetTokenUsed(ctx context.Context, id string, usedAt time.Time) (bool, error)
+	InvalidatePasswordResetTokens(ctx context.Context, userId string, usedAt time.Time) error
 }
 
 type authRepository struct {
@@ -19,7 +26,7 @@ type authRepository struct {
 }
 
 func (ar *authRepository) GetUserByEmail(ctx context.Context, email string) (*entity.User, error) {
-	row := ar.db.QueryRowContext(ctx, "SELECT id, email, password, full_name, role_code, created_at FROM users WHERE email = $1", email)
+	row := executor(ctx, ar.db).QueryRowContext(ctx, "SELECT id, email, password, full_name, role_code, email_verified_at, created_at FROM users WHERE email = $1", email)
 	if row.Err() != nil {
 		return nil, row.Err()
 	}
@@ -32,6 +39,7 @@ func (ar *authRepository) GetUserByEmail(ctx context.Context, email string) (*en
 		&user.Password,
 		&user.FullName,
 		&user.RoleCode,
+		&user.EmailVerifiedAt,
 		&user.CreatedAt,
 	)
 	if err != nil {
@@ -45,14 +53,42 @@ func (ar *authRepository) GetUserByEmail(ctx context.Context, email string) (*en
 	return &user, nil
 }
 
+func (ar *authRepository) GetUserById(ctx context.Context, id string) (*entity.User, error) {
+	row := executor(ctx, ar.db).QueryRowContext(ctx, "SELECT id, email, password, full_name, role_code, email_verified_at, created_at FROM users WHERE id = $1 AND is_deleted = false", id)
+	if row.Err() != nil {
+		return nil, row.Err()
+	}
+
+	var user entity.User
+
+	err := row.Scan(
+		&user.Id,
+		&user.Email,
+		&user.Password,
+		&user.FullName,
+		&user.RoleCode,
+		&user.EmailVerifiedAt,
+		&user.CreatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return &user, nil
+}
+
 func (as *authRepository) InsertUser(ctx context.Context, user *entity.User) error {
-	_, err := as.db.ExecContext(ctx,
-		"INSERT INTO users (id, full_name, email, role_code, password, created_at, created_by, updated_at, updated_by, deleted_at, deleted_by, is_deleted) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)",
+	_, err := executor(ctx, as.db).ExecContext(ctx,
+		"INSERT INTO users (id, full_name, email, role_code, password, email_verified_at, created_at, created_by, updated_at, updated_by, deleted_at, deleted_by, is_deleted) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)",
 		user.Id,
 		user.FullName,
 		user.Email,
 		user.RoleCode,
 		user.Password,
+		user.EmailVerifiedAt,
 		user.CreatedAt,
 		user.CreatedBy,
 		user.UpdatedAt,
@@ -69,7 +105,7 @@ func (as *authRepository) InsertUser(ctx context.Context, user *entity.User) err
 }
 
 func (as *authRepository) UpdateUserPassword(ctx context.Context, userId string, hashedNewPassword string, updatedBy string) error {
-	_, err := as.db.ExecContext(ctx,
+	_, err := executor(ctx, as.db).ExecContext(ctx,
 		"UPDATE users SET password = $1, updated_at = $2, updated_by = $3 WHERE id = $4",
 		hashedNewPassword,
 		time.Now(),
@@ -83,6 +119,108 @@ func (as *authRepository) UpdateUserPassword(ctx context.Context, userId string,
 	return nil
 }
 
+func (as *authRepository) UpdateUserRole(ctx context.Context, userId string, roleCode string, updatedBy string) error {
+	_, err := executor(ctx, as.db).ExecContext(ctx,
+		"UPDATE users SET role_code = $1, updated_at = $2, updated_by = $3 WHERE id = $4",
+		roleCode,
+		time.Now(),
+		updatedBy,
+		userId,
+	)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (as *authRepository) MarkEmailVerified(ctx context.Context, userId string, verifiedAt time.Time) error {
+	_, err := executor(ctx, as.db).ExecContext(ctx,
+		"UPDATE users SET email_verified_at = $1 WHERE id = $2 AND email_verified_at IS NULL",
+		verifiedAt,
+		userId,
+	)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (as *authRepository) InsertPasswordResetToken(ctx context.Context, resetToken *entity.PasswordResetToken) error {
+	_, err := executor(ctx, as.db).ExecContext(ctx,
+		"INSERT INTO password_reset_tokens (id, user_id, token_hash, expires_at, created_at) VALUES ($1, $2, $3, $4, $5)",
+		resetToken.Id,
+		resetToken.UserId,
+		resetToken.TokenHash,
+		resetToken.ExpiresAt,
+		resetToken.CreatedAt,
+	)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (as *authRepository) GetPasswordResetTokenByHash(ctx context.Context, tokenHash string) (*entity.PasswordResetToken, error) {
+	row := executor(ctx, as.db).QueryRowContext(ctx, "SELECT id, user_id, token_hash, expires_at, created_at, used_at FROM password_reset_tokens WHERE token_hash = $1", tokenHash)
+	if row.Err() != nil {
+		return nil, row.Err()
+	}
+
+	var resetToken entity.PasswordResetToken
+
+	err := row.Scan(
+		&resetToken.Id,
+		&resetToken.UserId,
+		&resetToken.TokenHash,
+		&resetToken.ExpiresAt,
+		&resetToken.CreatedAt,
+		&resetToken.UsedAt,
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return &resetToken, nil
+}
+
+// MarkPasswordResetTokenUsed consumes the token only once, returning false if it was already used.
+func (as *authRepository) MarkPasswordResetTokenUsed(ctx context.Context, id string, usedAt time.Time) (bool, error) {
+	result, err := executor(ctx, as.db).ExecContext(ctx,
+		"UPDATE password_reset_tokens SET used_at = $1 WHERE id = $2 AND used_at IS NULL",
+		usedAt,
+		id,
+	)
+	if err != nil {
+		return false, err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	return affected == 1, nil
+}
+
+func (as *authRepository) InvalidatePasswordResetTokens(ctx context.Context, userId string, usedAt time.Time) error {
+	_, err := executor(ctx, as.db).ExecContext(ctx,
+		"UPDATE password_reset_tokens SET used_at = $1 WHERE user_id = $2 AND used_at IS NULL",
+		usedAt,
+		userId,
+	)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
 func NewAuthRepository(db *sql.DB) IAuthRepository {
 	return &authRepository{
 		db: db,
@@ -0,0 +1,213 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/aldngrha/ecommerce-be/internal/entity"
+	"github.com/google/uuid"
+)
+
+type ICartRepository interface {
+	GetOrCreateCart(ctx context.Context, userId string) (*entity.Cart, error)
+	GetCartItems(ctx context.Context, cartId string) ([]*entity.CartItem, error)
+	GetCartItem(ctx context.Context, cartId string, productId string, variantId *string) (*entity.CartItem, error)
+	UpsertCartItem(ctx context.Context, item *entity.CartItem) error
+	// AddCartItemQuantity inserts the item or adds its quantity to the line already in the
+	// cart. It returns false, leaving the cart unchanged, when the line would exceed maxQuantity.
+	AddCartItemQuantity(ctx context.Context, item *entity.CartItem, maxQuantity int32) (bool, error)
+	DeleteCartItem(ctx context.Context, cartId string, productId string, variantId *string) error
+	ClearCart(ctx context.Context, cartId string) error
+}
+
+// cartLineKey is the unique key of a cart line, a product and optionally one of its variants.
+const cartLineKey = "(cart_id, product_id, COALESCE(variant_id, '00000000-0000-0000-0000-000000000000'::uuid))"
+
+type cartRepository struct {
+	db *sql.DB
+}
+
+func (repo *cartRepository) GetOrCreateCart(ctx context.Context, userId string) (*entity.Cart, error) {
+	cart, err := repo.getCartByUserId(ctx, userId)
+	if err != nil || cart != nil {
+		return cart, err
+	}
+
+	// a concurrent request may create the cart first, then this insert does nothing
+	now := time.Now()
+	_, err = executor(ctx, repo.db).ExecContext(
+		ctx,
+		"INSERT INTO carts (id, user_id, created_at, updated_at) VALUES ($1, $2, $3, $4) ON CONFLICT (user_id) DO NOTHING",
+		uuid.NewString(),
+		userId,
+		now,
+		now,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	return repo.getCartByUserId(ctx, userId)
+}
+
+func (repo *cartRepository) getCartByUserId(ctx context.Context, userId string) (*entity.Cart, error) {
+	var cart entity.Cart
+	err := executor(ctx, repo.db).QueryRowContext(
+		ctx,
+		"SELECT id, user_id, created_at, updated_at FROM carts WHERE user_id = $1",
+		userId,
+	).Scan(&cart.Id, &cart.UserId, &cart.CreatedAt, &cart.UpdatedAt)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return &cart, nil
+}
+
+func (repo *cartRepository) GetCartItems(ctx context.Context, cartId string) ([]*entity.CartItem, error) {
+	rows, err := executor(ctx, repo.db).QueryContext(
+		ctx,
+		"SELECT id, cart_id, product_id, variant_id, quantity, created_at, updated_at FROM cart_items WHERE cart_id = $1 ORDER BY created_at, id",
+		cartId,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	items := make([]*entity.CartItem, 0)
+	for rows.Next() {
+		var item entity.CartItem
+		err = rows.Scan(&item.Id, &item.CartId, &item.ProductId, &item.VariantId, &item.Quantity, &item.CreatedAt, &item.UpdatedAt)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, &item)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return items, nil
+}
+
+func (repo *cartRepository) GetCartItem(ctx context.Context, cartId string, productId string, variantId *string) (*entity.CartItem, error) {
+	var item entity.CartItem
+	err := executor(ctx, repo.db).QueryRowContext(
+		ctx,
+		"SELECT id, cart_id, product_id, variant_id, quantity, created_at, updated_at FROM cart_items WHERE cart_id = $1 AND product_id = $2 AND variant_id IS NOT DISTINCT FROM $3",
+		cartId,
+		productId,
+		variantId,
+	).Scan(&item.Id, &item.CartId, &item.ProductId, &item.VariantId, &item.Quantity, &item.CreatedAt, &item.UpdatedAt)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return &item, nil
+}
+
+// UpsertCartItem inserts the item or, when the product or variant is already in the cart,
+// sets its quantity.
+func (repo *cartRepository) UpsertCartItem(ctx context.Context, item *entity.CartItem) error {
+	_, err := executor(ctx, repo.db).ExecContext(
+		ctx,
+		"INSERT INTO cart_items (id, cart_id, product_id, variant_id, quantity, created_at, updated_at) VALUES ($1, $2, $3, $4, $5, $6, $7) "+
+			"ON CONFLICT "+cartLineKey+" DO UPDATE SET quantity = EXCLUDED.quantity, updated_at = EXCLUDED.updated_at",
+		item.Id,
+		item.CartId,
+		item.ProductId,
+		item.VariantId,
+		item.Quantity,
+		item.CreatedAt,
+		item.UpdatedAt,
+	)
+	if err != nil {
+		return err
+	}
+
+	_, err = executor(ctx, repo.db).ExecContext(ctx, "UPDATE carts SET updated_at = $1 WHERE id = $2", item.UpdatedAt, item.CartId)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (repo *cartRepository) AddCartItemQuantity(ctx context.Context, item *entity.CartItem, maxQuantity int32) (bool, error) {
+	if item.Quantity > maxQuantity {
+		return false, nil
+	}
+
+	// the sum is computed and checked by the database, so concurrent adds cannot overshoot
+	result, err := executor(ctx, repo.db).ExecContext(
+		ctx,
+		"INSERT INTO cart_items (id, cart_id, product_id, variant_id, quantity, created_at, updated_at) VALUES ($1, $2, $3, $4, $5, $6, $7) "+
+			"ON CONFLICT "+cartLineKey+" DO UPDATE SET quantity = cart_items.quantity + EXCLUDED.quantity, updated_at = EXCLUDED.updated_at "+
+			"WHERE cart_items.quantity + EXCLUDED.quantity <= $8",
+		item.Id,
+		item.CartId,
+		item.ProductId,
+		item.VariantId,
+		item.Quantity,
+		item.CreatedAt,
+		item.UpdatedAt,
+		maxQuantity,
+	)
+	if err != nil {
+		return false, err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	if affected == 0 {
+		return false, nil
+	}
+
+	_, err = executor(ctx, repo.db).ExecContext(ctx, "UPDATE carts SET updated_at = $1 WHERE id = $2", item.UpdatedAt, item.CartId)
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+func (repo *cartRepository) DeleteCartItem(ctx context.Context, cartId string, productId string, variantId *string) error {
+	_, err := executor(ctx, repo.db).ExecContext(
+		ctx,
+		"DELETE FROM cart_items WHERE cart_id = $1 AND product_id = $2 AND variant_id IS NOT DISTINCT FROM $3",
+		cartId,
+		productId,
+		variantId,
+	)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (repo *cartRepository) ClearCart(ctx context.Context, cartId string) error {
+	_, err := executor(ctx, repo.db).ExecContext(ctx, "DELETE FROM cart_items WHERE cart_id = $1", cartId)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func NewCartRepository(db *sql.DB) ICartRepository {
+	return &cartRepository{
+		db: db,
+	}
+}
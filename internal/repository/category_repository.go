@@ -0,0 +1,221 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/aldngrha/ecommerce-be/internal/entity"
+	"github.com/lib/pq"
+)
+
+// categorySubtreeQuery selects the id of the category given by placeholder, e.g. "$1",
+// and of all its descendants.
+func categorySubtreeQuery(placeholder string) string {
+	return "WITH RECURSIVE subtree AS (" +
+		"SELECT id FROM categories WHERE id = " + placeholder + " " +
+		"UNION ALL SELECT categories.id FROM categories JOIN subtree ON categories.parent_id = subtree.id" +
+		") SELECT id FROM subtree"
+}
+
+type ICategoryRepository interface {
+	// LockCategoryTree serializes changes to the tree for the rest of the transaction, so
+	// two concurrent moves cannot create a cycle.
+	LockCategoryTree(ctx context.Context) error
+	CreateCategory(ctx context.Context, category *entity.Category) error
+	GetCategoryById(ctx context.Context, id string) (*entity.Category, error)
+	GetCategoryBySlug(ctx context.Context, slug string) (*entity.Category, error)
+	UpdateCategory(ctx context.Context, category *entity.Category) error
+	DeleteCategory(ctx context.Context, id string) error
+	ListCategories(ctx context.Context) ([]*entity.Category, error)
+	HasChildCategories(ctx context.Context, id string) (bool, error)
+	// IsInCategorySubtree reports whether id is rootId itself or one of its descendants.
+	IsInCategorySubtree(ctx context.Context, rootId string, id string) (bool, error)
+	CountCategoriesByIds(ctx context.Context, ids []string) (int, error)
+	SetProductCategories(ctx context.Context, productId string, categoryIds []string) error
+	ListCategoriesByProductId(ctx context.Context, productId string) ([]*entity.Category, error)
+}
+
+type categoryRepository struct {
+	db *sql.DB
+}
+
+func (repo *categoryRepository) LockCategoryTree(ctx context.Context) error {
+	_, err := executor(ctx, repo.db).ExecContext(ctx, "SELECT pg_advisory_xact_lock(hashtext('categories'))")
+
+	return err
+}
+
+func (repo *categoryRepository) CreateCategory(ctx context.Context, category *entity.Category) error {
+	_, err := executor(ctx, repo.db).ExecContext(
+		ctx,
+		"INSERT INTO categories (id, parent_id, name, slug, created_at, created_by) VALUES ($1, $2, $3, $4, $5, $6)",
+		category.Id,
+		category.ParentId,
+		category.Name,
+		category.Slug,
+		category.CreatedAt,
+		category.CreatedBy,
+	)
+
+	return err
+}
+
+func (repo *categoryRepository) GetCategoryById(ctx context.Context, id string) (*entity.Category, error) {
+	return repo.getCategory(ctx, "SELECT id, parent_id, name, slug, created_at, created_by, updated_at, updated_by FROM categories WHERE id = $1", id)
+}
+
+func (repo *categoryRepository) GetCategoryBySlug(ctx context.Context, slug string) (*entity.Category, error) {
+	return repo.getCategory(ctx, "SELECT id, parent_id, name, slug, created_at, created_by, updated_at, updated_by FROM categories WHERE slug = $1", slug)
+}
+
+func (repo *categoryRepository) getCategory(ctx context.Context, query string, arg any) (*entity.Category, error) {
+	var category entity.Category
+	err := executor(ctx, repo.db).QueryRowContext(ctx, query, arg).Scan(
+		&category.Id,
+		&category.ParentId,
+		&category.Name,
+		&category.Slug,
+		&category.CreatedAt,
+		&category.CreatedBy,
+		&category.UpdatedAt,
+		&category.UpdatedBy,
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return &category, nil
+}
+
+func (repo *categoryRepository) UpdateCategory(ctx context.Context, category *entity.Category) error {
+	_, err := executor(ctx, repo.db).ExecContext(
+		ctx,
+		"UPDATE categories SET parent_id = $1, name = $2, slug = $3, updated_at = $4, updated_by = $5 WHERE id = $6",
+		category.ParentId,
+		category.Name,
+		category.Slug,
+		category.UpdatedAt,
+		category.UpdatedBy,
+		category.Id,
+	)
+
+	return err
+}
+
+// DeleteCategory removes the category and its product assignments. Callers make sure it
+// has no children first.
+func (repo *categoryRepository) DeleteCategory(ctx context.Context, id string) error {
+	_, err := executor(ctx, repo.db).ExecContext(ctx, "DELETE FROM categories WHERE id = $1", id)
+
+	return err
+}
+
+func (repo *categoryRepository) ListCategories(ctx context.Context) ([]*entity.Category, error) {
+	return repo.listCategories(ctx, "SELECT id, parent_id, name, slug, created_at, created_by, updated_at, updated_by FROM categories ORDER BY name, id")
+}
+
+func (repo *categoryRepository) ListCategoriesByProductId(ctx context.Context, productId string) ([]*entity.Category, error) {
+	return repo.listCategories(
+		ctx,
+		"SELECT categories.id, categories.parent_id, categories.name, categories.slug, categories.created_at, categories.created_by, categories.updated_at, categories.updated_by "+
+			"FROM categories JOIN product_categories ON product_categories.category_id = categories.id "+
+			"WHERE product_categories.product_id = $1 ORDER BY categories.name, categories.id",
+		productId,
+	)
+}
+
+func (repo *categoryRepository) listCategories(ctx context.Context, query string, args ...any) ([]*entity.Category, error) {
+	rows, err := executor(ctx, repo.db).QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	categories := make([]*entity.Category, 0)
+	for rows.Next() {
+		var category entity.Category
+		err = rows.Scan(
+			&category.Id,
+			&category.ParentId,
+			&category.Name,
+			&category.Slug,
+			&category.CreatedAt,
+			&category.CreatedBy,
+			&category.UpdatedAt,
+			&category.UpdatedBy,
+		)
+		if err != nil {
+			return nil, err
+		}
+		categories = append(categories, &category)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return categories, nil
+}
+
+func (repo *categoryRepository) HasChildCategories(ctx context.Context, id string) (bool, error) {
+	var exists bool
+	err := executor(ctx, repo.db).QueryRowContext(ctx, "SELECT EXISTS (SELECT 1 FROM categories WHERE parent_id = $1)", id).Scan(&exists)
+
+	return exists, err
+}
+
+func (repo *categoryRepository) IsInCategorySubtree(ctx context.Context, rootId string, id string) (bool, error) {
+	var exists bool
+	err := executor(ctx, repo.db).QueryRowContext(
+		ctx,
+		"SELECT EXISTS (SELECT 1 FROM ("+categorySubtreeQuery("$1")+") tree WHERE tree.id = $2)",
+		rootId,
+		id,
+	).Scan(&exists)
+
+	return exists, err
+}
+
+func (repo *categoryRepository) CountCategoriesByIds(ctx context.Context, ids []string) (int, error) {
+	var count int
+	err := executor(ctx, repo.db).QueryRowContext(ctx, "SELECT count(*) FROM categories WHERE id = ANY($1)", pq.Array(ids)).Scan(&count)
+
+	return count, err
+}
+
+// SetProductCategories replaces the categories the product is assigned to.
+func (repo *categoryRepository) SetProductCategories(ctx context.Context, productId string, categoryIds []string) error {
+	tx, err := beginTx(ctx, repo.db)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	_, err = tx.ExecContext(ctx, "DELETE FROM product_categories WHERE product_id = $1", productId)
+	if err != nil {
+		return err
+	}
+
+	for _, categoryId := range categoryIds {
+		_, err = tx.ExecContext(
+			ctx,
+			"INSERT INTO product_categories (product_id, category_id) VALUES ($1, $2)",
+			productId,
+			categoryId,
+		)
+		if err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+func NewCategoryRepository(db *sql.DB) ICategoryRepository {
+	return &categoryRepository{
+		db: db,
+	}
+}
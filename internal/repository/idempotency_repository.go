@@ -0,0 +1,156 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/aldngrha/ecommerce-be/internal/entity"
+)
+
+type IIdempotencyRepository interface {
+	// TryInsertIdempotencyKey claims the key and returns false if it already exists.
+	TryInsertIdempotencyKey(ctx context.Context, key *entity.IdempotencyKey) (bool, error)
+	// TakeOverIdempotencyKey replaces the stored key with key if the stored one expired by
+	// key.CreatedAt, and returns false if it has not.
+	TakeOverIdempotencyKey(ctx context.Context, key *entity.IdempotencyKey) (bool, error)
+	GetIdempotencyKey(ctx context.Context, userId string, method string, key string) (*entity.IdempotencyKey, error)
+	// CompleteIdempotencyKey stores the response and the final expiry of a key that is still
+	// in progress for the same request.
+	CompleteIdempotencyKey(ctx context.Context, key *entity.IdempotencyKey) error
+	DeleteIdempotencyKey(ctx context.Context, userId string, method string, key string) error
+	DeleteExpiredIdempotencyKeys(ctx context.Context, now time.Time) (int64, error)
+}
+
+type idempotencyRepository struct {
+	db *sql.DB
+}
+
+func (repo *idempotencyRepository) TryInsertIdempotencyKey(ctx context.Context, key *entity.IdempotencyKey) (bool, error) {
+	result, err := executor(ctx, repo.db).ExecContext(
+		ctx,
+		"INSERT INTO idempotency_keys (user_id, method, idempotency_key, request_hash, status, created_at, expires_at) VALUES ($1, $2, $3, $4, $5, $6, $7) ON CONFLICT DO NOTHING",
+		key.UserId,
+		key.Method,
+		key.Key,
+		key.RequestHash,
+		key.Status,
+		key.CreatedAt,
+		key.ExpiresAt,
+	)
+	if err != nil {
+		return false, err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	return affected == 1, nil
+}
+
+func (repo *idempotencyRepository) TakeOverIdempotencyKey(ctx context.Context, key *entity.IdempotencyKey) (bool, error) {
+	result, err := executor(ctx, repo.db).ExecContext(
+		ctx,
+		"UPDATE idempotency_keys SET request_hash = $1, status = $2, response_type = NULL, response = NULL, created_at = $3, expires_at = $4 WHERE user_id = $5 AND method = $6 AND idempotency_key = $7 AND expires_at <= $3",
+		key.RequestHash,
+		key.Status,
+		key.CreatedAt,
+		key.ExpiresAt,
+		key.UserId,
+		key.Method,
+		key.Key,
+	)
+	if err != nil {
+		return false, err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	return affected == 1, nil
+}
+
+func (repo *idempotencyRepository) GetIdempotencyKey(ctx context.Context, userId string, method string, key string) (*entity.IdempotencyKey, error) {
+	var idempotencyKey entity.IdempotencyKey
+	err := executor(ctx, repo.db).QueryRowContext(
+		ctx,
+		"SELECT user_id, method, idempotency_key, request_hash, status, response_type, response, created_at, expires_at FROM idempotency_keys WHERE user_id = $1 AND method = $2 AND idempotency_key = $3",
+		userId,
+		method,
+		key,
+	).Scan(
+		&idempotencyKey.UserId,
+		&idempotencyKey.Method,
+		&idempotencyKey.Key,
+		&idempotencyKey.RequestHash,
+		&idempotencyKey.Status,
+		&idempotencyKey.ResponseType,
+		&idempotencyKey.Response,
+		&idempotencyKey.CreatedAt,
+		&idempotencyKey.ExpiresAt,
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return &idempotencyKey, nil
+}
+
+func (repo *idempotencyRepository) CompleteIdempotencyKey(ctx context.Context, key *entity.IdempotencyKey) error {
+	_, err := executor(ctx, repo.db).ExecContext(
+		ctx,
+		"UPDATE idempotency_keys SET status = $1, response_type = $2, response = $3, expires_at = $4 WHERE user_id = $5 AND method = $6 AND idempotency_key = $7 AND request_hash = $8 AND status = $9",
+		entity.IdempotencyStatusCompleted,
+		key.ResponseType,
+		key.Response,
+		key.ExpiresAt,
+		key.UserId,
+		key.Method,
+		key.Key,
+		key.RequestHash,
+		entity.IdempotencyStatusInProgress,
+	)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (repo *idempotencyRepository) DeleteIdempotencyKey(ctx context.Context, userId string, method string, key string) error {
+	_, err := executor(ctx, repo.db).ExecContext(
+		ctx,
+		"DELETE FROM idempotency_keys WHERE user_id = $1 AND method = $2 AND idempotency_key = $3",
+		userId,
+		method,
+		key,
+	)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (repo *idempotencyRepository) DeleteExpiredIdempotencyKeys(ctx context.Context, now time.Time) (int64, error) {
+	result, err := executor(ctx, repo.db).ExecContext(ctx, "DELETE FROM idempotency_keys WHERE expires_at < $1", now)
+	if err != nil {
+		return 0, err
+	}
+
+	return result.RowsAffected()
+}
+
+func NewIdempotencyRepository(db *sql.DB) IIdempotencyRepository {
+	return &idempotencyRepository{
+		db: db,
+	}
+}
@@ -0,0 +1,369 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/aldngrha/ecommerce-be/internal/entity"
+)
+
+// InsufficientStockError is returned by ReserveStock when a product or variant cannot
+// cover the requested quantity. Nothing is reserved in that case.
+type InsufficientStockError struct {
+	ProductId string
+	VariantId *string
+}
+
+func (e *InsufficientStockError) Error() string {
+	if e.VariantId != nil {
+		return fmt.Sprintf("insufficient stock for variant %s of product %s", *e.VariantId, e.ProductId)
+	}
+
+	return fmt.Sprintf("insufficient stock for product %s", e.ProductId)
+}
+
+// IInventoryRepository keeps the stock of products. Products sold in variants keep it per
+// variant, every method takes a nil variantId for a product without variants.
+type IInventoryRepository interface {
+	GetInventory(ctx context.Context, productId string, variantId *string) (*entity.Inventory, error)
+	// ListVariantInventory returns the stock of every live variant of the product that has
+	// a stock row, keyed by variant id.
+	ListVariantInventory(ctx context.Context, productId string) (map[string]*entity.Inventory, error)
+	SetStock(ctx context.Context, productId string, variantId *string, onHand int32, updatedBy string) (bool, error)
+	AdjustStock(ctx context.Context, productId string, variantId *string, delta int32, updatedBy string) (bool, error)
+	ReserveStock(ctx context.Context, reservations []*entity.StockReservation) error
+	CommitReservations(ctx context.Context, orderId string) error
+	ReleaseReservations(ctx context.Context, orderId string) error
+	RestockReservations(ctx context.Context, orderId string) error
+	ListExpiredReservationOrderIds(ctx context.Context, now time.Time) ([]string, error)
+}
+
+type inventoryRepository struct {
+	db *sql.DB
+}
+
+// stockRow returns the table holding the stock of the product or variant, the column
+// identifying its row and the value of that column.
+func stockRow(productId string, variantId *string) (string, string, string) {
+	if variantId != nil {
+		return "variant_inventory", "variant_id", *variantId
+	}
+
+	return "inventory", "product_id", productId
+}
+
+func (repo *inventoryRepository) GetInventory(ctx context.Context, productId string, variantId *string) (*entity.Inventory, error) {
+	table, keyColumn, key := stockRow(productId, variantId)
+
+	inventory := entity.Inventory{
+		ProductId: productId,
+		VariantId: variantId,
+	}
+	err := executor(ctx, repo.db).QueryRowContext(
+		ctx,
+		fmt.Sprintf("SELECT on_hand, reserved, updated_at, updated_by FROM %s WHERE %s = $1", table, keyColumn),
+		key,
+	).Scan(&inventory.OnHand, &inventory.Reserved, &inventory.UpdatedAt, &inventory.UpdatedBy)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return &inventory, nil
+}
+
+func (repo *inventoryRepository) ListVariantInventory(ctx context.Context, productId string) (map[string]*entity.Inventory, error) {
+	rows, err := executor(ctx, repo.db).QueryContext(
+		ctx,
+		"SELECT variant_inventory.variant_id, variant_inventory.on_hand, variant_inventory.reserved, variant_inventory.updated_at, variant_inventory.updated_by "+
+			"FROM variant_inventory JOIN product_variants ON product_variants.id = variant_inventory.variant_id "+
+			"WHERE product_variants.product_id = $1 AND product_variants.is_deleted = false",
+		productId,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	inventories := make(map[string]*entity.Inventory)
+	for rows.Next() {
+		var variantId string
+		inventory := entity.Inventory{
+			ProductId: productId,
+		}
+		err = rows.Scan(&variantId, &inventory.OnHand, &inventory.Reserved, &inventory.UpdatedAt, &inventory.UpdatedBy)
+		if err != nil {
+			return nil, err
+		}
+		inventory.VariantId = &variantId
+		inventories[variantId] = &inventory
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return inventories, nil
+}
+
+// SetStock sets the on-hand quantity. It returns false when that would drop below what
+// is currently reserved.
+func (repo *inventoryRepository) SetStock(ctx context.Context, productId string, variantId *string, onHand int32, updatedBy string) (bool, error) {
+	table, keyColumn, key := stockRow(productId, variantId)
+
+	result, err := executor(ctx, repo.db).ExecContext(
+		ctx,
+		fmt.Sprintf(
+			"INSERT INTO %[1]s (%[2]s, on_hand, reserved, updated_at, updated_by) VALUES ($1, $2, 0, $3, $4) "+
+				"ON CONFLICT (%[2]s) DO UPDATE SET on_hand = EXCLUDED.on_hand, updated_at = EXCLUDED.updated_at, updated_by = EXCLUDED.updated_by "+
+				"WHERE %[1]s.reserved <= EXCLUDED.on_hand",
+			table,
+			keyColumn,
+		),
+		key,
+		onHand,
+		time.Now(),
+		updatedBy,
+	)
+	if err != nil {
+		return false, err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	return affected == 1, nil
+}
+
+// AdjustStock adds delta (possibly negative) to the on-hand quantity. It returns false
+// when the result would drop below what is currently reserved.
+func (repo *inventoryRepository) AdjustStock(ctx context.Context, productId string, variantId *string, delta int32, updatedBy string) (bool, error) {
+	table, keyColumn, key := stockRow(productId, variantId)
+
+	result, err := executor(ctx, repo.db).ExecContext(
+		ctx,
+		fmt.Sprintf("UPDATE %s SET on_hand = on_hand + $1, updated_at = $2, updated_by = $3 WHERE %s = $4 AND on_hand + $1 >= reserved", table, keyColumn),
+		delta,
+		time.Now(),
+		updatedBy,
+		key,
+	)
+	if err != nil {
+		return false, err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	if affected == 0 && delta > 0 {
+		// first stock for a product or variant without an inventory row yet
+		return repo.SetStock(ctx, productId, variantId, delta, updatedBy)
+	}
+
+	return affected == 1, nil
+}
+
+// ReserveStock reserves every line or none. Each line is a conditional update, so the
+// row lock taken by a concurrent buyer makes the second one re-check availability
+// instead of overselling. Lines are locked in product and variant id order to avoid
+// deadlocks.
+func (repo *inventoryRepository) ReserveStock(ctx context.Context, reservations []*entity.StockReservation) error {
+	sorted := append([]*entity.StockReservation(nil), reservations...)
+	sort.Slice(sorted, func(i, j int) bool {
+		if sorted[i].ProductId != sorted[j].ProductId {
+			return sorted[i].ProductId < sorted[j].ProductId
+		}
+		return variantSortKey(sorted[i].VariantId) < variantSortKey(sorted[j].VariantId)
+	})
+
+	tx, err := beginTx(ctx, repo.db)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	for _, reservation := range sorted {
+		table, keyColumn, key := stockRow(reservation.ProductId, reservation.VariantId)
+		result, err := tx.ExecContext(
+			ctx,
+			fmt.Sprintf("UPDATE %s SET reserved = reserved + $1, updated_at = $2 WHERE %s = $3 AND on_hand - reserved >= $1", table, keyColumn),
+			reservation.Quantity,
+			reservation.CreatedAt,
+			key,
+		)
+		if err != nil {
+			return err
+		}
+
+		affected, err := result.RowsAffected()
+		if err != nil {
+			return err
+		}
+		if affected == 0 {
+			return &InsufficientStockError{ProductId: reservation.ProductId, VariantId: reservation.VariantId}
+		}
+
+		_, err = tx.ExecContext(
+			ctx,
+			"INSERT INTO stock_reservations (id, order_id, product_id, variant_id, quantity, status, expires_at, created_at) VALUES ($1, $2, $3, $4, $5, $6, $7, $8)",
+			reservation.Id,
+			reservation.OrderId,
+			reservation.ProductId,
+			reservation.VariantId,
+			reservation.Quantity,
+			entity.ReservationStatusActive,
+			reservation.ExpiresAt,
+			reservation.CreatedAt,
+		)
+		if err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+func variantSortKey(variantId *string) string {
+	if variantId == nil {
+		return ""
+	}
+
+	return *variantId
+}
+
+// CommitReservations turns the order's active reservations into sold units.
+func (repo *inventoryRepository) CommitReservations(ctx context.Context, orderId string) error {
+	return repo.resolveReservations(
+		ctx,
+		orderId,
+		entity.ReservationStatusActive,
+		entity.ReservationStatusCommitted,
+		"on_hand = on_hand - $1, reserved = reserved - $1",
+	)
+}
+
+// ReleaseReservations gives the order's active reservations back to available stock.
+func (repo *inventoryRepository) ReleaseReservations(ctx context.Context, orderId string) error {
+	return repo.resolveReservations(
+		ctx,
+		orderId,
+		entity.ReservationStatusActive,
+		entity.ReservationStatusReleased,
+		"reserved = reserved - $1",
+	)
+}
+
+// RestockReservations puts the units sold by the order's committed reservations back on hand.
+func (repo *inventoryRepository) RestockReservations(ctx context.Context, orderId string) error {
+	return repo.resolveReservations(
+		ctx,
+		orderId,
+		entity.ReservationStatusCommitted,
+		entity.ReservationStatusRestocked,
+		"on_hand = on_hand + $1",
+	)
+}
+
+// resolveReservations applies stockChange, a SET clause using $1 for the quantity, to the
+// stock of every reservation of the order in fromStatus and marks them with status.
+func (repo *inventoryRepository) resolveReservations(ctx context.Context, orderId string, fromStatus string, status string, stockChange string) error {
+	tx, err := beginTx(ctx, repo.db)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	rows, err := tx.QueryContext(
+		ctx,
+		"SELECT id, product_id, variant_id, quantity FROM stock_reservations WHERE order_id = $1 AND status = $2 ORDER BY product_id, variant_id NULLS FIRST FOR UPDATE",
+		orderId,
+		fromStatus,
+	)
+	if err != nil {
+		return err
+	}
+
+	reservations := make([]*entity.StockReservation, 0)
+	for rows.Next() {
+		var reservation entity.StockReservation
+		if err = rows.Scan(&reservation.Id, &reservation.ProductId, &reservation.VariantId, &reservation.Quantity); err != nil {
+			rows.Close()
+			return err
+		}
+		reservations = append(reservations, &reservation)
+	}
+	rows.Close()
+	if err = rows.Err(); err != nil {
+		return err
+	}
+
+	now := time.Now()
+	for _, reservation := range reservations {
+		table, keyColumn, key := stockRow(reservation.ProductId, reservation.VariantId)
+		_, err = tx.ExecContext(
+			ctx,
+			fmt.Sprintf("UPDATE %s SET %s, updated_at = $2 WHERE %s = $3", table, stockChange, keyColumn),
+			reservation.Quantity,
+			now,
+			key,
+		)
+		if err != nil {
+			return err
+		}
+
+		_, err = tx.ExecContext(
+			ctx,
+			"UPDATE stock_reservations SET status = $1, resolved_at = $2 WHERE id = $3",
+			status,
+			now,
+			reservation.Id,
+		)
+		if err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+func (repo *inventoryRepository) ListExpiredReservationOrderIds(ctx context.Context, now time.Time) ([]string, error) {
+	rows, err := executor(ctx, repo.db).QueryContext(
+		ctx,
+		"SELECT DISTINCT order_id FROM stock_reservations WHERE status = $1 AND expires_at < $2",
+		entity.ReservationStatusActive,
+		now,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	orderIds := make([]string, 0)
+	for rows.Next() {
+		var orderId string
+		if err = rows.Scan(&orderId); err != nil {
+			return nil, err
+		}
+		orderIds = append(orderIds, orderId)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return orderIds, nil
+}
+
+func NewInventoryRepository(db *sql.DB) IInventoryRepository {
+	return &inventoryRepository{
+		db: db,
+	}
+}
@@ -0,0 +1,206 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/aldngrha/ecommerce-be/internal/entity"
+	"github.com/aldngrha/ecommerce-be/internal/money"
+)
+
+type IOrderRepository interface {
+	InsertOrder(ctx context.Context, order *entity.Order) error
+	GetOrderById(ctx context.Context, id string) (*entity.Order, error)
+	ListOrdersByUser(ctx context.Context, userId string, afterCreatedAt *time.Time, afterId string, limit int) ([]*entity.Order, error)
+	UpdateOrderStatus(ctx context.Context, order *entity.Order, fromStatus entity.OrderStatus) (bool, error)
+}
+
+type orderRepository struct {
+	db *sql.DB
+}
+
+// InsertOrder stores the order together with its items.
+func (repo *orderRepository) InsertOrder(ctx context.Context, order *entity.Order) error {
+	tx, err := beginTx(ctx, repo.db)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	_, err = tx.ExecContext(
+		ctx,
+		"INSERT INTO orders (id, user_id, status, total_minor, currency, created_at, created_by) VALUES ($1, $2, $3, $4, $5, $6, $7)",
+		order.Id,
+		order.UserId,
+		order.Status,
+		order.Total.Amount,
+		order.Total.Currency,
+		order.CreatedAt,
+		order.CreatedBy,
+	)
+	if err != nil {
+		return err
+	}
+
+	for _, item := range order.Items {
+		_, err = tx.ExecContext(
+			ctx,
+			"INSERT INTO order_items (id, order_id, product_id, product_name, variant_id, sku, variant_name, unit_price_minor, quantity, subtotal_minor) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)",
+			item.Id,
+			order.Id,
+			item.ProductId,
+			item.ProductName,
+			item.VariantId,
+			item.Sku,
+			item.VariantName,
+			item.UnitPrice.Amount,
+			item.Quantity,
+			item.Subtotal.Amount,
+		)
+		if err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+func (repo *orderRepository) GetOrderById(ctx context.Context, id string) (*entity.Order, error) {
+	var order entity.Order
+	var updatedAt sql.NullTime
+	err := executor(ctx, repo.db).QueryRowContext(
+		ctx,
+		"SELECT id, user_id, status, total_minor, currency, cancel_reason, created_at, created_by, updated_at, updated_by FROM orders WHERE id = $1",
+		id,
+	).Scan(
+		&order.Id,
+		&order.UserId,
+		&order.Status,
+		&order.Total.Amount,
+		&order.Total.Currency,
+		&order.CancelReason,
+		&order.CreatedAt,
+		&order.CreatedBy,
+		&updatedAt,
+		&order.UpdatedBy,
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	order.UpdatedAt = updatedAt.Time
+
+	order.Items, err = repo.getOrderItems(ctx, order.Id, order.Total.Currency)
+	if err != nil {
+		return nil, err
+	}
+
+	return &order, nil
+}
+
+// getOrderItems returns the items of the order, whose prices are in the order's currency.
+func (repo *orderRepository) getOrderItems(ctx context.Context, orderId string, currency string) ([]*entity.OrderItem, error) {
+	rows, err := executor(ctx, repo.db).QueryContext(
+		ctx,
+		"SELECT id, order_id, product_id, product_name, variant_id, sku, variant_name, unit_price_minor, quantity, subtotal_minor FROM order_items WHERE order_id = $1 ORDER BY product_name, variant_name NULLS FIRST, id",
+		orderId,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	items := make([]*entity.OrderItem, 0)
+	for rows.Next() {
+		item := entity.OrderItem{
+			UnitPrice: money.Zero(currency),
+			Subtotal:  money.Zero(currency),
+		}
+		err = rows.Scan(&item.Id, &item.OrderId, &item.ProductId, &item.ProductName, &item.VariantId, &item.Sku, &item.VariantName, &item.UnitPrice.Amount, &item.Quantity, &item.Subtotal.Amount)
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, &item)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return items, nil
+}
+
+// ListOrdersByUser returns the user's orders newest first, starting after the given keyset position when set.
+func (repo *orderRepository) ListOrdersByUser(ctx context.Context, userId string, afterCreatedAt *time.Time, afterId string, limit int) ([]*entity.Order, error) {
+	query := "SELECT id FROM orders WHERE user_id = $1 ORDER BY created_at DESC, id DESC LIMIT $2"
+	args := []any{userId, limit}
+	if afterCreatedAt != nil {
+		query = "SELECT id FROM orders WHERE user_id = $1 AND (created_at, id) < ($3, $4) ORDER BY created_at DESC, id DESC LIMIT $2"
+		args = append(args, *afterCreatedAt, afterId)
+	}
+
+	rows, err := executor(ctx, repo.db).QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	ids := make([]string, 0)
+	for rows.Next() {
+		var id string
+		if err = rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	orders := make([]*entity.Order, 0, len(ids))
+	for _, id := range ids {
+		order, err := repo.GetOrderById(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		if order != nil {
+			orders = append(orders, order)
+		}
+	}
+
+	return orders, nil
+}
+
+// UpdateOrderStatus persists order.Status only if the row is still in fromStatus, so two
+// concurrent transitions cannot both win. It returns false when the status had changed.
+func (repo *orderRepository) UpdateOrderStatus(ctx context.Context, order *entity.Order, fromStatus entity.OrderStatus) (bool, error) {
+	result, err := executor(ctx, repo.db).ExecContext(
+		ctx,
+		"UPDATE orders SET status = $1, cancel_reason = $2, updated_at = $3, updated_by = $4 WHERE id = $5 AND status = $6",
+		order.Status,
+		order.CancelReason,
+		order.UpdatedAt,
+		order.UpdatedBy,
+		order.Id,
+		fromStatus,
+	)
+	if err != nil {
+		return false, err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	return affected == 1, nil
+}
+
+func NewOrderRepository(db *sql.DB) IOrderRepository {
+	return &orderRepository{
+		db: db,
+	}
+}
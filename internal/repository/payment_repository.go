@@ -0,0 +1,155 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/aldngrha/ecommerce-be/internal/entity"
+)
+
+type IPaymentRepository interface {
+	// InsertPaymentIntent returns false if the order already has a pending, authorized, captured or refund_pending intent.
+	InsertPaymentIntent(ctx context.Context, intent *entity.PaymentIntent) (bool, error)
+	GetPaymentIntentByProviderReference(ctx context.Context, provider string, providerReference string) (*entity.PaymentIntent, error)
+	// GetPaidPaymentIntentByOrderId returns the intent that paid the order, whether it is
+	// still captured or its refund is pending or done.
+	GetPaidPaymentIntentByOrderId(ctx context.Context, orderId string) (*entity.PaymentIntent, error)
+	UpdatePaymentIntent(ctx context.Context, intent *entity.PaymentIntent, fromStatus entity.PaymentIntentStatus) (bool, error)
+	// RecordWebhookEvent returns false if the provider's event was recorded before, so a
+	// redelivered webhook is applied once.
+	RecordWebhookEvent(ctx context.Context, provider string, eventId string, eventType string, receivedAt time.Time) (bool, error)
+}
+
+type paymentRepository struct {
+	db *sql.DB
+}
+
+const paymentIntentColumns = "id, order_id, amount_minor, currency, status, provider, provider_reference, failure_reason, created_at, created_by, updated_at, updated_by"
+
+func (repo *paymentRepository) InsertPaymentIntent(ctx context.Context, intent *entity.PaymentIntent) (bool, error) {
+	result, err := executor(ctx, repo.db).ExecContext(
+		ctx,
+		"INSERT INTO payment_intents (id, order_id, amount_minor, currency, status, provider, created_at, created_by) VALUES ($1, $2, $3, $4, $5, $6, $7, $8) ON CONFLICT DO NOTHING",
+		intent.Id,
+		intent.OrderId,
+		intent.Amount.Amount,
+		intent.Amount.Currency,
+		intent.Status,
+		intent.Provider,
+		intent.CreatedAt,
+		intent.CreatedBy,
+	)
+	if err != nil {
+		return false, err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	return affected == 1, nil
+}
+
+func (repo *paymentRepository) GetPaymentIntentByProviderReference(ctx context.Context, provider string, providerReference string) (*entity.PaymentIntent, error) {
+	return repo.getPaymentIntent(
+		ctx,
+		"SELECT "+paymentIntentColumns+" FROM payment_intents WHERE provider = $1 AND provider_reference = $2",
+		provider,
+		providerReference,
+	)
+}
+
+func (repo *paymentRepository) GetPaidPaymentIntentByOrderId(ctx context.Context, orderId string) (*entity.PaymentIntent, error) {
+	return repo.getPaymentIntent(
+		ctx,
+		"SELECT "+paymentIntentColumns+" FROM payment_intents WHERE order_id = $1 AND status IN ($2, $3, $4) ORDER BY created_at DESC LIMIT 1",
+		orderId,
+		entity.PaymentIntentStatusCaptured,
+		entity.PaymentIntentStatusRefundPending,
+		entity.PaymentIntentStatusRefunded,
+	)
+}
+
+func (repo *paymentRepository) getPaymentIntent(ctx context.Context, query string, args ...any) (*entity.PaymentIntent, error) {
+	var intent entity.PaymentIntent
+	var updatedAt sql.NullTime
+	err := executor(ctx, repo.db).QueryRowContext(ctx, query, args...).Scan(
+		&intent.Id,
+		&intent.OrderId,
+		&intent.Amount.Amount,
+		&intent.Amount.Currency,
+		&intent.Status,
+		&intent.Provider,
+		&intent.ProviderReference,
+		&intent.FailureReason,
+		&intent.CreatedAt,
+		&intent.CreatedBy,
+		&updatedAt,
+		&intent.UpdatedBy,
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	intent.UpdatedAt = updatedAt.Time
+
+	return &intent, nil
+}
+
+// UpdatePaymentIntent persists the intent only if it is still in fromStatus, so a webhook
+// and a synchronous call reporting the same outcome apply it once.
+func (repo *paymentRepository) UpdatePaymentIntent(ctx context.Context, intent *entity.PaymentIntent, fromStatus entity.PaymentIntentStatus) (bool, error) {
+	result, err := executor(ctx, repo.db).ExecContext(
+		ctx,
+		"UPDATE payment_intents SET status = $1, provider_reference = $2, failure_reason = $3, updated_at = $4, updated_by = $5 WHERE id = $6 AND status = $7",
+		intent.Status,
+		intent.ProviderReference,
+		intent.FailureReason,
+		intent.UpdatedAt,
+		intent.UpdatedBy,
+		intent.Id,
+		fromStatus,
+	)
+	if err != nil {
+		return false, err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	return affected == 1, nil
+}
+
+func (repo *paymentRepository) RecordWebhookEvent(ctx context.Context, provider string, eventId string, eventType string, receivedAt time.Time) (bool, error) {
+	result, err := executor(ctx, repo.db).ExecContext(
+		ctx,
+		"INSERT INTO payment_webhook_events (provider, event_id, type, received_at) VALUES ($1, $2, $3, $4) ON CONFLICT DO NOTHING",
+		provider,
+		eventId,
+		eventType,
+		receivedAt,
+	)
+	if err != nil {
+		return false, err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	return affected == 1, nil
+}
+
+func NewPaymentRepository(db *sql.DB) IPaymentRepository {
+	return &paymentRepository{
+		db: db,
+	}
+}
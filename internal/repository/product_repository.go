@@ -4,6 +4,8 @@ import (
 	"context"
 	"database/sql"
 	"errors"
+	"fmt"
+	"strings"
 
 	"github.com/aldngrha/ecommerce-be/internal/entity"
 )
@@ -12,6 +14,36 @@ type IProductRepository interface {
 	CreateNewProduct(ctx context.Context, product *entity.Product) error
 	GetProductById(ctx context.Context, id string) (*entity.Product, error)
 	UpdateProduct(ctx context.Context, product *entity.Product) error
+	ListProducts(ctx context.Context, params ListProductsParams) ([]*entity.Product, error)
+	GetDeletedProductById(ctx context.Context, id string) (*entity.Product, error)
+	DeleteProduct(ctx context.Context, product *entity.Product) error
+	RestoreProduct(ctx context.Context, product *entity.Product) error
+}
+
+const (
+	ProductSortByCreatedAt = "created_at"
+	ProductSortByPrice     = "price"
+	ProductSortByName      = "name"
+)
+
+var likeEscaper = strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`)
+
+// ListProductsParams describes a single keyset page. When AfterId is set, only rows
+// strictly after (AfterValue, AfterId) in the requested order are returned.
+// Deleted switches the listing from live products to soft-deleted ones. CategoryId keeps
+// products assigned to that category or any of its descendants. MinPrice and MaxPrice are
+// in minor units.
+type ListProductsParams struct {
+	Deleted    bool
+	Name       string
+	CategoryId string
+	MinPrice   *int64
+	MaxPrice   *int64
+	SortBy     string
+	SortDesc   bool
+	Limit      int
+	AfterValue any
+	AfterId    string
 }
 
 type productRepository struct {
@@ -19,12 +51,13 @@ type productRepository struct {
 }
 
 func (repo *productRepository) CreateNewProduct(ctx context.Context, product *entity.Product) error {
-	_, err := repo.db.ExecContext(
-		ctx, "INSERT INTO products (id, name, description, price, image_file_name, created_at, created_by, updated_at, updated_by, deleted_at, deleted_by, is_deleted) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)",
+	_, err := executor(ctx, repo.db).ExecContext(
+		ctx, "INSERT INTO products (id, name, description, price_minor, currency, image_file_name, created_at, created_by, updated_at, updated_by, deleted_at, deleted_by, is_deleted) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)",
 		product.Id,
 		product.Name,
 		product.Description,
-		product.Price,
+		product.Price.Amount,
+		product.Price.Currency,
 		product.ImageFileName,
 		product.CreatedAt,
 		product.CreatedBy,
@@ -43,16 +76,16 @@ func (repo *productRepository) CreateNewProduct(ctx context.Context, product *en
 
 func (repo *productRepository) GetProductById(ctx context.Context, id string) (*entity.Product, error) {
 	var productEntity entity.Product
-	row := repo.db.QueryRowContext(
+	row := executor(ctx, repo.db).QueryRowContext(
 		ctx,
-		"SELECT id, name, description, price, image_file_name FROM products WHERE id = $1 AND is_deleted = false",
+		"SELECT id, name, description, price_minor, currency, image_file_name FROM products WHERE id = $1 AND is_deleted = false",
 		id)
 
 	if row.Err() != nil {
 		return nil, row.Err()
 	}
 
-	err := row.Scan(&productEntity.Id, &productEntity.Name, &productEntity.Description, &productEntity.Price, &productEntity.ImageFileName)
+	err := row.Scan(&productEntity.Id, &productEntity.Name, &productEntity.Description, &productEntity.Price.Amount, &productEntity.Price.Currency, &productEntity.ImageFileName)
 
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
@@ -65,11 +98,12 @@ func (repo *productRepository) GetProductById(ctx context.Context, id string) (*
 }
 
 func (repo *productRepository) UpdateProduct(ctx context.Context, product *entity.Product) error {
-	_, err := repo.db.ExecContext(
-		ctx, "UPDATE products SET name=$1, description=$2, price=$3, image_file_name=$4, updated_at=$5, updated_by=$6 WHERE id = $7",
+	_, err := executor(ctx, repo.db).ExecContext(
+		ctx, "UPDATE products SET name=$1, description=$2, price_minor=$3, currency=$4, image_file_name=$5, updated_at=$6, updated_by=$7 WHERE id = $8",
 		product.Name,
 		product.Description,
-		product.Price,
+		product.Price.Amount,
+		product.Price.Currency,
 		product.ImageFileName,
 		product.UpdatedAt,
 		product.UpdatedBy,
@@ -82,6 +116,155 @@ func (repo *productRepository) UpdateProduct(ctx context.Context, product *entit
 	return nil
 }
 
+func (repo *productRepository) ListProducts(ctx context.Context, params ListProductsParams) ([]*entity.Product, error) {
+	sortColumn, ok := map[string]string{
+		ProductSortByCreatedAt: "created_at",
+		ProductSortByPrice:     "price_minor",
+		ProductSortByName:      "name",
+	}[params.SortBy]
+	if !ok {
+		return nil, fmt.Errorf("unsupported product sort field %q", params.SortBy)
+	}
+
+	args := []any{params.Deleted}
+	conditions := []string{"is_deleted = $1"}
+
+	if params.Name != "" {
+		args = append(args, "%"+likeEscaper.Replace(params.Name)+"%")
+		conditions = append(conditions, fmt.Sprintf("name ILIKE $%d", len(args)))
+	}
+	if params.CategoryId != "" {
+		args = append(args, params.CategoryId)
+		conditions = append(conditions, fmt.Sprintf(
+			"EXISTS (SELECT 1 FROM product_categories WHERE product_categories.product_id = products.id AND product_categories.category_id IN (%s))",
+			categorySubtreeQuery(fmt.Sprintf("$%d", len(args))),
+		))
+	}
+	if params.MinPrice != nil {
+		args = append(args, *params.MinPrice)
+		conditions = append(conditions, fmt.Sprintf("price_minor >= $%d", len(args)))
+	}
+	if params.MaxPrice != nil {
+		args = append(args, *params.MaxPrice)
+		conditions = append(conditions, fmt.Sprintf("price_minor <= $%d", len(args)))
+	}
+
+	direction, comparator := "ASC", ">"
+	if params.SortDesc {
+		direction, comparator = "DESC", "<"
+	}
+
+	if params.AfterId != "" {
+		args = append(args, params.AfterValue, params.AfterId)
+		conditions = append(conditions, fmt.Sprintf("(%s, id) %s ($%d, $%d)", sortColumn, comparator, len(args)-1, len(args)))
+	}
+
+	args = append(args, params.Limit)
+	query := fmt.Sprintf(
+		"SELECT id, name, description, price_minor, currency, image_file_name, created_at, deleted_at, deleted_by FROM products WHERE %s ORDER BY %s %s, id %s LIMIT $%d",
+		strings.Join(conditions, " AND "),
+		sortColumn,
+		direction,
+		direction,
+		len(args),
+	)
+
+	rows, err := executor(ctx, repo.db).QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	products := make([]*entity.Product, 0)
+	for rows.Next() {
+		var productEntity entity.Product
+		err = rows.Scan(
+			&productEntity.Id,
+			&productEntity.Name,
+			&productEntity.Description,
+			&productEntity.Price.Amount,
+			&productEntity.Price.Currency,
+			&productEntity.ImageFileName,
+			&productEntity.CreatedAt,
+			&productEntity.DeletedAt,
+			&productEntity.DeletedBy,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		products = append(products, &productEntity)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return products, nil
+}
+
+func (repo *productRepository) GetDeletedProductById(ctx context.Context, id string) (*entity.Product, error) {
+	var productEntity entity.Product
+	row := executor(ctx, repo.db).QueryRowContext(
+		ctx,
+		"SELECT id, name, description, price_minor, currency, image_file_name, deleted_at, deleted_by FROM products WHERE id = $1 AND is_deleted = true",
+		id)
+
+	if row.Err() != nil {
+		return nil, row.Err()
+	}
+
+	err := row.Scan(
+		&productEntity.Id,
+		&productEntity.Name,
+		&productEntity.Description,
+		&productEntity.Price.Amount,
+		&productEntity.Price.Currency,
+		&productEntity.ImageFileName,
+		&productEntity.DeletedAt,
+		&productEntity.DeletedBy,
+	)
+
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	productEntity.IsDeleted = true
+
+	return &productEntity, nil
+}
+
+func (repo *productRepository) DeleteProduct(ctx context.Context, product *entity.Product) error {
+	_, err := executor(ctx, repo.db).ExecContext(
+		ctx, "UPDATE products SET is_deleted = true, deleted_at = $1, deleted_by = $2 WHERE id = $3 AND is_deleted = false",
+		product.DeletedAt,
+		product.DeletedBy,
+		product.Id,
+	)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (repo *productRepository) RestoreProduct(ctx context.Context, product *entity.Product) error {
+	_, err := executor(ctx, repo.db).ExecContext(
+		ctx, "UPDATE products SET is_deleted = false, deleted_at = NULL, deleted_by = NULL, updated_at = $1, updated_by = $2 WHERE id = $3 AND is_deleted = true",
+		product.UpdatedAt,
+		product.UpdatedBy,
+		product.Id,
+	)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
 func NewProductRepository(db *sql.DB) IProductRepository {
 	return &productRepository{
 		db: db,
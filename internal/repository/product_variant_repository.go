@@ -0,0 +1,368 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/aldngrha/ecommerce-be/internal/entity"
+	"github.com/aldngrha/ecommerce-be/internal/money"
+	"github.com/lib/pq"
+)
+
+// SkuTakenError is returned by ReplaceProductVariants when a SKU already belongs to a
+// live variant of another product.
+type SkuTakenError struct {
+	Sku string
+}
+
+func (e *SkuTakenError) Error() string {
+	return fmt.Sprintf("sku %s is used by another product", e.Sku)
+}
+
+// skuError reports a unique violation on the SKU, hit when another product takes the same
+// SKU concurrently, as SkuTakenError.
+func skuError(err error, sku string) error {
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) && pqErr.Code == "23505" {
+		return &SkuTakenError{Sku: sku}
+	}
+
+	return err
+}
+
+type IProductVariantRepository interface {
+	ListProductOptions(ctx context.Context, productId string) ([]*entity.ProductOption, error)
+	// ListProductVariants returns the live variants of the product with their option values.
+	ListProductVariants(ctx context.Context, productId string) ([]*entity.ProductVariant, error)
+	GetProductVariantById(ctx context.Context, id string) (*entity.ProductVariant, error)
+	HasProductVariants(ctx context.Context, productId string) (bool, error)
+	// ReplaceProductVariants replaces the product's options and variants. Variants are
+	// matched by SKU, so a kept SKU keeps its id and stock; variants whose SKU is missing
+	// are soft deleted. The id of each variant is set to the stored one.
+	ReplaceProductVariants(ctx context.Context, productId string, options []*entity.ProductOption, variants []*entity.ProductVariant, updatedBy string, now time.Time) error
+}
+
+// productVariantColumns are read by scanProductVariant. The price of a variant is in the
+// currency of its product, so queries join products as p.
+const productVariantColumns = "v.id, v.product_id, v.sku, v.price_minor, p.currency, v.image_file_name, v.position, " +
+	"v.created_at, v.created_by, v.updated_at, v.updated_by, v.is_deleted"
+
+type productVariantRepository struct {
+	db *sql.DB
+}
+
+func (repo *productVariantRepository) ListProductOptions(ctx context.Context, productId string) ([]*entity.ProductOption, error) {
+	rows, err := executor(ctx, repo.db).QueryContext(
+		ctx,
+		"SELECT o.id, o.product_id, o.name, o.position, v.id, v.option_id, v.value, v.position "+
+			"FROM product_options o JOIN product_option_values v ON v.option_id = o.id "+
+			"WHERE o.product_id = $1 ORDER BY o.position, v.position",
+		productId,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	options := make([]*entity.ProductOption, 0)
+	for rows.Next() {
+		var option entity.ProductOption
+		var value entity.ProductOptionValue
+		err = rows.Scan(&option.Id, &option.ProductId, &option.Name, &option.Position, &value.Id, &value.OptionId, &value.Value, &value.Position)
+		if err != nil {
+			return nil, err
+		}
+
+		if len(options) == 0 || options[len(options)-1].Id != option.Id {
+			options = append(options, &option)
+		}
+		last := options[len(options)-1]
+		last.Values = append(last.Values, &value)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return options, nil
+}
+
+func (repo *productVariantRepository) ListProductVariants(ctx context.Context, productId string) ([]*entity.ProductVariant, error) {
+	rows, err := executor(ctx, repo.db).QueryContext(
+		ctx,
+		"SELECT "+productVariantColumns+" FROM product_variants v JOIN products p ON p.id = v.product_id "+
+			"WHERE v.product_id = $1 AND v.is_deleted = false ORDER BY v.position, v.id",
+		productId,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	variants := make([]*entity.ProductVariant, 0)
+	for rows.Next() {
+		variant, err := scanProductVariant(rows)
+		if err != nil {
+			return nil, err
+		}
+		variants = append(variants, variant)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	if err = repo.loadOptionValues(ctx, variants); err != nil {
+		return nil, err
+	}
+
+	return variants, nil
+}
+
+func (repo *productVariantRepository) GetProductVariantById(ctx context.Context, id string) (*entity.ProductVariant, error) {
+	row := executor(ctx, repo.db).QueryRowContext(
+		ctx,
+		"SELECT "+productVariantColumns+" FROM product_variants v JOIN products p ON p.id = v.product_id "+
+			"WHERE v.id = $1 AND v.is_deleted = false",
+		id,
+	)
+
+	variant, err := scanProductVariant(row)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	if err = repo.loadOptionValues(ctx, []*entity.ProductVariant{variant}); err != nil {
+		return nil, err
+	}
+
+	return variant, nil
+}
+
+func (repo *productVariantRepository) HasProductVariants(ctx context.Context, productId string) (bool, error) {
+	var exists bool
+	err := executor(ctx, repo.db).QueryRowContext(
+		ctx,
+		"SELECT EXISTS (SELECT 1 FROM product_variants WHERE product_id = $1 AND is_deleted = false)",
+		productId,
+	).Scan(&exists)
+	if err != nil {
+		return false, err
+	}
+
+	return exists, nil
+}
+
+func (repo *productVariantRepository) ReplaceProductVariants(ctx context.Context, productId string, options []*entity.ProductOption, variants []*entity.ProductVariant, updatedBy string, now time.Time) error {
+	tx, err := beginTx(ctx, repo.db)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	// serialize replacements of the same product
+	_, err = tx.ExecContext(ctx, "SELECT id FROM products WHERE id = $1 FOR UPDATE", productId)
+	if err != nil {
+		return err
+	}
+
+	// values and the links of variants to them go with the options
+	_, err = tx.ExecContext(ctx, "DELETE FROM product_options WHERE product_id = $1", productId)
+	if err != nil {
+		return err
+	}
+
+	for _, option := range options {
+		_, err = tx.ExecContext(
+			ctx,
+			"INSERT INTO product_options (id, product_id, name, position) VALUES ($1, $2, $3, $4)",
+			option.Id,
+			productId,
+			option.Name,
+			option.Position,
+		)
+		if err != nil {
+			return err
+		}
+
+		for _, value := range option.Values {
+			_, err = tx.ExecContext(
+				ctx,
+				"INSERT INTO product_option_values (id, option_id, value, position) VALUES ($1, $2, $3, $4)",
+				value.Id,
+				option.Id,
+				value.Value,
+				value.Position,
+			)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	skus := make([]string, 0, len(variants))
+	for _, variant := range variants {
+		skus = append(skus, variant.Sku)
+	}
+	_, err = tx.ExecContext(
+		ctx,
+		"UPDATE product_variants SET is_deleted = true, updated_at = $1, updated_by = $2 WHERE product_id = $3 AND is_deleted = false AND NOT (sku = ANY($4))",
+		now,
+		updatedBy,
+		productId,
+		pq.Array(skus),
+	)
+	if err != nil {
+		return err
+	}
+
+	for _, variant := range variants {
+		var priceMinor *int64
+		if variant.Price != nil {
+			priceMinor = &variant.Price.Amount
+		}
+
+		// a variant the product had before is brought back, so carts, stock and order lines
+		// pointing at it stay valid, unless another product uses its SKU by now
+		err = tx.QueryRowContext(
+			ctx,
+			"UPDATE product_variants SET price_minor = $1, image_file_name = $2, position = $3, updated_at = $4, updated_by = $5, is_deleted = false "+
+				"WHERE product_id = $6 AND sku = $7 AND is_deleted = true "+
+				"AND NOT EXISTS (SELECT 1 FROM product_variants live WHERE live.sku = $7 AND live.is_deleted = false) RETURNING id",
+			priceMinor,
+			variant.ImageFileName,
+			variant.Position,
+			now,
+			updatedBy,
+			productId,
+			variant.Sku,
+		).Scan(&variant.Id)
+		if err != nil && !errors.Is(err, sql.ErrNoRows) {
+			return skuError(err, variant.Sku)
+		}
+
+		if errors.Is(err, sql.ErrNoRows) {
+			// a live SKU of another product matches the conflict but not the WHERE, so no
+			// row comes back
+			err = tx.QueryRowContext(
+				ctx,
+				"INSERT INTO product_variants (id, product_id, sku, price_minor, image_file_name, position, created_at, created_by) VALUES ($1, $2, $3, $4, $5, $6, $7, $8) "+
+					"ON CONFLICT (sku) WHERE is_deleted = false DO UPDATE SET price_minor = EXCLUDED.price_minor, image_file_name = EXCLUDED.image_file_name, position = EXCLUDED.position, "+
+					"updated_at = EXCLUDED.created_at, updated_by = EXCLUDED.created_by "+
+					"WHERE product_variants.product_id = EXCLUDED.product_id RETURNING id",
+				variant.Id,
+				productId,
+				variant.Sku,
+				priceMinor,
+				variant.ImageFileName,
+				variant.Position,
+				now,
+				updatedBy,
+			).Scan(&variant.Id)
+			if err != nil {
+				if errors.Is(err, sql.ErrNoRows) {
+					return &SkuTakenError{Sku: variant.Sku}
+				}
+				return skuError(err, variant.Sku)
+			}
+		}
+
+		for _, value := range variant.OptionValues {
+			_, err = tx.ExecContext(
+				ctx,
+				"INSERT INTO product_variant_values (variant_id, option_value_id) VALUES ($1, $2)",
+				variant.Id,
+				value.Id,
+			)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return tx.Commit()
+}
+
+// loadOptionValues fills in the option values of the variants, in option order.
+func (repo *productVariantRepository) loadOptionValues(ctx context.Context, variants []*entity.ProductVariant) error {
+	if len(variants) == 0 {
+		return nil
+	}
+
+	byId := make(map[string]*entity.ProductVariant, len(variants))
+	ids := make([]string, 0, len(variants))
+	for _, variant := range variants {
+		byId[variant.Id] = variant
+		ids = append(ids, variant.Id)
+	}
+
+	rows, err := executor(ctx, repo.db).QueryContext(
+		ctx,
+		"SELECT pvv.variant_id, v.id, v.option_id, v.value, v.position "+
+			"FROM product_variant_values pvv "+
+			"JOIN product_option_values v ON v.id = pvv.option_value_id "+
+			"JOIN product_options o ON o.id = v.option_id "+
+			"WHERE pvv.variant_id = ANY($1) ORDER BY o.position",
+		pq.Array(ids),
+	)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var variantId string
+		var value entity.ProductOptionValue
+		if err = rows.Scan(&variantId, &value.Id, &value.OptionId, &value.Value, &value.Position); err != nil {
+			return err
+		}
+		variant := byId[variantId]
+		variant.OptionValues = append(variant.OptionValues, &value)
+	}
+
+	return rows.Err()
+}
+
+type rowScanner interface {
+	Scan(dest ...any) error
+}
+
+func scanProductVariant(row rowScanner) (*entity.ProductVariant, error) {
+	var variant entity.ProductVariant
+	var priceMinor sql.NullInt64
+	var currency string
+	err := row.Scan(
+		&variant.Id,
+		&variant.ProductId,
+		&variant.Sku,
+		&priceMinor,
+		&currency,
+		&variant.ImageFileName,
+		&variant.Position,
+		&variant.CreatedAt,
+		&variant.CreatedBy,
+		&variant.UpdatedAt,
+		&variant.UpdatedBy,
+		&variant.IsDeleted,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if priceMinor.Valid {
+		price := money.New(priceMinor.Int64, currency)
+		variant.Price = &price
+	}
+
+	return &variant, nil
+}
+
+func NewProductVariantRepository(db *sql.DB) IProductVariantRepository {
+	return &productVariantRepository{
+		db: db,
+	}
+}
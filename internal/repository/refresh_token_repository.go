@@ -0,0 +1,126 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/aldngrha/ecommerce-be/internal/entity"
+)
+
+type IRefreshTokenRepository interface {
+	InsertRefreshToken(ctx context.Context, refreshToken *entity.RefreshToken) error
+	GetRefreshTokenByHash(ctx context.Context, tokenHash string) (*entity.RefreshToken, error)
+	MarkRefreshTokenUsed(ctx context.Context, id string, usedAt time.Time) (bool, error)
+	RevokeRefreshTokenFamily(ctx context.Context, familyId string, revokedAt time.Time) error
+	RevokeUserRefreshTokens(ctx context.Context, userId string, revokedAt time.Time) error
+}
+
+type refreshTokenRepository struct {
+	db *sql.DB
+}
+
+func (repo *refreshTokenRepository) InsertRefreshToken(ctx context.Context, refreshToken *entity.RefreshToken) error {
+	_, err := executor(ctx, repo.db).ExecContext(
+		ctx,
+		"INSERT INTO refresh_tokens (id, user_id, family_id, token_hash, expires_at, created_at) VALUES ($1, $2, $3, $4, $5, $6)",
+		refreshToken.Id,
+		refreshToken.UserId,
+		refreshToken.FamilyId,
+		refreshToken.TokenHash,
+		refreshToken.ExpiresAt,
+		refreshToken.CreatedAt,
+	)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (repo *refreshTokenRepository) GetRefreshTokenByHash(ctx context.Context, tokenHash string) (*entity.RefreshToken, error) {
+	row := executor(ctx, repo.db).QueryRowContext(
+		ctx,
+		"SELECT id, user_id, family_id, token_hash, expires_at, created_at, used_at, revoked_at FROM refresh_tokens WHERE token_hash = $1",
+		tokenHash,
+	)
+	if row.Err() != nil {
+		return nil, row.Err()
+	}
+
+	var refreshToken entity.RefreshToken
+	err := row.Scan(
+		&refreshToken.Id,
+		&refreshToken.UserId,
+		&refreshToken.FamilyId,
+		&refreshToken.TokenHash,
+		&refreshToken.ExpiresAt,
+		&refreshToken.CreatedAt,
+		&refreshToken.UsedAt,
+		&refreshToken.RevokedAt,
+	)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return &refreshToken, nil
+}
+
+// MarkRefreshTokenUsed consumes the token only if nobody consumed or revoked it first.
+// It returns false when the token was already spent, which the caller treats as reuse.
+func (repo *refreshTokenRepository) MarkRefreshTokenUsed(ctx context.Context, id string, usedAt time.Time) (bool, error) {
+	result, err := executor(ctx, repo.db).ExecContext(
+		ctx,
+		"UPDATE refresh_tokens SET used_at = $1 WHERE id = $2 AND used_at IS NULL AND revoked_at IS NULL",
+		usedAt,
+		id,
+	)
+	if err != nil {
+		return false, err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	return affected == 1, nil
+}
+
+func (repo *refreshTokenRepository) RevokeRefreshTokenFamily(ctx context.Context, familyId string, revokedAt time.Time) error {
+	_, err := executor(ctx, repo.db).ExecContext(
+		ctx,
+		"UPDATE refresh_tokens SET revoked_at = $1 WHERE family_id = $2 AND revoked_at IS NULL",
+		revokedAt,
+		familyId,
+	)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (repo *refreshTokenRepository) RevokeUserRefreshTokens(ctx context.Context, userId string, revokedAt time.Time) error {
+	_, err := executor(ctx, repo.db).ExecContext(
+		ctx,
+		"UPDATE refresh_tokens SET revoked_at = $1 WHERE user_id = $2 AND revoked_at IS NULL",
+		revokedAt,
+		userId,
+	)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func NewRefreshTokenRepository(db *sql.DB) IRefreshTokenRepository {
+	return &refreshTokenRepository{
+		db: db,
+	}
+}
@@ -0,0 +1,222 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/aldngrha/ecommerce-be/internal/entity"
+)
+
+type IRoleRepository interface {
+	// LockRoleAssignments serializes changes to roles and their permissions for the rest of
+	// the transaction, so two concurrent changes cannot both remove the last role manager.
+	LockRoleAssignments(ctx context.Context) error
+	GetRoleByCode(ctx context.Context, code string) (*entity.Role, error)
+	ListRoles(ctx context.Context) ([]*entity.Role, error)
+	InsertRole(ctx context.Context, role *entity.Role) error
+	SetRolePermissions(ctx context.Context, roleCode string, permissions []string, updatedBy string) error
+	GetPermissionsByRoleCode(ctx context.Context, roleCode string) ([]string, error)
+	ListPermissions(ctx context.Context) ([]*entity.Permission, error)
+	// CountUsersWithPermission counts the active users whose role grants the permission.
+	CountUsersWithPermission(ctx context.Context, permission string) (int, error)
+}
+
+type roleRepository struct {
+	db *sql.DB
+}
+
+func (repo *roleRepository) LockRoleAssignments(ctx context.Context) error {
+	_, err := executor(ctx, repo.db).ExecContext(ctx, "SELECT pg_advisory_xact_lock(hashtext('role_assignments'))")
+
+	return err
+}
+
+func (repo *roleRepository) GetRoleByCode(ctx context.Context, code string) (*entity.Role, error) {
+	row := executor(ctx, repo.db).QueryRowContext(ctx, "SELECT id, name, code, created_at FROM roles WHERE code = $1 AND is_deleted = false", code)
+	if row.Err() != nil {
+		return nil, row.Err()
+	}
+
+	var role entity.Role
+	err := row.Scan(&role.Id, &role.Name, &role.Code, &role.CreatedAt)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	role.Permissions, err = repo.GetPermissionsByRoleCode(ctx, code)
+	if err != nil {
+		return nil, err
+	}
+
+	return &role, nil
+}
+
+func (repo *roleRepository) ListRoles(ctx context.Context) ([]*entity.Role, error) {
+	rows, err := executor(ctx, repo.db).QueryContext(ctx, "SELECT id, name, code, created_at FROM roles WHERE is_deleted = false ORDER BY code")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	roles := make([]*entity.Role, 0)
+	for rows.Next() {
+		var role entity.Role
+		if err = rows.Scan(&role.Id, &role.Name, &role.Code, &role.CreatedAt); err != nil {
+			return nil, err
+		}
+		roles = append(roles, &role)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	for _, role := range roles {
+		role.Permissions, err = repo.GetPermissionsByRoleCode(ctx, role.Code)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return roles, nil
+}
+
+func (repo *roleRepository) InsertRole(ctx context.Context, role *entity.Role) error {
+	tx, err := beginTx(ctx, repo.db)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	_, err = tx.ExecContext(ctx,
+		"INSERT INTO roles (id, name, code, created_at, created_by, is_deleted) VALUES ($1, $2, $3, $4, $5, false)",
+		role.Id,
+		role.Name,
+		role.Code,
+		role.CreatedAt,
+		role.CreatedBy,
+	)
+	if err != nil {
+		return err
+	}
+
+	for _, permission := range role.Permissions {
+		_, err = tx.ExecContext(ctx,
+			"INSERT INTO role_permissions (role_code, permission_code, created_at, created_by) VALUES ($1, $2, $3, $4)",
+			role.Code,
+			permission,
+			role.CreatedAt,
+			role.CreatedBy,
+		)
+		if err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+// SetRolePermissions replaces the whole permission set of a role.
+func (repo *roleRepository) SetRolePermissions(ctx context.Context, roleCode string, permissions []string, updatedBy string) error {
+	tx, err := beginTx(ctx, repo.db)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	now := time.Now()
+
+	_, err = tx.ExecContext(ctx, "DELETE FROM role_permissions WHERE role_code = $1", roleCode)
+	if err != nil {
+		return err
+	}
+
+	for _, permission := range permissions {
+		_, err = tx.ExecContext(ctx,
+			"INSERT INTO role_permissions (role_code, permission_code, created_at, created_by) VALUES ($1, $2, $3, $4)",
+			roleCode,
+			permission,
+			now,
+			updatedBy,
+		)
+		if err != nil {
+			return err
+		}
+	}
+
+	_, err = tx.ExecContext(ctx, "UPDATE roles SET updated_at = $1, updated_by = $2 WHERE code = $3", now, updatedBy, roleCode)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func (repo *roleRepository) GetPermissionsByRoleCode(ctx context.Context, roleCode string) ([]string, error) {
+	rows, err := executor(ctx, repo.db).QueryContext(ctx, "SELECT permission_code FROM role_permissions WHERE role_code = $1 ORDER BY permission_code", roleCode)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	permissions := make([]string, 0)
+	for rows.Next() {
+		var permission string
+		if err = rows.Scan(&permission); err != nil {
+			return nil, err
+		}
+		permissions = append(permissions, permission)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return permissions, nil
+}
+
+func (repo *roleRepository) ListPermissions(ctx context.Context) ([]*entity.Permission, error) {
+	rows, err := executor(ctx, repo.db).QueryContext(ctx, "SELECT code, description FROM permissions ORDER BY code")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	permissions := make([]*entity.Permission, 0)
+	for rows.Next() {
+		var permission entity.Permission
+		if err = rows.Scan(&permission.Code, &permission.Description); err != nil {
+			return nil, err
+		}
+		permissions = append(permissions, &permission)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return permissions, nil
+}
+
+func (repo *roleRepository) CountUsersWithPermission(ctx context.Context, permission string) (int, error) {
+	var count int
+	err := executor(ctx, repo.db).QueryRowContext(
+		ctx,
+		"SELECT COUNT(*) FROM users JOIN role_permissions ON role_permissions.role_code = users.role_code "+
+			"WHERE role_permissions.permission_code = $1 AND users.is_deleted = false",
+		permission,
+	).Scan(&count)
+	if err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
+
+func NewRoleRepository(db *sql.DB) IRoleRepository {
+	return &roleRepository{
+		db: db,
+	}
+}
@@ -0,0 +1,103 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"time"
+)
+
+// TokenRevocationStore keeps the ids (jti) of access tokens that were revoked before
+// they expired, and per user the time before which every issued token is revoked.
+// Entries only need to live until the tokens themselves expire.
+type TokenRevocationStore interface {
+	Revoke(ctx context.Context, jti string, expiresAt time.Time) error
+	// RevokeUserTokens revokes every access token of the user issued before issuedBefore.
+	// expiresAt is when the last of those tokens expires.
+	RevokeUserTokens(ctx context.Context, userId string, issuedBefore time.Time, expiresAt time.Time) error
+	IsRevoked(ctx context.Context, jti string, userId string, issuedAt time.Time) (bool, error)
+	DeleteExpired(ctx context.Context, now time.Time) (int64, error)
+}
+
+type tokenRevocationStore struct {
+	db *sql.DB
+}
+
+func (s *tokenRevocationStore) Revoke(ctx context.Context, jti string, expiresAt time.Time) error {
+	_, err := executor(ctx, s.db).ExecContext(
+		ctx,
+		"INSERT INTO revoked_tokens (jti, expires_at, revoked_at) VALUES ($1, $2, $3) ON CONFLICT (jti) DO NOTHING",
+		jti,
+		expiresAt,
+		time.Now(),
+	)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (s *tokenRevocationStore) RevokeUserTokens(ctx context.Context, userId string, issuedBefore time.Time, expiresAt time.Time) error {
+	_, err := executor(ctx, s.db).ExecContext(
+		ctx,
+		"INSERT INTO revoked_user_tokens (user_id, revoked_before, expires_at) VALUES ($1, $2, $3) "+
+			"ON CONFLICT (user_id) DO UPDATE SET "+
+			"revoked_before = GREATEST(revoked_user_tokens.revoked_before, EXCLUDED.revoked_before), "+
+			"expires_at = GREATEST(revoked_user_tokens.expires_at, EXCLUDED.expires_at)",
+		userId,
+		issuedBefore,
+		expiresAt,
+	)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (s *tokenRevocationStore) IsRevoked(ctx context.Context, jti string, userId string, issuedAt time.Time) (bool, error) {
+	var revoked bool
+	err := executor(ctx, s.db).QueryRowContext(
+		ctx,
+		"SELECT EXISTS (SELECT 1 FROM revoked_tokens WHERE jti = $1) "+
+			"OR EXISTS (SELECT 1 FROM revoked_user_tokens WHERE user_id = $2 AND revoked_before > $3)",
+		jti,
+		userId,
+		issuedAt,
+	).Scan(&revoked)
+	if err != nil {
+		return false, err
+	}
+
+	return revoked, nil
+}
+
+func (s *tokenRevocationStore) DeleteExpired(ctx context.Context, now time.Time) (int64, error) {
+	result, err := executor(ctx, s.db).ExecContext(ctx, "DELETE FROM revoked_tokens WHERE expires_at < $1", now)
+	if err != nil {
+		return 0, err
+	}
+
+	deleted, err := result.RowsAffected()
+	if err != nil {
+		return 0, err
+	}
+
+	result, err = executor(ctx, s.db).ExecContext(ctx, "DELETE FROM revoked_user_tokens WHERE expires_at < $1", now)
+	if err != nil {
+		return 0, err
+	}
+
+	deletedUsers, err := result.RowsAffected()
+	if err != nil {
+		return 0, err
+	}
+
+	return deleted + deletedUsers, nil
+}
+
+func NewTokenRevocationStore(db *sql.DB) TokenRevocationStore {
+	return &tokenRevocationStore{
+		db: db,
+	}
+}
@@ -0,0 +1,88 @@
+package repository
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// inMemoryTokenRevocationStore is a process-local TokenRevocationStore meant for tests
+// and single-process local runs. It is not shared between replicas.
+type inMemoryTokenRevocationStore struct {
+	mu      sync.RWMutex
+	revoked map[string]time.Time
+	users   map[string]revokedUserTokens
+}
+
+type revokedUserTokens struct {
+	issuedBefore time.Time
+	expiresAt    time.Time
+}
+
+func (s *inMemoryTokenRevocationStore) Revoke(ctx context.Context, jti string, expiresAt time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.revoked[jti]; !ok {
+		s.revoked[jti] = expiresAt
+	}
+
+	return nil
+}
+
+func (s *inMemoryTokenRevocationStore) RevokeUserTokens(ctx context.Context, userId string, issuedBefore time.Time, expiresAt time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	entry := s.users[userId]
+	if issuedBefore.After(entry.issuedBefore) {
+		entry.issuedBefore = issuedBefore
+	}
+	if expiresAt.After(entry.expiresAt) {
+		entry.expiresAt = expiresAt
+	}
+	s.users[userId] = entry
+
+	return nil
+}
+
+func (s *inMemoryTokenRevocationStore) IsRevoked(ctx context.Context, jti string, userId string, issuedAt time.Time) (bool, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if _, ok := s.revoked[jti]; ok {
+		return true, nil
+	}
+
+	entry, ok := s.users[userId]
+
+	return ok && issuedAt.Before(entry.issuedBefore), nil
+}
+
+func (s *inMemoryTokenRevocationStore) DeleteExpired(ctx context.Context, now time.Time) (int64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var deleted int64
+	for jti, expiresAt := range s.revoked {
+		if expiresAt.Before(now) {
+			delete(s.revoked, jti)
+			deleted++
+		}
+	}
+	for userId, entry := range s.users {
+		if entry.expiresAt.Before(now) {
+			delete(s.users, userId)
+			deleted++
+		}
+	}
+
+	return deleted, nil
+}
+
+func NewInMemoryTokenRevocationStore() TokenRevocationStore {
+	return &inMemoryTokenRevocationStore{
+		revoked: make(map[string]time.Time),
+		users:   make(map[string]revokedUserTokens),
+	}
+}
@@ -0,0 +1,97 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+)
+
+type txContextKey struct{}
+
+// ITransactionManager runs several repository calls atomically. Repositories look up the
+// transaction in the context they are given, so fn must pass its ctx on to them.
+type ITransactionManager interface {
+	// WithinTransaction commits when fn returns nil and rolls back otherwise. Nested calls
+	// join the outer transaction.
+	WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error
+}
+
+type transactionManager struct {
+	db *sql.DB
+}
+
+func (tm *transactionManager) WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	if _, ok := ctx.Value(txContextKey{}).(*sql.Tx); ok {
+		return fn(ctx)
+	}
+
+	tx, err := tm.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if err = fn(context.WithValue(ctx, txContextKey{}, tx)); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func NewTransactionManager(db *sql.DB) ITransactionManager {
+	return &transactionManager{
+		db: db,
+	}
+}
+
+// dbExecutor is what *sql.DB and *sql.Tx have in common.
+type dbExecutor interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row
+}
+
+// executor returns the transaction carried by ctx, or db outside of a transaction.
+func executor(ctx context.Context, db *sql.DB) dbExecutor {
+	if tx, ok := ctx.Value(txContextKey{}).(*sql.Tx); ok {
+		return tx
+	}
+
+	return db
+}
+
+// scopedTx is used by repository methods that need a transaction of their own. Inside
+// WithinTransaction it wraps the caller's transaction, and Commit and Rollback are left
+// to the caller.
+type scopedTx struct {
+	*sql.Tx
+	owned bool
+}
+
+func (t *scopedTx) Commit() error {
+	if !t.owned {
+		return nil
+	}
+
+	return t.Tx.Commit()
+}
+
+func (t *scopedTx) Rollback() error {
+	if !t.owned {
+		return nil
+	}
+
+	return t.Tx.Rollback()
+}
+
+func beginTx(ctx context.Context, db *sql.DB) (*scopedTx, error) {
+	if tx, ok := ctx.Value(txContextKey{}).(*sql.Tx); ok {
+		return &scopedTx{Tx: tx}, nil
+	}
+
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return &scopedTx{Tx: tx, owned: true}, nil
+}
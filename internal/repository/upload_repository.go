@@ -0,0 +1,245 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/aldngrha/ecommerce-be/internal/entity"
+)
+
+type UploadQuota struct {
+	Since    time.Time
+	MaxCount int
+	MaxBytes int64
+}
+
+// allows reports whether one more upload of sizeBytes fits next to the usage so far.
+func (quota UploadQuota) allows(count int, totalBytes int64, sizeBytes int64) bool {
+	return count+1 <= quota.MaxCount && totalBytes+sizeBytes <= quota.MaxBytes
+}
+
+type IUploadRepository interface {
+	// InsertUploadWithinQuota records the upload unless the user's uploads since quota.Since,
+	// including this one, would exceed the quota. It returns false in that case.
+	InsertUploadWithinQuota(ctx context.Context, upload *entity.Upload, quota UploadQuota) (bool, error)
+	// HasUploadQuota reports whether an upload of sizeBytes would currently fit in the
+	// user's quota. It takes no lock, so InsertUploadWithinQuota still has the final say.
+	HasUploadQuota(ctx context.Context, userId string, sizeBytes int64, quota UploadQuota) (bool, error)
+	// AbandonUpload marks a pending upload whose files could not all be stored as orphaned,
+	// so the reaper removes the files that were written.
+	AbandonUpload(ctx context.Context, id string, now time.Time) error
+	// AttachUpload marks the file as referenced by a product or variant. It returns false when the
+	// file was never uploaded or has already been deleted.
+	AttachUpload(ctx context.Context, fileName string) (bool, error)
+	// ReleaseUpload marks the file as orphaned once no product or variant references it anymore.
+	ReleaseUpload(ctx context.Context, fileName string, now time.Time) error
+	// ClaimUploadForDeletion marks an unreferenced pending or orphaned file as deleted and
+	// returns true if the caller should now delete the stored files.
+	ClaimUploadForDeletion(ctx context.Context, fileName string, now time.Time) (bool, error)
+	// ListReapableUploads returns unreferenced files uploaded or released before the given time.
+	ListReapableUploads(ctx context.Context, before time.Time, limit int) ([]string, error)
+	// ListReferencedImages returns every image file used by a product or a live variant.
+	ListReferencedImages(ctx context.Context) ([]string, error)
+}
+
+// uploadUnreferenced holds when neither a product nor a live variant uses the upload.
+const uploadUnreferenced = "NOT EXISTS (SELECT 1 FROM products WHERE products.image_file_name = uploads.file_name) " +
+	"AND NOT EXISTS (SELECT 1 FROM product_variants WHERE product_variants.image_file_name = uploads.file_name AND product_variants.is_deleted = false)"
+
+type uploadRepository struct {
+	db *sql.DB
+}
+
+func (repo *uploadRepository) InsertUploadWithinQuota(ctx context.Context, upload *entity.Upload, quota UploadQuota) (bool, error) {
+	tx, err := beginTx(ctx, repo.db)
+	if err != nil {
+		return false, err
+	}
+	defer tx.Rollback()
+
+	// serialize uploads of the same user, so parallel requests cannot all pass the check
+	_, err = tx.ExecContext(ctx, "SELECT pg_advisory_xact_lock(hashtext($1))", "uploads:"+upload.UserId)
+	if err != nil {
+		return false, err
+	}
+
+	count, totalBytes, err := uploadUsage(ctx, tx, upload.UserId, quota.Since)
+	if err != nil {
+		return false, err
+	}
+
+	if !quota.allows(count, totalBytes, upload.SizeBytes) {
+		return false, nil
+	}
+
+	_, err = tx.ExecContext(
+		ctx,
+		"INSERT INTO uploads (id, user_id, file_name, content_type, size_bytes, status, created_at) VALUES ($1, $2, $3, $4, $5, $6, $7)",
+		upload.Id,
+		upload.UserId,
+		upload.FileName,
+		upload.ContentType,
+		upload.SizeBytes,
+		entity.UploadStatusPending,
+		upload.CreatedAt,
+	)
+	if err != nil {
+		return false, err
+	}
+
+	return true, tx.Commit()
+}
+
+func (repo *uploadRepository) HasUploadQuota(ctx context.Context, userId string, sizeBytes int64, quota UploadQuota) (bool, error) {
+	count, totalBytes, err := uploadUsage(ctx, executor(ctx, repo.db), userId, quota.Since)
+	if err != nil {
+		return false, err
+	}
+
+	return quota.allows(count, totalBytes, sizeBytes), nil
+}
+
+// uploadUsage counts the uploads of the user since the given time and their total size.
+func uploadUsage(ctx context.Context, db dbExecutor, userId string, since time.Time) (int, int64, error) {
+	var count int
+	var totalBytes int64
+	err := db.QueryRowContext(
+		ctx,
+		"SELECT COUNT(*), COALESCE(SUM(size_bytes), 0) FROM uploads WHERE user_id = $1 AND created_at >= $2",
+		userId,
+		since,
+	).Scan(&count, &totalBytes)
+
+	return count, totalBytes, err
+}
+
+func (repo *uploadRepository) AbandonUpload(ctx context.Context, id string, now time.Time) error {
+	_, err := executor(ctx, repo.db).ExecContext(
+		ctx,
+		"UPDATE uploads SET status = $1, released_at = $2 WHERE id = $3 AND status = $4",
+		entity.UploadStatusOrphaned,
+		now,
+		id,
+		entity.UploadStatusPending,
+	)
+
+	return err
+}
+
+func (repo *uploadRepository) AttachUpload(ctx context.Context, fileName string) (bool, error) {
+	result, err := executor(ctx, repo.db).ExecContext(
+		ctx,
+		"UPDATE uploads SET status = $1, released_at = NULL WHERE file_name = $2 AND status <> $3",
+		entity.UploadStatusAttached,
+		fileName,
+		entity.UploadStatusDeleted,
+	)
+	if err != nil {
+		return false, err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	return affected == 1, nil
+}
+
+func (repo *uploadRepository) ReleaseUpload(ctx context.Context, fileName string, now time.Time) error {
+	_, err := executor(ctx, repo.db).ExecContext(
+		ctx,
+		"UPDATE uploads SET status = $1, released_at = $2 WHERE file_name = $3 AND status = $4 AND "+uploadUnreferenced,
+		entity.UploadStatusOrphaned,
+		now,
+		fileName,
+		entity.UploadStatusAttached,
+	)
+
+	return err
+}
+
+func (repo *uploadRepository) ClaimUploadForDeletion(ctx context.Context, fileName string, now time.Time) (bool, error) {
+	result, err := executor(ctx, repo.db).ExecContext(
+		ctx,
+		"UPDATE uploads SET status = $1, deleted_at = $2 WHERE file_name = $3 AND status IN ($4, $5) AND "+uploadUnreferenced,
+		entity.UploadStatusDeleted,
+		now,
+		fileName,
+		entity.UploadStatusPending,
+		entity.UploadStatusOrphaned,
+	)
+	if err != nil {
+		return false, err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	return affected == 1, nil
+}
+
+func (repo *uploadRepository) ListReapableUploads(ctx context.Context, before time.Time, limit int) ([]string, error) {
+	rows, err := executor(ctx, repo.db).QueryContext(
+		ctx,
+		"SELECT file_name FROM uploads WHERE ((status = $1 AND created_at < $3) OR (status = $2 AND released_at < $3)) AND "+uploadUnreferenced+" ORDER BY created_at LIMIT $4",
+		entity.UploadStatusPending,
+		entity.UploadStatusOrphaned,
+		before,
+		limit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	fileNames := make([]string, 0)
+	for rows.Next() {
+		var fileName string
+		if err = rows.Scan(&fileName); err != nil {
+			return nil, err
+		}
+		fileNames = append(fileNames, fileName)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return fileNames, nil
+}
+
+func (repo *uploadRepository) ListReferencedImages(ctx context.Context) ([]string, error) {
+	rows, err := executor(ctx, repo.db).QueryContext(
+		ctx,
+		"SELECT image_file_name FROM products "+
+			"UNION SELECT image_file_name FROM product_variants WHERE image_file_name IS NOT NULL AND is_deleted = false "+
+			"ORDER BY 1",
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	fileNames := make([]string, 0)
+	for rows.Next() {
+		var fileName string
+		if err = rows.Scan(&fileName); err != nil {
+			return nil, err
+		}
+		fileNames = append(fileNames, fileName)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return fileNames, nil
+}
+
+func NewUploadRepository(db *sql.DB) IUploadRepository {
+	return &uploadRepository{
+		db: db,
+	}
+}
@@ -0,0 +1,45 @@
+package restmiddleware
+
+import (
+	"github.com/aldngrha/ecommerce-be/internal/apperror"
+	jwtentity "github.com/aldngrha/ecommerce-be/internal/entity/jwt"
+	"github.com/aldngrha/ecommerce-be/internal/service"
+	"github.com/gofiber/fiber/v2"
+)
+
+type authMiddleware struct {
+	tokenAuthenticator service.ITokenAuthenticator
+}
+
+// Require accepts requests with a valid, unrevoked bearer token whose role has every
+// given permission. The claims are put into the request's user context.
+func (am *authMiddleware) Require(permissions ...string) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		token, err := jwtentity.ParseBearerToken(c.Get(fiber.HeaderAuthorization))
+		if err != nil {
+			return WriteError(c, err)
+		}
+
+		claims, err := am.tokenAuthenticator.Authenticate(c.UserContext(), token)
+		if err != nil {
+			return WriteError(c, err)
+		}
+
+		for _, permission := range permissions {
+			if !claims.HasPermission(permission) {
+				return WriteError(c, apperror.PermissionDenied("MISSING_PERMISSION", "your role lacks the permissions required to call this endpoint").
+					WithMetadata("permission", permission))
+			}
+		}
+
+		c.SetUserContext(claims.SendToContext(c.UserContext()))
+
+		return c.Next()
+	}
+}
+
+func NewAuthMiddleware(tokenAuthenticator service.ITokenAuthenticator) *authMiddleware {
+	return &authMiddleware{
+		tokenAuthenticator: tokenAuthenticator,
+	}
+}
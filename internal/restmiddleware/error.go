@@ -0,0 +1,52 @@
+package restmiddleware
+
+import (
+	"errors"
+	"log"
+	"net/http"
+
+	"github.com/aldngrha/ecommerce-be/internal/apperror"
+	"github.com/gofiber/fiber/v2"
+	"google.golang.org/grpc/codes"
+)
+
+var codeToHttpStatus = map[codes.Code]int{
+	codes.InvalidArgument:    http.StatusBadRequest,
+	codes.NotFound:           http.StatusNotFound,
+	codes.AlreadyExists:      http.StatusConflict,
+	codes.FailedPrecondition: http.StatusBadRequest,
+	codes.Aborted:            http.StatusConflict,
+	codes.Unauthenticated:    http.StatusUnauthorized,
+	codes.PermissionDenied:   http.StatusForbidden,
+	codes.ResourceExhausted:  http.StatusTooManyRequests,
+}
+
+// HttpStatusFromCode returns the HTTP status for a gRPC code, 500 for codes clients cannot act on.
+func HttpStatusFromCode(code codes.Code) int {
+	if status, ok := codeToHttpStatus[code]; ok {
+		return status
+	}
+
+	return http.StatusInternalServerError
+}
+
+// WriteError answers with the status matching a domain error. Anything else is logged and
+// hidden behind a 500, like ErrorMiddleware does for gRPC.
+func WriteError(c *fiber.Ctx, err error) error {
+	var appErr *apperror.Error
+	if errors.As(err, &appErr) {
+		if status, ok := codeToHttpStatus[appErr.Code]; ok {
+			return c.Status(status).JSON(fiber.Map{
+				"success": false,
+				"reason":  appErr.Reason,
+				"message": appErr.Message,
+			})
+		}
+	}
+
+	log.Printf("%s %s failed: %v", c.Method(), c.Path(), err)
+	return c.Status(http.StatusInternalServerError).JSON(fiber.Map{
+		"success": false,
+		"message": "Internal server error",
+	})
+}
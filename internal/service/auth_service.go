@@ -3,18 +3,20 @@ package service
 import (
 	"context"
 	"errors"
+	"fmt"
+	"github.com/aldngrha/ecommerce-be/internal/apperror"
 	"github.com/aldngrha/ecommerce-be/internal/entity"
 	jwtentity "github.com/aldngrha/ecommerce-be/internal/entity/jwt"
+	"github.com/aldngrha/ecommerce-be/internal/mailer"
 	"github.com/aldngrha/ecommerce-be/internal/repository"
 	"github.com/aldngrha/ecommerce-be/internal/utils"
 	"github.com/aldngrha/ecommerce-be/pb/auth"
 	"github.com/golang-jwt/jwt/v5"
 	"github.com/google/uuid"
-	gocache "github.com/patrickmn/go-cache"
 	"golang.org/x/crypto/bcrypt"
-	"google.golang.org/grpc/codes"
-	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/types/known/timestamppb"
+	"log"
+	"net/url"
 	"os"
 	"time"
 )
@@ -25,18 +27,33 @@ type IAuthService interface {
 	Logout(ctx context.Context, req *auth.LogoutRequest) (*auth.LogoutResponse, error)
 	ChangePassword(ctx context.Context, req *auth.ChangePasswordRequest) (*auth.ChangePasswordResponse, error)
 	GetProfile(ctx context.Context, req *auth.GetProfileRequest) (*auth.GetProfileResponse, error)
+	RefreshToken(ctx context.Context, req *auth.RefreshTokenRequest) (*auth.RefreshTokenResponse, error)
+	RequestPasswordReset(ctx context.Context, req *auth.RequestPasswordResetRequest) (*auth.RequestPasswordResetResponse, error)
+	ConfirmPasswordReset(ctx context.Context, req *auth.ConfirmPasswordResetRequest) (*auth.ConfirmPasswordResetResponse, error)
+	VerifyEmail(ctx context.Context, req *auth.VerifyEmailRequest) (*auth.VerifyEmailResponse, error)
+	ResendVerification(ctx context.Context, req *auth.ResendVerificationRequest) (*auth.ResendVerificationResponse, error)
 }
 
+const (
+	accessTokenTTL  = time.Minute * 15
+	refreshTokenTTL = time.Hour * 24 * 30
+	resetTokenTTL   = time.Hour
+	verifyTokenTTL  = time.Hour * 24
+)
+
 type authService struct {
-	authRepository repository.IAuthRepository
-	cacheService   *gocache.Cache
+	authRepository         repository.IAuthRepository
+	refreshTokenRepository repository.IRefreshTokenRepository
+	revocationStore        repository.TokenRevocationStore
+	transactionManager     repository.ITransactionManager
+	mailer                 mailer.Mailer
+	// when set, Login refuses accounts that have not verified their email yet
+	requireVerifiedEmail bool
 }
 
 func (s *authService) Register(ctx context.Context, req *auth.RegisterRequest) (*auth.RegisterResponse, error) {
 	if req.Password != req.ConfirmPassword {
-		return &auth.RegisterResponse{
-			Base: utils.BadRequestResponse("Password and confirm password do not match"),
-		}, nil
+		return nil, apperror.InvalidArgument("PASSWORD_MISMATCH", "password and confirm password do not match", apperror.FieldViolation{Field: "confirm_password", Description: "must match password"})
 	}
 
 	// Check email from db
@@ -46,9 +63,7 @@ func (s *authService) Register(ctx context.Context, req *auth.RegisterRequest) (
 	}
 	// if email already exists, return error
 	if user != nil {
-		return &auth.RegisterResponse{
-			Base: utils.BadRequestResponse("User with this email already exists"),
-		}, nil
+		return nil, apperror.AlreadyExists("EMAIL_TAKEN", "user with this email already exists")
 	}
 
 	// if email does not exist, proceed with registration logic insert to db
@@ -73,8 +88,14 @@ func (s *authService) Register(ctx context.Context, req *auth.RegisterRequest) (
 		return nil, err
 	}
 
+	// the account already exists at this point, a lost email is recovered with ResendVerification
+	err = s.sendVerificationEmail(ctx, &newUser)
+	if err != nil {
+		log.Printf("Failed to send verification email to user %s: %v", newUser.Id, err)
+	}
+
 	return &auth.RegisterResponse{
-		Base: utils.SuccessResponse("User registered successfully"),
+		Base: utils.SuccessResponse("User registered successfully, please check your email to verify your account"),
 	}, nil
 }
 
@@ -86,27 +107,47 @@ func (s *authService) Login(ctx context.Context, req *auth.LoginRequest) (*auth.
 	}
 
 	if user == nil {
-		return &auth.LoginResponse{
-			Base: utils.BadRequestResponse("User with this email does not exist"),
-		}, nil
+		return nil, apperror.Unauthenticated("INVALID_CREDENTIALS", "user with this email does not exist")
 	}
 	// check if password is correct
 
 	err = bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(req.Password))
 	if err != nil {
 		if errors.Is(err, bcrypt.ErrMismatchedHashAndPassword) {
-			return nil, status.Errorf(codes.Unauthenticated, "invalid password")
+			return nil, apperror.Unauthenticated("INVALID_CREDENTIALS", "invalid password")
 		}
 		return nil, err // return error if there is an issue with comparing passwords
 	}
 
+	if s.requireVerifiedEmail && !user.IsEmailVerified() {
+		return nil, apperror.PermissionDenied("EMAIL_NOT_VERIFIED", "please verify your email before logging in")
+	}
+
+	// every login starts a new refresh token family
+	accessToken, refreshToken, err := s.issueTokens(ctx, user, uuid.NewString())
+	if err != nil {
+		return nil, err
+	}
+
+	return &auth.LoginResponse{
+		Base:         utils.SuccessResponse("Login successful"),
+		AccessToken:  accessToken,
+		RefreshToken: refreshToken,
+		ExpiresIn:    int64(accessTokenTTL.Seconds()),
+	}, nil
+
+}
+
+// issueTokens signs a short-lived access token and stores a new refresh token in the given family.
+func (s *authService) issueTokens(ctx context.Context, user *entity.User, familyId string) (string, string, error) {
 	// generate JWT token
 	now := time.Now()
 	token := jwt.NewWithClaims(jwt.SigningMethodHS256, jwtentity.JwtClaims{
 		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        uuid.NewString(),
 			Subject:   user.Id,
 			IssuedAt:  jwt.NewNumericDate(now),
-			ExpiresAt: jwt.NewNumericDate(now.Add(time.Hour * 24)),
+			ExpiresAt: jwt.NewNumericDate(now.Add(accessTokenTTL)),
 		},
 		Email:    user.Email,
 		FullName: user.FullName,
@@ -115,34 +156,130 @@ func (s *authService) Login(ctx context.Context, req *auth.LoginRequest) (*auth.
 
 	secretKey := os.Getenv("JWT_SECRET")
 
-	accessToken, err := token.SignedString([]byte(secretKey)) //
+	accessToken, err := token.SignedString([]byte(secretKey))
 	if err != nil {
-		return nil, err
+		return "", "", err
 	}
 
-	return &auth.LoginResponse{
-		Base:        utils.SuccessResponse("Login successful"),
-		AccessToken: accessToken,
-	}, nil
+	refreshToken, err := utils.GenerateOpaqueToken()
+	if err != nil {
+		return "", "", err
+	}
 
+	err = s.refreshTokenRepository.InsertRefreshToken(ctx, &entity.RefreshToken{
+		Id:        uuid.NewString(),
+		UserId:    user.Id,
+		FamilyId:  familyId,
+		TokenHash: utils.HashToken(refreshToken),
+		ExpiresAt: now.Add(refreshTokenTTL),
+		CreatedAt: now,
+	})
+	if err != nil {
+		return "", "", err
+	}
+
+	return accessToken, refreshToken, nil
 }
 
-func (s *authService) Logout(ctx context.Context, req *auth.LogoutRequest) (*auth.LogoutResponse, error) {
-	// get token from metadata grpcmiddleware
-	jwtToken, err := jwtentity.ParseTokenFromContext(ctx)
+func (s *authService) RefreshToken(ctx context.Context, req *auth.RefreshTokenRequest) (*auth.RefreshTokenResponse, error) {
+	refreshToken, err := s.refreshTokenRepository.GetRefreshTokenByHash(ctx, utils.HashToken(req.RefreshToken))
 	if err != nil {
 		return nil, err
 	}
+	if refreshToken == nil {
+		return nil, apperror.Unauthenticated("INVALID_REFRESH_TOKEN", "invalid refresh token")
+	}
 
+	now := time.Now()
+
+	if refreshToken.RevokedAt != nil {
+		return nil, apperror.Unauthenticated("REFRESH_TOKEN_REVOKED", "refresh token has been revoked")
+	}
+
+	// a consumed token showing up again means it was stolen or replayed,
+	// so nobody holding any token of this family can be trusted anymore
+	if refreshToken.UsedAt != nil {
+		err = s.refreshTokenRepository.RevokeRefreshTokenFamily(ctx, refreshToken.FamilyId, now)
+		if err != nil {
+			return nil, err
+		}
+		return nil, apperror.Unauthenticated("REFRESH_TOKEN_REUSED", "refresh token reuse detected, please login again")
+	}
+
+	if now.After(refreshToken.ExpiresAt) {
+		return nil, apperror.Unauthenticated("REFRESH_TOKEN_EXPIRED", "refresh token has expired")
+	}
+
+	// the token is only spent together with issuing its successor, so a failure in between
+	// leaves it usable for the client's retry instead of looking like reuse
+	var accessToken, newRefreshToken string
+	var reused bool
+	err = s.transactionManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		consumed, err := s.refreshTokenRepository.MarkRefreshTokenUsed(ctx, refreshToken.Id, now)
+		if err != nil {
+			return err
+		}
+		if !consumed {
+			// lost a race against another request presenting the same token
+			reused = true
+			return nil
+		}
+
+		user, err := s.authRepository.GetUserById(ctx, refreshToken.UserId)
+		if err != nil {
+			return err
+		}
+		if user == nil {
+			return apperror.Unauthenticated("USER_NOT_FOUND", "user no longer exists")
+		}
+
+		accessToken, newRefreshToken, err = s.issueTokens(ctx, user, refreshToken.FamilyId)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	if reused {
+		err = s.refreshTokenRepository.RevokeRefreshTokenFamily(ctx, refreshToken.FamilyId, now)
+		if err != nil {
+			return nil, err
+		}
+		return nil, apperror.Unauthenticated("REFRESH_TOKEN_REUSED", "refresh token reuse detected, please login again")
+	}
+
+	return &auth.RefreshTokenResponse{
+		Base:         utils.SuccessResponse("Refresh token successful"),
+		AccessToken:  accessToken,
+		RefreshToken: newRefreshToken,
+		ExpiresIn:    int64(accessTokenTTL.Seconds()),
+	}, nil
+}
+
+func (s *authService) Logout(ctx context.Context, req *auth.LogoutRequest) (*auth.LogoutResponse, error) {
 	tokenClaims, err := jwtentity.GetClaimsFromContext(ctx)
 
 	if err != nil {
 		return nil, err
 	}
-	// return token
 
-	// insert token to memory cache or database to invalidate the token
-	s.cacheService.Set(jwtToken, "", time.Duration(tokenClaims.ExpiresAt.Time.Unix()-time.Now().Unix())*time.Second)
+	// revoke the token id until the token expires on its own
+	err = s.revocationStore.Revoke(ctx, tokenClaims.ID, tokenClaims.ExpiresAt.Time)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.RefreshToken != "" {
+		refreshToken, err := s.refreshTokenRepository.GetRefreshTokenByHash(ctx, utils.HashToken(req.RefreshToken))
+		if err != nil {
+			return nil, err
+		}
+		if refreshToken != nil && refreshToken.UserId == tokenClaims.Subject {
+			err = s.refreshTokenRepository.RevokeRefreshTokenFamily(ctx, refreshToken.FamilyId, time.Now())
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
 
 	// send response
 
@@ -153,9 +290,7 @@ func (s *authService) Logout(ctx context.Context, req *auth.LogoutRequest) (*aut
 
 func (s *authService) ChangePassword(ctx context.Context, req *auth.ChangePasswordRequest) (*auth.ChangePasswordResponse, error) {
 	if req.NewPassword != req.ConfirmNewPassword {
-		return &auth.ChangePasswordResponse{
-			Base: utils.BadRequestResponse("New password and confirm new password do not match"),
-		}, nil
+		return nil, apperror.InvalidArgument("PASSWORD_MISMATCH", "new password and confirm new password do not match", apperror.FieldViolation{Field: "confirm_new_password", Description: "must match new_password"})
 	}
 
 	jwtToken, err := jwtentity.ParseTokenFromContext(ctx)
@@ -172,17 +307,13 @@ func (s *authService) ChangePassword(ctx context.Context, req *auth.ChangePasswo
 		return nil, err
 	}
 	if user == nil {
-		return &auth.ChangePasswordResponse{
-			Base: utils.BadRequestResponse("User with this email does not exist"),
-		}, nil
+		return nil, apperror.NotFound("USER_NOT_FOUND", "user not found")
 	}
 
 	err = bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(req.OldPassword))
 	if err != nil {
 		if errors.Is(err, bcrypt.ErrMismatchedHashAndPassword) {
-			return &auth.ChangePasswordResponse{
-				Base: utils.BadRequestResponse("Invalid old password"),
-			}, nil
+			return nil, apperror.InvalidArgument("INVALID_OLD_PASSWORD", "invalid old password", apperror.FieldViolation{Field: "old_password", Description: "does not match the current password"})
 		}
 		return nil, err // return error if there is an issue with comparing passwords
 	}
@@ -215,24 +346,220 @@ func (s *authService) GetProfile(ctx context.Context, req *auth.GetProfileReques
 		return nil, err
 	}
 	if user == nil {
-		return &auth.GetProfileResponse{
-			Base: utils.BadRequestResponse("User with this email does not exist"),
-		}, nil
+		return nil, apperror.NotFound("USER_NOT_FOUND", "user not found")
 	}
 
 	return &auth.GetProfileResponse{
-		Base:        utils.SuccessResponse("Get profile successful"),
-		UserId:      claims.Subject,
-		Email:       claims.Email,
-		FullName:    claims.FullName,
-		RoleCode:    claims.Role,
-		MemberSince: timestamppb.New(user.CreatedAt),
+		Base:          utils.SuccessResponse("Get profile successful"),
+		UserId:        claims.Subject,
+		Email:         claims.Email,
+		FullName:      claims.FullName,
+		RoleCode:      claims.Role,
+		MemberSince:   timestamppb.New(user.CreatedAt),
+		EmailVerified: user.IsEmailVerified(),
 	}, nil
 }
 
-func NewAuthService(authRepository repository.IAuthRepository, cacheService *gocache.Cache) IAuthService {
+func (s *authService) RequestPasswordReset(ctx context.Context, req *auth.RequestPasswordResetRequest) (*auth.RequestPasswordResetResponse, error) {
+	// the response is the same whether or not the email exists, so it cannot be used to enumerate users
+	response := &auth.RequestPasswordResetResponse{
+		Base: utils.SuccessResponse("If the email is registered, a password reset link has been sent"),
+	}
+
+	user, err := s.authRepository.GetUserByEmail(ctx, req.Email)
+	if err != nil {
+		return nil, err
+	}
+	if user == nil {
+		return response, nil
+	}
+
+	now := time.Now()
+
+	// only the most recently requested link stays usable
+	err = s.authRepository.InvalidatePasswordResetTokens(ctx, user.Id, now)
+	if err != nil {
+		return nil, err
+	}
+
+	resetToken, err := utils.GenerateOpaqueToken()
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.authRepository.InsertPasswordResetToken(ctx, &entity.PasswordResetToken{
+		Id:        uuid.NewString(),
+		UserId:    user.Id,
+		TokenHash: utils.HashToken(resetToken),
+		ExpiresAt: now.Add(resetTokenTTL),
+		CreatedAt: now,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	resetLink := fmt.Sprintf("%s?token=%s", os.Getenv("PASSWORD_RESET_URL"), url.QueryEscape(resetToken))
+	err = s.mailer.Send(ctx, mailer.Message{
+		To:      user.Email,
+		Subject: "Reset your password",
+		Body: fmt.Sprintf(
+			"Hi %s,\n\nUse the link below to reset your password. It expires in %d minutes.\n\n%s\n\nIf you did not request this, you can ignore this email.\n",
+			user.FullName,
+			int(resetTokenTTL.Minutes()),
+			resetLink,
+		),
+	})
+	if err != nil {
+		// failing here would tell the caller the email is registered, the user can ask again
+		log.Printf("Failed to send password reset email to user %s: %v", user.Id, err)
+	}
+
+	return response, nil
+}
+
+func (s *authService) ConfirmPasswordReset(ctx context.Context, req *auth.ConfirmPasswordResetRequest) (*auth.ConfirmPasswordResetResponse, error) {
+	if req.NewPassword != req.ConfirmNewPassword {
+		return nil, apperror.InvalidArgument("PASSWORD_MISMATCH", "new password and confirm new password do not match", apperror.FieldViolation{Field: "confirm_new_password", Description: "must match new_password"})
+	}
+
+	resetToken, err := s.authRepository.GetPasswordResetTokenByHash(ctx, utils.HashToken(req.Token))
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	if resetToken == nil || resetToken.UsedAt != nil || now.After(resetToken.ExpiresAt) {
+		return nil, apperror.FailedPrecondition("INVALID_RESET_TOKEN", "password reset link is invalid or has expired")
+	}
+
+	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(req.NewPassword), 10)
+	if err != nil {
+		return nil, err
+	}
+
+	// the token is only spent together with the password change, a failed update leaves it usable
+	err = s.transactionManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		consumed, err := s.authRepository.MarkPasswordResetTokenUsed(ctx, resetToken.Id, now)
+		if err != nil {
+			return err
+		}
+		if !consumed {
+			return apperror.FailedPrecondition("INVALID_RESET_TOKEN", "password reset link is invalid or has expired")
+		}
+
+		user, err := s.authRepository.GetUserById(ctx, resetToken.UserId)
+		if err != nil {
+			return err
+		}
+		if user == nil {
+			return apperror.NotFound("USER_NOT_FOUND", "user not found")
+		}
+
+		err = s.authRepository.UpdateUserPassword(ctx, user.Id, string(hashedPassword), user.FullName)
+		if err != nil {
+			return err
+		}
+
+		// sessions started with the old password must not survive the reset, neither the
+		// refresh tokens nor the access tokens already handed out
+		err = s.refreshTokenRepository.RevokeUserRefreshTokens(ctx, user.Id, now)
+		if err != nil {
+			return err
+		}
+
+		// iat has whole seconds, so tokens from a login in the same second as the reset
+		// are kept rather than rejected
+		return s.revocationStore.RevokeUserTokens(ctx, user.Id, now.Truncate(time.Second), now.Add(accessTokenTTL))
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &auth.ConfirmPasswordResetResponse{
+		Base: utils.SuccessResponse("Password has been reset successfully"),
+	}, nil
+}
+
+func (s *authService) sendVerificationEmail(ctx context.Context, user *entity.User) error {
+	verifyToken, err := jwtentity.NewEmailVerificationToken(user.Id, user.Email, verifyTokenTTL)
+	if err != nil {
+		return err
+	}
+
+	verifyLink := fmt.Sprintf("%s?token=%s", os.Getenv("EMAIL_VERIFICATION_URL"), url.QueryEscape(verifyToken))
+
+	return s.mailer.Send(ctx, mailer.Message{
+		To:      user.Email,
+		Subject: "Verify your email address",
+		Body: fmt.Sprintf(
+			"Hi %s,\n\nPlease confirm your email address by opening the link below. It expires in %d hours.\n\n%s\n",
+			user.FullName,
+			int(verifyTokenTTL.Hours()),
+			verifyLink,
+		),
+	})
+}
+
+func (s *authService) VerifyEmail(ctx context.Context, req *auth.VerifyEmailRequest) (*auth.VerifyEmailResponse, error) {
+	claims, err := jwtentity.GetEmailVerificationClaims(req.Token)
+	if err != nil {
+		return nil, apperror.FailedPrecondition("INVALID_VERIFICATION_TOKEN", "verification link is invalid or has expired")
+	}
+
+	user, err := s.authRepository.GetUserById(ctx, claims.Subject)
+	if err != nil {
+		return nil, err
+	}
+	if user == nil || user.Email != claims.Email {
+		return nil, apperror.FailedPrecondition("INVALID_VERIFICATION_TOKEN", "verification link is invalid or has expired")
+	}
+
+	if user.IsEmailVerified() {
+		return &auth.VerifyEmailResponse{
+			Base: utils.SuccessResponse("Email already verified"),
+		}, nil
+	}
+
+	err = s.authRepository.MarkEmailVerified(ctx, user.Id, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	return &auth.VerifyEmailResponse{
+		Base: utils.SuccessResponse("Email verified successfully"),
+	}, nil
+}
+
+func (s *authService) ResendVerification(ctx context.Context, req *auth.ResendVerificationRequest) (*auth.ResendVerificationResponse, error) {
+	// same answer for unknown and already verified addresses to avoid leaking accounts
+	response := &auth.ResendVerificationResponse{
+		Base: utils.SuccessResponse("If the email is registered and not verified yet, a verification link has been sent"),
+	}
+
+	user, err := s.authRepository.GetUserByEmail(ctx, req.Email)
+	if err != nil {
+		return nil, err
+	}
+	if user == nil || user.IsEmailVerified() {
+		return response, nil
+	}
+
+	err = s.sendVerificationEmail(ctx, user)
+	if err != nil {
+		// failing here would tell the caller the account exists and is unverified
+		log.Printf("Failed to send verification email to user %s: %v", user.Id, err)
+	}
+
+	return response, nil
+}
+
+func NewAuthService(authRepository repository.IAuthRepository, refreshTokenRepository repository.IRefreshTokenRepository, revocationStore repository.TokenRevocationStore, transactionManager repository.ITransactionManager, mailer mailer.Mailer, requireVerifiedEmail bool) IAuthService {
 	return &authService{
-		authRepository: authRepository,
-		cacheService:   cacheService,
+		authRepository:         authRepository,
+		refreshTokenRepository: refreshTokenRepository,
+		revocationStore:        revocationStore,
+		transactionManager:     transactionManager,
+		mailer:                 mailer,
+		requireVerifiedEmail:   requireVerifiedEmail,
 	}
 }
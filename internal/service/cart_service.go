@@ -0,0 +1,292 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/aldngrha/ecommerce-be/internal/apperror"
+	"github.com/aldngrha/ecommerce-be/internal/entity"
+	jwtentity "github.com/aldngrha/ecommerce-be/internal/entity/jwt"
+	"github.com/aldngrha/ecommerce-be/internal/money"
+	"github.com/aldngrha/ecommerce-be/internal/repository"
+	"github.com/aldngrha/ecommerce-be/internal/storage"
+	"github.com/aldngrha/ecommerce-be/internal/utils"
+	"github.com/aldngrha/ecommerce-be/pb/cart"
+	"github.com/google/uuid"
+)
+
+const maxCartItemQuantity = 99
+
+type ICartService interface {
+	AddItem(ctx context.Context, req *cart.AddItemRequest) (*cart.AddItemResponse, error)
+	UpdateQuantity(ctx context.Context, req *cart.UpdateQuantityRequest) (*cart.UpdateQuantityResponse, error)
+	RemoveItem(ctx context.Context, req *cart.RemoveItemRequest) (*cart.RemoveItemResponse, error)
+	GetCart(ctx context.Context, req *cart.GetCartRequest) (*cart.GetCartResponse, error)
+	ClearCart(ctx context.Context, req *cart.ClearCartRequest) (*cart.ClearCartResponse, error)
+}
+
+type cartService struct {
+	cartRepository    repository.ICartRepository
+	productRepository repository.IProductRepository
+	variantRepository repository.IProductVariantRepository
+	blobStore         storage.BlobStore
+	currency          string
+	// the image variant shown next to cart lines
+	thumbnailVariant string
+}
+
+// currentCart returns the caller's cart, creating an empty one on first use.
+func (cs *cartService) currentCart(ctx context.Context) (*entity.Cart, error) {
+	claims, err := jwtentity.GetClaimsFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return cs.cartRepository.GetOrCreateCart(ctx, claims.Subject)
+}
+
+// buildCart prices every item from the current product and variant rows. Client-side
+// prices are never used.
+func (cs *cartService) buildCart(ctx context.Context, cartEntity *entity.Cart) (*cart.Cart, error) {
+	items, err := cs.cartRepository.GetCartItems(ctx, cartEntity.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &cart.Cart{
+		Items: make([]*cart.CartItem, 0, len(items)),
+	}
+	total := money.Zero(cs.currency)
+
+	for _, item := range items {
+		unavailable := &cart.CartItem{
+			ProductId: item.ProductId,
+			VariantId: stringValue(item.VariantId),
+			Quantity:  item.Quantity,
+			Available: false,
+		}
+
+		productEntity, err := cs.productRepository.GetProductById(ctx, item.ProductId)
+		if err != nil {
+			return nil, err
+		}
+		if productEntity == nil {
+			result.Items = append(result.Items, unavailable)
+			continue
+		}
+
+		// the variant was removed, or the product has been split into variants since
+		variant, err := resolveVariant(ctx, cs.variantRepository, productEntity, stringValue(item.VariantId))
+		var appErr *apperror.Error
+		if errors.As(err, &appErr) {
+			unavailable.Name = productEntity.Name
+			result.Items = append(result.Items, unavailable)
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		price := productEntity.Price
+		if variant != nil {
+			price = variant.EffectivePrice(productEntity)
+		}
+		// priced in a currency the store no longer sells in
+		if price.Currency != cs.currency {
+			unavailable.Name = productEntity.Name
+			result.Items = append(result.Items, unavailable)
+			continue
+		}
+
+		subtotal, err := price.Multiply(int64(item.Quantity))
+		if err != nil {
+			return nil, err
+		}
+		total, err = total.Add(subtotal)
+		if err != nil {
+			return nil, err
+		}
+
+		cartItem := &cart.CartItem{
+			ProductId:    productEntity.Id,
+			Name:         productEntity.Name,
+			Price:        toMoneyProto(price),
+			Subtotal:     toMoneyProto(subtotal),
+			Quantity:     item.Quantity,
+			ImageFileUrl: cs.blobStore.URL(storage.ProductImageVariantKey(productEntity.ImageFileName, cs.thumbnailVariant)),
+			Available:    true,
+		}
+		if variant != nil {
+			cartItem.VariantId = variant.Id
+			cartItem.Sku = variant.Sku
+			cartItem.VariantName = variant.Name()
+			cartItem.ImageFileUrl = cs.blobStore.URL(storage.ProductImageVariantKey(variant.EffectiveImageFileName(productEntity), cs.thumbnailVariant))
+		}
+
+		result.Items = append(result.Items, cartItem)
+		result.TotalQuantity += item.Quantity
+	}
+	result.Total = toMoneyProto(total)
+
+	return result, nil
+}
+
+func (cs *cartService) AddItem(ctx context.Context, req *cart.AddItemRequest) (*cart.AddItemResponse, error) {
+	cartEntity, err := cs.currentCart(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	productEntity, err := cs.productRepository.GetProductById(ctx, req.ProductId)
+	if err != nil {
+		return nil, err
+	}
+	if productEntity == nil {
+		return nil, apperror.NotFound("PRODUCT_NOT_FOUND", "product not found")
+	}
+
+	_, err = resolveVariant(ctx, cs.variantRepository, productEntity, req.VariantId)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	added, err := cs.cartRepository.AddCartItemQuantity(ctx, &entity.CartItem{
+		Id:        uuid.NewString(),
+		CartId:    cartEntity.Id,
+		ProductId: productEntity.Id,
+		VariantId: optionalId(req.VariantId),
+		Quantity:  req.Quantity,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}, maxCartItemQuantity)
+	if err != nil {
+		return nil, err
+	}
+	if !added {
+		return nil, apperror.InvalidArgument("CART_ITEM_QUANTITY_EXCEEDED", fmt.Sprintf("quantity per product cannot exceed %d", maxCartItemQuantity))
+	}
+
+	result, err := cs.buildCart(ctx, cartEntity)
+	if err != nil {
+		return nil, err
+	}
+
+	return &cart.AddItemResponse{
+		Base: utils.SuccessResponse("Item added to cart"),
+		Cart: result,
+	}, nil
+}
+
+func (cs *cartService) UpdateQuantity(ctx context.Context, req *cart.UpdateQuantityRequest) (*cart.UpdateQuantityResponse, error) {
+	cartEntity, err := cs.currentCart(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	existing, err := cs.cartRepository.GetCartItem(ctx, cartEntity.Id, req.ProductId, optionalId(req.VariantId))
+	if err != nil {
+		return nil, err
+	}
+	if existing == nil {
+		return nil, apperror.NotFound("CART_ITEM_NOT_FOUND", "product is not in the cart")
+	}
+
+	productEntity, err := cs.productRepository.GetProductById(ctx, req.ProductId)
+	if err != nil {
+		return nil, err
+	}
+	if productEntity == nil {
+		return nil, apperror.NotFound("PRODUCT_NOT_FOUND", "product not found")
+	}
+
+	_, err = resolveVariant(ctx, cs.variantRepository, productEntity, req.VariantId)
+	if err != nil {
+		return nil, err
+	}
+
+	existing.Quantity = req.Quantity
+	existing.UpdatedAt = time.Now()
+	err = cs.cartRepository.UpsertCartItem(ctx, existing)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := cs.buildCart(ctx, cartEntity)
+	if err != nil {
+		return nil, err
+	}
+
+	return &cart.UpdateQuantityResponse{
+		Base: utils.SuccessResponse("Cart item quantity updated"),
+		Cart: result,
+	}, nil
+}
+
+func (cs *cartService) RemoveItem(ctx context.Context, req *cart.RemoveItemRequest) (*cart.RemoveItemResponse, error) {
+	cartEntity, err := cs.currentCart(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	err = cs.cartRepository.DeleteCartItem(ctx, cartEntity.Id, req.ProductId, optionalId(req.VariantId))
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := cs.buildCart(ctx, cartEntity)
+	if err != nil {
+		return nil, err
+	}
+
+	return &cart.RemoveItemResponse{
+		Base: utils.SuccessResponse("Item removed from cart"),
+		Cart: result,
+	}, nil
+}
+
+func (cs *cartService) GetCart(ctx context.Context, req *cart.GetCartRequest) (*cart.GetCartResponse, error) {
+	cartEntity, err := cs.currentCart(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := cs.buildCart(ctx, cartEntity)
+	if err != nil {
+		return nil, err
+	}
+
+	return &cart.GetCartResponse{
+		Base: utils.SuccessResponse("Get cart successfully"),
+		Cart: result,
+	}, nil
+}
+
+func (cs *cartService) ClearCart(ctx context.Context, req *cart.ClearCartRequest) (*cart.ClearCartResponse, error) {
+	cartEntity, err := cs.currentCart(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	err = cs.cartRepository.ClearCart(ctx, cartEntity.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	return &cart.ClearCartResponse{
+		Base: utils.SuccessResponse("Cart cleared"),
+	}, nil
+}
+
+func NewCartService(cartRepository repository.ICartRepository, productRepository repository.IProductRepository, variantRepository repository.IProductVariantRepository, blobStore storage.BlobStore, currency string, thumbnailVariant string) ICartService {
+	return &cartService{
+		cartRepository:    cartRepository,
+		productRepository: productRepository,
+		variantRepository: variantRepository,
+		blobStore:         blobStore,
+		currency:          currency,
+		thumbnailVariant:  thumbnailVariant,
+	}
+}
@@ -0,0 +1,234 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/aldngrha/ecommerce-be/internal/apperror"
+	"github.com/aldngrha/ecommerce-be/internal/entity"
+	jwtentity "github.com/aldngrha/ecommerce-be/internal/entity/jwt"
+	"github.com/aldngrha/ecommerce-be/internal/repository"
+	"github.com/aldngrha/ecommerce-be/internal/utils"
+	"github.com/aldngrha/ecommerce-be/pb/category"
+	"github.com/google/uuid"
+)
+
+type ICategoryService interface {
+	CreateCategory(ctx context.Context, req *category.CreateCategoryRequest) (*category.CreateCategoryResponse, error)
+	EditCategory(ctx context.Context, req *category.EditCategoryRequest) (*category.EditCategoryResponse, error)
+	DeleteCategory(ctx context.Context, req *category.DeleteCategoryRequest) (*category.DeleteCategoryResponse, error)
+	ListCategories(ctx context.Context, req *category.ListCategoriesRequest) (*category.ListCategoriesResponse, error)
+}
+
+type categoryService struct {
+	categoryRepository repository.ICategoryRepository
+	transactionManager repository.ITransactionManager
+}
+
+// slugAvailable reports whether no category other than id uses slug.
+func (cgs *categoryService) slugAvailable(ctx context.Context, slug string, id string) (bool, error) {
+	existing, err := cgs.categoryRepository.GetCategoryBySlug(ctx, slug)
+	if err != nil {
+		return false, err
+	}
+
+	return existing == nil || existing.Id == id, nil
+}
+
+func (cgs *categoryService) CreateCategory(ctx context.Context, req *category.CreateCategoryRequest) (*category.CreateCategoryResponse, error) {
+	claims, err := jwtentity.GetClaimsFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	categoryEntity := entity.Category{
+		Id:        uuid.NewString(),
+		Name:      req.Name,
+		Slug:      req.Slug,
+		CreatedAt: time.Now(),
+		CreatedBy: claims.FullName,
+	}
+	if req.ParentId != "" {
+		categoryEntity.ParentId = &req.ParentId
+	}
+
+	err = cgs.transactionManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := cgs.categoryRepository.LockCategoryTree(ctx); err != nil {
+			return err
+		}
+
+		if req.ParentId != "" {
+			parent, err := cgs.categoryRepository.GetCategoryById(ctx, req.ParentId)
+			if err != nil {
+				return err
+			}
+			if parent == nil {
+				return apperror.NotFound("PARENT_CATEGORY_NOT_FOUND", "parent category not found").WithMetadata("parent_id", req.ParentId)
+			}
+		}
+
+		available, err := cgs.slugAvailable(ctx, req.Slug, "")
+		if err != nil {
+			return err
+		}
+		if !available {
+			return apperror.AlreadyExists("CATEGORY_SLUG_TAKEN", "category with this slug already exists").WithMetadata("slug", req.Slug)
+		}
+
+		return cgs.categoryRepository.CreateCategory(ctx, &categoryEntity)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &category.CreateCategoryResponse{
+		Base: utils.SuccessResponse("Category created successfully"),
+		Id:   categoryEntity.Id,
+	}, nil
+}
+
+func (cgs *categoryService) EditCategory(ctx context.Context, req *category.EditCategoryRequest) (*category.EditCategoryResponse, error) {
+	claims, err := jwtentity.GetClaimsFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	err = cgs.transactionManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := cgs.categoryRepository.LockCategoryTree(ctx); err != nil {
+			return err
+		}
+
+		categoryEntity, err := cgs.categoryRepository.GetCategoryById(ctx, req.Id)
+		if err != nil {
+			return err
+		}
+		if categoryEntity == nil {
+			return apperror.NotFound("CATEGORY_NOT_FOUND", "category not found").WithMetadata("category_id", req.Id)
+		}
+
+		categoryEntity.ParentId = nil
+		if req.ParentId != "" {
+			parent, err := cgs.categoryRepository.GetCategoryById(ctx, req.ParentId)
+			if err != nil {
+				return err
+			}
+			if parent == nil {
+				return apperror.NotFound("PARENT_CATEGORY_NOT_FOUND", "parent category not found").WithMetadata("parent_id", req.ParentId)
+			}
+
+			// moving a category below itself would cut its subtree off the tree
+			inSubtree, err := cgs.categoryRepository.IsInCategorySubtree(ctx, req.Id, req.ParentId)
+			if err != nil {
+				return err
+			}
+			if inSubtree {
+				return apperror.FailedPrecondition("CATEGORY_CYCLE", "category cannot be moved below itself")
+			}
+
+			categoryEntity.ParentId = &parent.Id
+		}
+
+		available, err := cgs.slugAvailable(ctx, req.Slug, req.Id)
+		if err != nil {
+			return err
+		}
+		if !available {
+			return apperror.AlreadyExists("CATEGORY_SLUG_TAKEN", "category with this slug already exists").WithMetadata("slug", req.Slug)
+		}
+
+		now := time.Now()
+		categoryEntity.Name = req.Name
+		categoryEntity.Slug = req.Slug
+		categoryEntity.UpdatedAt = &now
+		categoryEntity.UpdatedBy = &claims.FullName
+
+		return cgs.categoryRepository.UpdateCategory(ctx, categoryEntity)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &category.EditCategoryResponse{
+		Base: utils.SuccessResponse("Edit category successfully"),
+		Id:   req.Id,
+	}, nil
+}
+
+func (cgs *categoryService) DeleteCategory(ctx context.Context, req *category.DeleteCategoryRequest) (*category.DeleteCategoryResponse, error) {
+	err := cgs.transactionManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := cgs.categoryRepository.LockCategoryTree(ctx); err != nil {
+			return err
+		}
+
+		categoryEntity, err := cgs.categoryRepository.GetCategoryById(ctx, req.Id)
+		if err != nil {
+			return err
+		}
+		if categoryEntity == nil {
+			return apperror.NotFound("CATEGORY_NOT_FOUND", "category not found").WithMetadata("category_id", req.Id)
+		}
+
+		hasChildren, err := cgs.categoryRepository.HasChildCategories(ctx, req.Id)
+		if err != nil {
+			return err
+		}
+		if hasChildren {
+			return apperror.FailedPrecondition("CATEGORY_HAS_CHILDREN", "category still has subcategories, move or delete them first")
+		}
+
+		// products stay, they just lose this category
+		return cgs.categoryRepository.DeleteCategory(ctx, req.Id)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &category.DeleteCategoryResponse{
+		Base: utils.SuccessResponse("Delete category successfully"),
+		Id:   req.Id,
+	}, nil
+}
+
+func (cgs *categoryService) ListCategories(ctx context.Context, req *category.ListCategoriesRequest) (*category.ListCategoriesResponse, error) {
+	categories, err := cgs.categoryRepository.ListCategories(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// categories come sorted by name, so every level of the tree is as well
+	nodes := make(map[string]*category.Category, len(categories))
+	for _, categoryEntity := range categories {
+		node := &category.Category{
+			Id:   categoryEntity.Id,
+			Name: categoryEntity.Name,
+			Slug: categoryEntity.Slug,
+		}
+		if categoryEntity.ParentId != nil {
+			node.ParentId = *categoryEntity.ParentId
+		}
+		nodes[categoryEntity.Id] = node
+	}
+
+	roots := make([]*category.Category, 0)
+	for _, categoryEntity := range categories {
+		node := nodes[categoryEntity.Id]
+		parent, ok := nodes[node.ParentId]
+		if !ok {
+			roots = append(roots, node)
+			continue
+		}
+		parent.Children = append(parent.Children, node)
+	}
+
+	return &category.ListCategoriesResponse{
+		Base:       utils.SuccessResponse("Get category list successfully"),
+		Categories: roots,
+	}, nil
+}
+
+func NewCategoryService(categoryRepository repository.ICategoryRepository, transactionManager repository.ITransactionManager) ICategoryService {
+	return &categoryService{
+		categoryRepository: categoryRepository,
+		transactionManager: transactionManager,
+	}
+}
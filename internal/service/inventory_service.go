@@ -0,0 +1,150 @@
+package service
+
+import (
+	"context"
+
+	"github.com/aldngrha/ecommerce-be/internal/apperror"
+	"github.com/aldngrha/ecommerce-be/internal/entity"
+	jwtentity "github.com/aldngrha/ecommerce-be/internal/entity/jwt"
+	"github.com/aldngrha/ecommerce-be/internal/repository"
+	"github.com/aldngrha/ecommerce-be/internal/utils"
+	"github.com/aldngrha/ecommerce-be/pb/inventory"
+)
+
+type IInventoryService interface {
+	GetStock(ctx context.Context, req *inventory.GetStockRequest) (*inventory.GetStockResponse, error)
+	SetStock(ctx context.Context, req *inventory.SetStockRequest) (*inventory.SetStockResponse, error)
+	AdjustStock(ctx context.Context, req *inventory.AdjustStockRequest) (*inventory.AdjustStockResponse, error)
+}
+
+type inventoryService struct {
+	inventoryRepository repository.IInventoryRepository
+	productRepository   repository.IProductRepository
+	variantRepository   repository.IProductVariantRepository
+}
+
+// findStockItem returns the product whose stock a request refers to, after checking the
+// variant chosen for it.
+func (is *inventoryService) findStockItem(ctx context.Context, productId string, variantId string) (*entity.Product, error) {
+	productEntity, err := is.productRepository.GetProductById(ctx, productId)
+	if err != nil {
+		return nil, err
+	}
+	if productEntity == nil {
+		return nil, apperror.NotFound("PRODUCT_NOT_FOUND", "product not found")
+	}
+
+	if _, err = resolveVariant(ctx, is.variantRepository, productEntity, variantId); err != nil {
+		return nil, err
+	}
+
+	return productEntity, nil
+}
+
+// loadStock returns the stock of the product or variant, treating one without an
+// inventory row as empty.
+func (is *inventoryService) loadStock(ctx context.Context, productId string, variantId *string) (*inventory.Stock, error) {
+	inventoryEntity, err := is.inventoryRepository.GetInventory(ctx, productId, variantId)
+	if err != nil {
+		return nil, err
+	}
+	if inventoryEntity == nil {
+		inventoryEntity = &entity.Inventory{ProductId: productId, VariantId: variantId}
+	}
+
+	return &inventory.Stock{
+		ProductId: inventoryEntity.ProductId,
+		VariantId: stringValue(inventoryEntity.VariantId),
+		OnHand:    inventoryEntity.OnHand,
+		Reserved:  inventoryEntity.Reserved,
+		Available: inventoryEntity.Available(),
+	}, nil
+}
+
+func (is *inventoryService) GetStock(ctx context.Context, req *inventory.GetStockRequest) (*inventory.GetStockResponse, error) {
+	productEntity, err := is.findStockItem(ctx, req.ProductId, req.VariantId)
+	if err != nil {
+		return nil, err
+	}
+	variantId := optionalId(req.VariantId)
+
+	stock, err := is.loadStock(ctx, productEntity.Id, variantId)
+	if err != nil {
+		return nil, err
+	}
+
+	return &inventory.GetStockResponse{
+		Base:  utils.SuccessResponse("Get stock successfully"),
+		Stock: stock,
+	}, nil
+}
+
+func (is *inventoryService) SetStock(ctx context.Context, req *inventory.SetStockRequest) (*inventory.SetStockResponse, error) {
+	claims, err := jwtentity.GetClaimsFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	productEntity, err := is.findStockItem(ctx, req.ProductId, req.VariantId)
+	if err != nil {
+		return nil, err
+	}
+	variantId := optionalId(req.VariantId)
+
+	updated, err := is.inventoryRepository.SetStock(ctx, productEntity.Id, variantId, req.OnHand, claims.FullName)
+	if err != nil {
+		return nil, err
+	}
+	if !updated {
+		return nil, apperror.FailedPrecondition("STOCK_BELOW_RESERVED", "stock cannot be lower than the quantity reserved by unpaid orders")
+	}
+
+	stock, err := is.loadStock(ctx, productEntity.Id, variantId)
+	if err != nil {
+		return nil, err
+	}
+
+	return &inventory.SetStockResponse{
+		Base:  utils.SuccessResponse("Stock updated successfully"),
+		Stock: stock,
+	}, nil
+}
+
+func (is *inventoryService) AdjustStock(ctx context.Context, req *inventory.AdjustStockRequest) (*inventory.AdjustStockResponse, error) {
+	claims, err := jwtentity.GetClaimsFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	productEntity, err := is.findStockItem(ctx, req.ProductId, req.VariantId)
+	if err != nil {
+		return nil, err
+	}
+	variantId := optionalId(req.VariantId)
+
+	updated, err := is.inventoryRepository.AdjustStock(ctx, productEntity.Id, variantId, req.Delta, claims.FullName)
+	if err != nil {
+		return nil, err
+	}
+	if !updated {
+		return nil, apperror.FailedPrecondition("STOCK_BELOW_RESERVED", "stock cannot be lower than the quantity reserved by unpaid orders")
+	}
+
+	stock, err := is.loadStock(ctx, productEntity.Id, variantId)
+	if err != nil {
+		return nil, err
+	}
+
+	return &inventory.AdjustStockResponse{
+		Base:  utils.SuccessResponse("Stock adjusted successfully"),
+		Stock: stock,
+	}, nil
+}
+
+func NewInventoryService(inventoryRepository repository.IInventoryRepository, productRepository repository.IProductRepository, variantRepository repository.IProductVariantRepository) IInventoryService {
+	return &inventoryService{
+		inventoryRepository: inventoryRepository,
+		productRepository:   productRepository,
+		variantRepository:   variantRepository,
+	}
+}
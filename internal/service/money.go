@@ -0,0 +1,17 @@
+package service
+
+import (
+	"github.com/aldngrha/ecommerce-be/internal/money"
+	"github.com/aldngrha/ecommerce-be/pb/common"
+)
+
+func toMoneyProto(m money.Money) *common.Money {
+	return &common.Money{
+		Amount:   m.Amount,
+		Currency: m.Currency,
+	}
+}
+
+func fromMoneyProto(m *common.Money) money.Money {
+	return money.New(m.GetAmount(), m.GetCurrency())
+}
@@ -0,0 +1,548 @@
+package service
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/aldngrha/ecommerce-be/internal/apperror"
+	"github.com/aldngrha/ecommerce-be/internal/entity"
+	jwtentity "github.com/aldngrha/ecommerce-be/internal/entity/jwt"
+	"github.com/aldngrha/ecommerce-be/internal/money"
+	"github.com/aldngrha/ecommerce-be/internal/repository"
+	"github.com/aldngrha/ecommerce-be/internal/utils"
+	"github.com/aldngrha/ecommerce-be/pb/order"
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const (
+	defaultListOrdersLimit = 20
+	// how long an unpaid order holds its stock
+	reservationTTL = 30 * time.Minute
+)
+
+var orderStatusToProto = map[entity.OrderStatus]order.OrderStatus{
+	entity.OrderStatusPendingPayment: order.OrderStatus_ORDER_STATUS_PENDING_PAYMENT,
+	entity.OrderStatusPaid:           order.OrderStatus_ORDER_STATUS_PAID,
+	entity.OrderStatusFulfilled:      order.OrderStatus_ORDER_STATUS_FULFILLED,
+	entity.OrderStatusDelivered:      order.OrderStatus_ORDER_STATUS_DELIVERED,
+	entity.OrderStatusCancelled:      order.OrderStatus_ORDER_STATUS_CANCELLED,
+	entity.OrderStatusRefunded:       order.OrderStatus_ORDER_STATUS_REFUNDED,
+}
+
+type IOrderService interface {
+	Checkout(ctx context.Context, req *order.CheckoutRequest) (*order.CheckoutResponse, error)
+	GetOrder(ctx context.Context, req *order.GetOrderRequest) (*order.GetOrderResponse, error)
+	ListMyOrders(ctx context.Context, req *order.ListMyOrdersRequest) (*order.ListMyOrdersResponse, error)
+	CancelOrder(ctx context.Context, req *order.CancelOrderRequest) (*order.CancelOrderResponse, error)
+	UpdateOrderStatus(ctx context.Context, req *order.UpdateOrderStatusRequest) (*order.UpdateOrderStatusResponse, error)
+	ExpireUnpaidOrders(ctx context.Context, now time.Time) (int, error)
+	MarkOrderPaid(ctx context.Context, orderId string, updatedBy string) error
+	MarkOrderRefunded(ctx context.Context, orderId string, updatedBy string) error
+}
+
+type orderService struct {
+	orderRepository     repository.IOrderRepository
+	cartRepository      repository.ICartRepository
+	productRepository   repository.IProductRepository
+	variantRepository   repository.IProductVariantRepository
+	inventoryRepository repository.IInventoryRepository
+	transactionManager  repository.ITransactionManager
+	currency            string
+}
+
+// checkoutLine is the total quantity of one product, or one variant of it, in a checkout.
+type checkoutLine struct {
+	productId string
+	variantId string
+	quantity  int32
+}
+
+func (ors *orderService) Checkout(ctx context.Context, req *order.CheckoutRequest) (*order.CheckoutResponse, error) {
+	claims, err := jwtentity.GetClaimsFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// quantities per product and variant, in the order they first appear
+	lines := make([]*checkoutLine, 0)
+	linesByKey := make(map[string]*checkoutLine)
+	addLine := func(productId string, variantId string, quantity int32) {
+		key := productId + "|" + variantId
+		line, ok := linesByKey[key]
+		if !ok {
+			line = &checkoutLine{productId: productId, variantId: variantId}
+			linesByKey[key] = line
+			lines = append(lines, line)
+		}
+		line.quantity += quantity
+	}
+
+	fromCart := len(req.Items) == 0
+	var cartEntity *entity.Cart
+	if fromCart {
+		cartEntity, err = ors.cartRepository.GetOrCreateCart(ctx, claims.Subject)
+		if err != nil {
+			return nil, err
+		}
+
+		cartItems, err := ors.cartRepository.GetCartItems(ctx, cartEntity.Id)
+		if err != nil {
+			return nil, err
+		}
+		for _, item := range cartItems {
+			addLine(item.ProductId, stringValue(item.VariantId), item.Quantity)
+		}
+	} else {
+		for _, item := range req.Items {
+			addLine(item.ProductId, item.VariantId, item.Quantity)
+		}
+	}
+
+	if len(lines) == 0 {
+		return nil, apperror.FailedPrecondition("CART_EMPTY", "cart is empty")
+	}
+
+	now := time.Now()
+	orderEntity := entity.Order{
+		Id:        uuid.NewString(),
+		UserId:    claims.Subject,
+		Status:    entity.OrderStatusPendingPayment,
+		Items:     make([]*entity.OrderItem, 0, len(lines)),
+		Total:     money.Zero(ors.currency),
+		CreatedAt: now,
+		CreatedBy: claims.FullName,
+	}
+
+	for _, line := range lines {
+		productEntity, err := ors.productRepository.GetProductById(ctx, line.productId)
+		if err != nil {
+			return nil, err
+		}
+		if productEntity == nil {
+			return nil, apperror.FailedPrecondition("PRODUCT_UNAVAILABLE", "product is no longer available").WithMetadata("product_id", line.productId)
+		}
+
+		variant, err := resolveVariant(ctx, ors.variantRepository, productEntity, line.variantId)
+		if err != nil {
+			return nil, err
+		}
+
+		item := &entity.OrderItem{
+			Id:          uuid.NewString(),
+			OrderId:     orderEntity.Id,
+			ProductId:   productEntity.Id,
+			ProductName: productEntity.Name,
+			UnitPrice:   productEntity.Price,
+			Quantity:    line.quantity,
+		}
+		if variant != nil {
+			variantName := variant.Name()
+			item.VariantId = &variant.Id
+			item.Sku = &variant.Sku
+			item.VariantName = &variantName
+			item.UnitPrice = variant.EffectivePrice(productEntity)
+		}
+		if item.UnitPrice.Currency != ors.currency {
+			return nil, apperror.FailedPrecondition("UNSUPPORTED_CURRENCY", fmt.Sprintf("product %s is not sold in %s", productEntity.Name, ors.currency)).WithMetadata("product_id", productEntity.Id)
+		}
+
+		item.Subtotal, err = item.UnitPrice.Multiply(int64(item.Quantity))
+		if err != nil {
+			return nil, err
+		}
+		orderEntity.Total, err = orderEntity.Total.Add(item.Subtotal)
+		if err != nil {
+			return nil, err
+		}
+
+		orderEntity.Items = append(orderEntity.Items, item)
+	}
+
+	reservations := make([]*entity.StockReservation, 0, len(orderEntity.Items))
+	for _, item := range orderEntity.Items {
+		reservations = append(reservations, &entity.StockReservation{
+			Id:        uuid.NewString(),
+			OrderId:   orderEntity.Id,
+			ProductId: item.ProductId,
+			VariantId: item.VariantId,
+			Quantity:  item.Quantity,
+			ExpiresAt: now.Add(reservationTTL),
+			CreatedAt: now,
+		})
+	}
+
+	// stock, order and cart change together or not at all
+	err = ors.transactionManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		err := ors.inventoryRepository.ReserveStock(ctx, reservations)
+		if err != nil {
+			return err
+		}
+
+		err = ors.orderRepository.InsertOrder(ctx, &orderEntity)
+		if err != nil {
+			return err
+		}
+
+		if fromCart {
+			return ors.cartRepository.ClearCart(ctx, cartEntity.Id)
+		}
+
+		return nil
+	})
+	if err != nil {
+		var insufficientStockErr *repository.InsufficientStockError
+		if errors.As(err, &insufficientStockErr) {
+			productName := insufficientStockErr.ProductId
+			for _, item := range orderEntity.Items {
+				if item.ProductId == insufficientStockErr.ProductId && stringValue(item.VariantId) == stringValue(insufficientStockErr.VariantId) {
+					productName = item.ProductName
+					if item.VariantName != nil {
+						productName += " (" + *item.VariantName + ")"
+					}
+				}
+			}
+			return nil, apperror.FailedPrecondition("OUT_OF_STOCK", fmt.Sprintf("product %s is out of stock", productName))
+		}
+		return nil, err
+	}
+
+	return &order.CheckoutResponse{
+		Base:  utils.SuccessResponse("Checkout successful"),
+		Order: toOrderProto(&orderEntity),
+	}, nil
+}
+
+// getOwnOrder loads an order of the caller. Orders of other users are reported as not found.
+func (ors *orderService) getOwnOrder(ctx context.Context, id string) (*entity.Order, *jwtentity.JwtClaims, error) {
+	claims, err := jwtentity.GetClaimsFromContext(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	orderEntity, err := ors.orderRepository.GetOrderById(ctx, id)
+	if err != nil {
+		return nil, nil, err
+	}
+	if orderEntity == nil || orderEntity.UserId != claims.Subject {
+		return nil, claims, nil
+	}
+
+	return orderEntity, claims, nil
+}
+
+func (ors *orderService) GetOrder(ctx context.Context, req *order.GetOrderRequest) (*order.GetOrderResponse, error) {
+	orderEntity, _, err := ors.getOwnOrder(ctx, req.Id)
+	if err != nil {
+		return nil, err
+	}
+	if orderEntity == nil {
+		return nil, apperror.NotFound("ORDER_NOT_FOUND", "order not found").WithMetadata("order_id", req.Id)
+	}
+
+	return &order.GetOrderResponse{
+		Base:  utils.SuccessResponse("Get order successfully"),
+		Order: toOrderProto(orderEntity),
+	}, nil
+}
+
+func (ors *orderService) ListMyOrders(ctx context.Context, req *order.ListMyOrdersRequest) (*order.ListMyOrdersResponse, error) {
+	claims, err := jwtentity.GetClaimsFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	limit := int(req.Limit)
+	if limit == 0 {
+		limit = defaultListOrdersLimit
+	}
+
+	var afterCreatedAt *time.Time
+	afterId := ""
+	if req.Cursor != "" {
+		createdAt, id, err := decodeOrderCursor(req.Cursor)
+		if err != nil {
+			return nil, apperror.InvalidArgument("INVALID_CURSOR", "invalid cursor")
+		}
+		afterCreatedAt = &createdAt
+		afterId = id
+	}
+
+	orders, err := ors.orderRepository.ListOrdersByUser(ctx, claims.Subject, afterCreatedAt, afterId, limit+1)
+	if err != nil {
+		return nil, err
+	}
+
+	hasNext := len(orders) > limit
+	if hasNext {
+		orders = orders[:limit]
+	}
+
+	items := make([]*order.Order, 0, len(orders))
+	for _, orderEntity := range orders {
+		items = append(items, toOrderProto(orderEntity))
+	}
+
+	nextCursor := ""
+	if hasNext {
+		last := orders[len(orders)-1]
+		nextCursor = encodeOrderCursor(last.CreatedAt, last.Id)
+	}
+
+	return &order.ListMyOrdersResponse{
+		Base:       utils.SuccessResponse("Get order list successfully"),
+		Orders:     items,
+		NextCursor: nextCursor,
+		HasNext:    hasNext,
+	}, nil
+}
+
+func (ors *orderService) CancelOrder(ctx context.Context, req *order.CancelOrderRequest) (*order.CancelOrderResponse, error) {
+	orderEntity, claims, err := ors.getOwnOrder(ctx, req.Id)
+	if err != nil {
+		return nil, err
+	}
+	if orderEntity == nil {
+		return nil, apperror.NotFound("ORDER_NOT_FOUND", "order not found").WithMetadata("order_id", req.Id)
+	}
+
+	// the charge of a paid order is returned through a refund, never by cancelling
+	if orderEntity.Status != entity.OrderStatusPendingPayment {
+		return nil, apperror.FailedPrecondition(
+			"ORDER_NOT_CANCELLABLE",
+			fmt.Sprintf("only orders pending payment can be cancelled, this order is %s", orderEntity.Status),
+		).WithMetadata("status", string(orderEntity.Status))
+	}
+
+	if req.Reason != "" {
+		orderEntity.CancelReason = &req.Reason
+	}
+
+	err = ors.transition(ctx, orderEntity, entity.OrderStatusCancelled, claims.FullName)
+	if err != nil {
+		return nil, err
+	}
+
+	return &order.CancelOrderResponse{
+		Base:  utils.SuccessResponse("Order cancelled"),
+		Order: toOrderProto(orderEntity),
+	}, nil
+}
+
+func (ors *orderService) UpdateOrderStatus(ctx context.Context, req *order.UpdateOrderStatusRequest) (*order.UpdateOrderStatusResponse, error) {
+	claims, err := jwtentity.GetClaimsFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var next entity.OrderStatus
+	for status, protoStatus := range orderStatusToProto {
+		if protoStatus == req.Status {
+			next = status
+		}
+	}
+
+	// paid and refunded must match what the payment provider did, so only the payment
+	// service moves an order there
+	if next != entity.OrderStatusFulfilled && next != entity.OrderStatusDelivered {
+		return nil, apperror.FailedPrecondition(
+			"ORDER_STATUS_NOT_SETTABLE",
+			"only fulfilled and delivered can be set directly, payments and refunds go through the payment service",
+		).WithMetadata("status", string(next))
+	}
+
+	orderEntity, err := ors.orderRepository.GetOrderById(ctx, req.Id)
+	if err != nil {
+		return nil, err
+	}
+	if orderEntity == nil {
+		return nil, apperror.NotFound("ORDER_NOT_FOUND", "order not found").WithMetadata("order_id", req.Id)
+	}
+
+	err = ors.transition(ctx, orderEntity, next, claims.FullName)
+	if err != nil {
+		return nil, err
+	}
+
+	return &order.UpdateOrderStatusResponse{
+		Base:  utils.SuccessResponse("Order status updated"),
+		Order: toOrderProto(orderEntity),
+	}, nil
+}
+
+// transition applies a state machine move and persists it, failing if another request
+// changed the status in between.
+func (ors *orderService) transition(ctx context.Context, orderEntity *entity.Order, next entity.OrderStatus, updatedBy string) error {
+	from := orderEntity.Status
+	if err := orderEntity.TransitionTo(next); err != nil {
+		return err
+	}
+
+	orderEntity.UpdatedAt = time.Now()
+	orderEntity.UpdatedBy = &updatedBy
+
+	// the status and the stock it holds must not diverge
+	return ors.transactionManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		updated, err := ors.orderRepository.UpdateOrderStatus(ctx, orderEntity, from)
+		if err != nil {
+			return err
+		}
+		if !updated {
+			return apperror.New(codes.Aborted, "ORDER_STATUS_CHANGED", "order status changed concurrently, please retry")
+		}
+
+		switch next {
+		case entity.OrderStatusPaid:
+			return ors.inventoryRepository.CommitReservations(ctx, orderEntity.Id)
+		case entity.OrderStatusCancelled:
+			return ors.inventoryRepository.ReleaseReservations(ctx, orderEntity.Id)
+		case entity.OrderStatusRefunded:
+			// units of a fulfilled order have left the warehouse, returns are taken back
+			// in through stock adjustments
+			if from == entity.OrderStatusPaid {
+				return ors.inventoryRepository.RestockReservations(ctx, orderEntity.Id)
+			}
+		}
+
+		return nil
+	})
+}
+
+// MarkOrderPaid moves the order to paid once its payment is captured. It is a no-op when
+// the order is already paid, so webhook redeliveries are harmless.
+func (ors *orderService) MarkOrderPaid(ctx context.Context, orderId string, updatedBy string) error {
+	return ors.moveOrderTo(ctx, orderId, entity.OrderStatusPaid, updatedBy)
+}
+
+// MarkOrderRefunded moves the order to refunded once its payment is refunded.
+func (ors *orderService) MarkOrderRefunded(ctx context.Context, orderId string, updatedBy string) error {
+	return ors.moveOrderTo(ctx, orderId, entity.OrderStatusRefunded, updatedBy)
+}
+
+func (ors *orderService) moveOrderTo(ctx context.Context, orderId string, next entity.OrderStatus, updatedBy string) error {
+	orderEntity, err := ors.orderRepository.GetOrderById(ctx, orderId)
+	if err != nil {
+		return err
+	}
+	if orderEntity == nil {
+		return apperror.NotFound("ORDER_NOT_FOUND", fmt.Sprintf("order %s not found", orderId))
+	}
+	if orderEntity.Status == next {
+		return nil
+	}
+
+	return ors.transition(ctx, orderEntity, next, updatedBy)
+}
+
+// ExpireUnpaidOrders cancels orders whose stock reservations timed out before payment and
+// returns the number of cancelled orders. Expired reservations of orders that are no longer
+// pending are released as well.
+func (ors *orderService) ExpireUnpaidOrders(ctx context.Context, now time.Time) (int, error) {
+	orderIds, err := ors.inventoryRepository.ListExpiredReservationOrderIds(ctx, now)
+	if err != nil {
+		return 0, err
+	}
+
+	cancelled := 0
+	for _, orderId := range orderIds {
+		orderEntity, err := ors.orderRepository.GetOrderById(ctx, orderId)
+		if err != nil {
+			return cancelled, err
+		}
+
+		if orderEntity == nil || orderEntity.Status != entity.OrderStatusPendingPayment {
+			err = ors.inventoryRepository.ReleaseReservations(ctx, orderId)
+			if err != nil {
+				return cancelled, err
+			}
+			continue
+		}
+
+		reason := "payment timeout"
+		orderEntity.CancelReason = &reason
+		err = ors.transition(ctx, orderEntity, entity.OrderStatusCancelled, "system")
+		if err != nil {
+			var appErr *apperror.Error
+			if errors.As(err, &appErr) && appErr.Code == codes.Aborted {
+				// paid or cancelled in the meantime, the next run sees the new state
+				continue
+			}
+			return cancelled, err
+		}
+		cancelled++
+	}
+
+	return cancelled, nil
+}
+
+func toOrderProto(orderEntity *entity.Order) *order.Order {
+	items := make([]*order.OrderItem, 0, len(orderEntity.Items))
+	for _, item := range orderEntity.Items {
+		items = append(items, &order.OrderItem{
+			ProductId:   item.ProductId,
+			ProductName: item.ProductName,
+			UnitPrice:   toMoneyProto(item.UnitPrice),
+			Quantity:    item.Quantity,
+			Subtotal:    toMoneyProto(item.Subtotal),
+			VariantId:   stringValue(item.VariantId),
+			Sku:         stringValue(item.Sku),
+			VariantName: stringValue(item.VariantName),
+		})
+	}
+
+	result := &order.Order{
+		Id:        orderEntity.Id,
+		Status:    orderStatusToProto[orderEntity.Status],
+		Items:     items,
+		Total:     toMoneyProto(orderEntity.Total),
+		CreatedAt: timestamppb.New(orderEntity.CreatedAt),
+	}
+	if orderEntity.CancelReason != nil {
+		result.CancelReason = *orderEntity.CancelReason
+	}
+	if !orderEntity.UpdatedAt.IsZero() {
+		result.UpdatedAt = timestamppb.New(orderEntity.UpdatedAt)
+	}
+
+	return result
+}
+
+func encodeOrderCursor(createdAt time.Time, id string) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(createdAt.UTC().Format(time.RFC3339Nano) + "|" + id))
+}
+
+func decodeOrderCursor(encoded string) (time.Time, string, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return time.Time{}, "", errInvalidCursor
+	}
+
+	createdAtStr, id, ok := strings.Cut(string(raw), "|")
+	if !ok || id == "" {
+		return time.Time{}, "", errInvalidCursor
+	}
+
+	createdAt, err := time.Parse(time.RFC3339Nano, createdAtStr)
+	if err != nil {
+		return time.Time{}, "", errInvalidCursor
+	}
+
+	return createdAt, id, nil
+}
+
+func NewOrderService(orderRepository repository.IOrderRepository, cartRepository repository.ICartRepository, productRepository repository.IProductRepository, variantRepository repository.IProductVariantRepository, inventoryRepository repository.IInventoryRepository, transactionManager repository.ITransactionManager, currency string) IOrderService {
+	return &orderService{
+		orderRepository:     orderRepository,
+		cartRepository:      cartRepository,
+		productRepository:   productRepository,
+		variantRepository:   variantRepository,
+		inventoryRepository: inventoryRepository,
+		transactionManager:  transactionManager,
+		currency:            currency,
+	}
+}
@@ -0,0 +1,404 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/aldngrha/ecommerce-be/internal/apperror"
+	"github.com/aldngrha/ecommerce-be/internal/entity"
+	jwtentity "github.com/aldngrha/ecommerce-be/internal/entity/jwt"
+	paymentgateway "github.com/aldngrha/ecommerce-be/internal/payment"
+	"github.com/aldngrha/ecommerce-be/internal/repository"
+	"github.com/aldngrha/ecommerce-be/internal/utils"
+	"github.com/aldngrha/ecommerce-be/pb/payment"
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// recorded as updated_by for changes made by provider webhooks
+const paymentWebhookActor = "payment webhook"
+
+var paymentIntentStatusToProto = map[entity.PaymentIntentStatus]payment.PaymentIntentStatus{
+	entity.PaymentIntentStatusPending:       payment.PaymentIntentStatus_PAYMENT_INTENT_STATUS_PENDING,
+	entity.PaymentIntentStatusAuthorized:    payment.PaymentIntentStatus_PAYMENT_INTENT_STATUS_AUTHORIZED,
+	entity.PaymentIntentStatusCaptured:      payment.PaymentIntentStatus_PAYMENT_INTENT_STATUS_CAPTURED,
+	entity.PaymentIntentStatusFailed:        payment.PaymentIntentStatus_PAYMENT_INTENT_STATUS_FAILED,
+	entity.PaymentIntentStatusRefunded:      payment.PaymentIntentStatus_PAYMENT_INTENT_STATUS_REFUNDED,
+	entity.PaymentIntentStatusRefundPending: payment.PaymentIntentStatus_PAYMENT_INTENT_STATUS_REFUND_PENDING,
+}
+
+type IPaymentService interface {
+	PayOrder(ctx context.Context, req *payment.PayOrderRequest) (*payment.PayOrderResponse, error)
+	RefundOrder(ctx context.Context, req *payment.RefundOrderRequest) (*payment.RefundOrderResponse, error)
+	// HandleWebhook verifies and applies a provider webhook. It returns
+	// paymentgateway.ErrInvalidWebhookSignature for payloads that must not be trusted.
+	HandleWebhook(ctx context.Context, payload []byte, signature string) error
+}
+
+type paymentService struct {
+	paymentRepository  repository.IPaymentRepository
+	orderRepository    repository.IOrderRepository
+	orderService       IOrderService
+	gateway            paymentgateway.PaymentGateway
+	transactionManager repository.ITransactionManager
+}
+
+func (ps *paymentService) PayOrder(ctx context.Context, req *payment.PayOrderRequest) (*payment.PayOrderResponse, error) {
+	claims, err := jwtentity.GetClaimsFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	orderEntity, err := ps.orderRepository.GetOrderById(ctx, req.OrderId)
+	if err != nil {
+		return nil, err
+	}
+	if orderEntity == nil || orderEntity.UserId != claims.Subject {
+		return nil, apperror.NotFound("ORDER_NOT_FOUND", "order not found").WithMetadata("order_id", req.OrderId)
+	}
+	if orderEntity.Status != entity.OrderStatusPendingPayment {
+		return nil, apperror.FailedPrecondition("ORDER_NOT_AWAITING_PAYMENT", "order is not awaiting payment").WithMetadata("status", string(orderEntity.Status))
+	}
+
+	intent := entity.PaymentIntent{
+		Id:        uuid.NewString(),
+		OrderId:   orderEntity.Id,
+		Amount:    orderEntity.Total,
+		Status:    entity.PaymentIntentStatusPending,
+		Provider:  ps.gateway.Name(),
+		CreatedAt: time.Now(),
+		CreatedBy: claims.FullName,
+	}
+
+	inserted, err := ps.paymentRepository.InsertPaymentIntent(ctx, &intent)
+	if err != nil {
+		return nil, err
+	}
+	if !inserted {
+		return nil, apperror.AlreadyExists("PAYMENT_IN_PROGRESS", "a payment for this order is already in progress or completed")
+	}
+
+	authorization, err := ps.gateway.Authorize(ctx, paymentgateway.AuthorizeRequest{
+		IntentId: intent.Id,
+		Amount:   intent.Amount,
+		Card: paymentgateway.Card{
+			Number:   req.Card.Number,
+			ExpMonth: req.Card.ExpMonth,
+			ExpYear:  req.Card.ExpYear,
+			Cvc:      req.Card.Cvc,
+		},
+	})
+	if err != nil {
+		return ps.failPayment(ctx, &intent, err, claims.FullName)
+	}
+
+	intent.ProviderReference = &authorization.ProviderReference
+	err = ps.moveIntentTo(ctx, &intent, entity.PaymentIntentStatusAuthorized, claims.FullName)
+	if err != nil {
+		return nil, err
+	}
+
+	err = ps.gateway.Capture(ctx, authorization.ProviderReference, intent.Amount)
+	if err != nil {
+		return ps.failPayment(ctx, &intent, err, claims.FullName)
+	}
+
+	err = ps.moveIntentTo(ctx, &intent, entity.PaymentIntentStatusCaptured, claims.FullName)
+	if err != nil {
+		return nil, err
+	}
+
+	paid, err := ps.markOrderPaid(ctx, &intent, claims.FullName)
+	if err != nil {
+		return nil, err
+	}
+	if !paid {
+		if err = ps.finishRefund(ctx, &intent, claims.FullName); err != nil {
+			return nil, err
+		}
+		return nil, apperror.FailedPrecondition(
+			"ORDER_NOT_AWAITING_PAYMENT",
+			"order is no longer awaiting payment, the payment was refunded",
+		).WithMetadata("payment_intent_id", intent.Id)
+	}
+
+	return &payment.PayOrderResponse{
+		Base:          utils.SuccessResponse("Payment successful"),
+		PaymentIntent: toPaymentIntentProto(&intent),
+	}, nil
+}
+
+// failPayment records a failed attempt. Declines are reported as PAYMENT_DECLINED, any other
+// gateway error is returned as is.
+func (ps *paymentService) failPayment(ctx context.Context, intent *entity.PaymentIntent, cause error, updatedBy string) (*payment.PayOrderResponse, error) {
+	reason := "processing_error"
+	var declinedErr *paymentgateway.DeclinedError
+	if errors.As(cause, &declinedErr) {
+		reason = declinedErr.Reason
+	}
+
+	intent.FailureReason = &reason
+	if err := ps.moveIntentTo(ctx, intent, entity.PaymentIntentStatusFailed, updatedBy); err != nil {
+		return nil, errors.Join(cause, err)
+	}
+
+	if declinedErr == nil {
+		return nil, cause
+	}
+
+	return nil, apperror.FailedPrecondition("PAYMENT_DECLINED", fmt.Sprintf("payment declined: %s", reason)).
+		WithMetadata("payment_intent_id", intent.Id).
+		WithMetadata("decline_reason", reason)
+}
+
+// markOrderPaid moves the intent's order to paid. When the order was cancelled or expired
+// while the payment was in flight, the intent is marked refund_pending instead and false is
+// returned; the caller then calls finishRefund, outside of any transaction.
+func (ps *paymentService) markOrderPaid(ctx context.Context, intent *entity.PaymentIntent, updatedBy string) (bool, error) {
+	err := ps.orderService.MarkOrderPaid(ctx, intent.OrderId, updatedBy)
+	if err == nil {
+		return true, nil
+	}
+
+	var appErr *apperror.Error
+	if !errors.As(err, &appErr) || appErr.Code != codes.FailedPrecondition {
+		return false, err
+	}
+
+	if err = ps.startRefund(ctx, intent, updatedBy); err != nil {
+		return false, err
+	}
+
+	return false, nil
+}
+
+// refundIntent refunds a captured intent, or finishes the refund of one left pending by an
+// earlier attempt. The intent is marked refund_pending before the provider is called and
+// its id is the provider's idempotency key, so concurrent or retried refunds pay out once.
+func (ps *paymentService) refundIntent(ctx context.Context, intent *entity.PaymentIntent, updatedBy string) error {
+	if err := ps.startRefund(ctx, intent, updatedBy); err != nil {
+		return err
+	}
+
+	return ps.finishRefund(ctx, intent, updatedBy)
+}
+
+// startRefund marks a captured intent refund_pending. It may run inside a transaction,
+// unlike finishRefund.
+func (ps *paymentService) startRefund(ctx context.Context, intent *entity.PaymentIntent, updatedBy string) error {
+	if intent.Status != entity.PaymentIntentStatusCaptured {
+		return nil
+	}
+
+	return ps.moveIntentTo(ctx, intent, entity.PaymentIntentStatusRefundPending, updatedBy)
+}
+
+// finishRefund asks the provider to refund a refund_pending intent and records the outcome.
+func (ps *paymentService) finishRefund(ctx context.Context, intent *entity.PaymentIntent, updatedBy string) error {
+	err := ps.gateway.Refund(ctx, *intent.ProviderReference, intent.Id, intent.Amount)
+	if err != nil {
+		var declinedErr *paymentgateway.DeclinedError
+		if errors.As(err, &declinedErr) {
+			// nothing was paid out, the payment stays captured
+			return errors.Join(err, ps.moveIntentTo(ctx, intent, entity.PaymentIntentStatusCaptured, updatedBy))
+		}
+		// the outcome is unknown, the intent stays refund_pending for a retry or the webhook
+		return err
+	}
+
+	return ps.moveIntentTo(ctx, intent, entity.PaymentIntentStatusRefunded, updatedBy)
+}
+
+// moveIntentTo persists a status change, failing if a webhook changed the intent first.
+func (ps *paymentService) moveIntentTo(ctx context.Context, intent *entity.PaymentIntent, next entity.PaymentIntentStatus, updatedBy string) error {
+	from := intent.Status
+	intent.Status = next
+	intent.UpdatedAt = time.Now()
+	intent.UpdatedBy = &updatedBy
+
+	updated, err := ps.paymentRepository.UpdatePaymentIntent(ctx, intent, from)
+	if err != nil {
+		return err
+	}
+	if !updated {
+		return apperror.New(codes.Aborted, "PAYMENT_STATUS_CHANGED", "payment status changed concurrently, please retry")
+	}
+
+	return nil
+}
+
+func (ps *paymentService) RefundOrder(ctx context.Context, req *payment.RefundOrderRequest) (*payment.RefundOrderResponse, error) {
+	claims, err := jwtentity.GetClaimsFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	orderEntity, err := ps.orderRepository.GetOrderById(ctx, req.OrderId)
+	if err != nil {
+		return nil, err
+	}
+	if orderEntity == nil {
+		return nil, apperror.NotFound("ORDER_NOT_FOUND", "order not found").WithMetadata("order_id", req.OrderId)
+	}
+	if !orderEntity.Status.CanTransitionTo(entity.OrderStatusRefunded) {
+		return nil, apperror.FailedPrecondition("ORDER_NOT_REFUNDABLE", fmt.Sprintf("order in status %s cannot be refunded", orderEntity.Status)).WithMetadata("status", string(orderEntity.Status))
+	}
+
+	intent, err := ps.paymentRepository.GetPaidPaymentIntentByOrderId(ctx, orderEntity.Id)
+	if err != nil {
+		return nil, err
+	}
+	if intent == nil {
+		return nil, apperror.FailedPrecondition("NO_CAPTURED_PAYMENT", "order has no captured payment")
+	}
+
+	// a refunded intent means an earlier attempt failed after the provider paid out, only
+	// the order is left to update
+	if intent.Status != entity.PaymentIntentStatusRefunded {
+		err = ps.refundIntent(ctx, intent, claims.FullName)
+		if err != nil {
+			var declinedErr *paymentgateway.DeclinedError
+			if errors.As(err, &declinedErr) {
+				return nil, apperror.FailedPrecondition("REFUND_DECLINED", fmt.Sprintf("refund declined: %s", declinedErr.Reason)).
+					WithMetadata("payment_intent_id", intent.Id).
+					WithMetadata("decline_reason", declinedErr.Reason)
+			}
+			return nil, err
+		}
+	}
+
+	err = ps.orderService.MarkOrderRefunded(ctx, orderEntity.Id, claims.FullName)
+	if err != nil {
+		return nil, err
+	}
+
+	return &payment.RefundOrderResponse{
+		Base:          utils.SuccessResponse("Order refunded"),
+		PaymentIntent: toPaymentIntentProto(intent),
+	}, nil
+}
+
+func (ps *paymentService) HandleWebhook(ctx context.Context, payload []byte, signature string) error {
+	event, err := ps.gateway.VerifyWebhook(payload, signature)
+	if err != nil {
+		return err
+	}
+
+	// the event is recorded together with its effects, so a failed attempt is retried by
+	// the provider and a redelivery of an applied one is skipped
+	var refund *entity.PaymentIntent
+	err = ps.transactionManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		recorded, err := ps.paymentRepository.RecordWebhookEvent(ctx, ps.gateway.Name(), event.Id, event.Type, time.Now())
+		if err != nil {
+			return err
+		}
+		if !recorded {
+			log.Printf("Ignoring redelivered payment webhook %s", event.Id)
+			return nil
+		}
+
+		refund, err = ps.applyWebhookEvent(ctx, event)
+		return err
+	})
+	if err != nil || refund == nil {
+		return err
+	}
+
+	// the provider is only called once the refund_pending intent is committed. The event
+	// is already recorded, so a failure is not retried through the webhook: the intent
+	// stays refund_pending until the provider's refunded webhook, or is captured again if
+	// the refund was declined.
+	if err = ps.finishRefund(ctx, refund, paymentWebhookActor); err != nil {
+		log.Printf("Failed to refund payment %s of order %s that is no longer awaiting payment: %v", refund.Id, refund.OrderId, err)
+	}
+
+	return nil
+}
+
+// applyWebhookEvent applies the event within the webhook's transaction. It returns the
+// intent to refund once the transaction committed, if any.
+func (ps *paymentService) applyWebhookEvent(ctx context.Context, event *paymentgateway.WebhookEvent) (*entity.PaymentIntent, error) {
+	intent, err := ps.paymentRepository.GetPaymentIntentByProviderReference(ctx, ps.gateway.Name(), event.ProviderReference)
+	if err != nil {
+		return nil, err
+	}
+	if intent == nil {
+		// acknowledged so the provider stops retrying a payment we do not know
+		log.Printf("Ignoring payment webhook %s for unknown payment %s", event.Id, event.ProviderReference)
+		return nil, nil
+	}
+
+	switch event.Type {
+	case paymentgateway.WebhookEventCaptured:
+		switch intent.Status {
+		case entity.PaymentIntentStatusPending, entity.PaymentIntentStatusAuthorized:
+			if err = ps.moveIntentTo(ctx, intent, entity.PaymentIntentStatusCaptured, paymentWebhookActor); err != nil {
+				return nil, err
+			}
+		case entity.PaymentIntentStatusCaptured:
+		default:
+			return nil, nil
+		}
+		paid, err := ps.markOrderPaid(ctx, intent, paymentWebhookActor)
+		if err != nil || paid {
+			return nil, err
+		}
+		return intent, nil
+	case paymentgateway.WebhookEventFailed:
+		if intent.Status != entity.PaymentIntentStatusPending && intent.Status != entity.PaymentIntentStatusAuthorized {
+			return nil, nil
+		}
+		reason := event.FailureReason
+		if reason == "" {
+			reason = "processing_error"
+		}
+		intent.FailureReason = &reason
+		return nil, ps.moveIntentTo(ctx, intent, entity.PaymentIntentStatusFailed, paymentWebhookActor)
+	case paymentgateway.WebhookEventRefunded:
+		if intent.Status != entity.PaymentIntentStatusCaptured && intent.Status != entity.PaymentIntentStatusRefundPending {
+			return nil, nil
+		}
+		if err = ps.moveIntentTo(ctx, intent, entity.PaymentIntentStatusRefunded, paymentWebhookActor); err != nil {
+			return nil, err
+		}
+		err = ps.orderService.MarkOrderRefunded(ctx, intent.OrderId, paymentWebhookActor)
+		var appErr *apperror.Error
+		if errors.As(err, &appErr) && appErr.Code == codes.FailedPrecondition {
+			log.Printf("Payment %s was refunded but order %s cannot move to refunded: %v", intent.Id, intent.OrderId, err)
+			return nil, nil
+		}
+		return nil, err
+	default:
+		log.Printf("Ignoring payment webhook %s of type %s", event.Id, event.Type)
+		return nil, nil
+	}
+}
+
+func toPaymentIntentProto(intent *entity.PaymentIntent) *payment.PaymentIntent {
+	result := &payment.PaymentIntent{
+		Id:        intent.Id,
+		OrderId:   intent.OrderId,
+		Status:    paymentIntentStatusToProto[intent.Status],
+		Amount:    toMoneyProto(intent.Amount),
+		CreatedAt: timestamppb.New(intent.CreatedAt),
+	}
+	if intent.FailureReason != nil {
+		result.FailureReason = *intent.FailureReason
+	}
+
+	return result
+}
+
+func NewPaymentService(paymentRepository repository.IPaymentRepository, orderRepository repository.IOrderRepository, orderService IOrderService, gateway paymentgateway.PaymentGateway, transactionManager repository.ITransactionManager) IPaymentService {
+	return &paymentService{
+		paymentRepository:  paymentRepository,
+		orderRepository:    orderRepository,
+		orderService:       orderService,
+		gateway:            gateway,
+		transactionManager: transactionManager,
+	}
+}
@@ -0,0 +1,49 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/aldngrha/ecommerce-be/internal/repository"
+	gocache "github.com/patrickmn/go-cache"
+)
+
+// permissionCacheTTL bounds how long another replica may keep serving a role's old
+// permissions after they were changed through RoleService.
+const permissionCacheTTL = time.Minute * 5
+
+type IPermissionResolver interface {
+	PermissionsForRole(ctx context.Context, roleCode string) ([]string, error)
+	Invalidate(roleCode string)
+}
+
+type permissionResolver struct {
+	roleRepository repository.IRoleRepository
+	cacheService   *gocache.Cache
+}
+
+func (r *permissionResolver) PermissionsForRole(ctx context.Context, roleCode string) ([]string, error) {
+	if cached, ok := r.cacheService.Get(roleCode); ok {
+		return cached.([]string), nil
+	}
+
+	permissions, err := r.roleRepository.GetPermissionsByRoleCode(ctx, roleCode)
+	if err != nil {
+		return nil, err
+	}
+
+	r.cacheService.SetDefault(roleCode, permissions)
+
+	return permissions, nil
+}
+
+func (r *permissionResolver) Invalidate(roleCode string) {
+	r.cacheService.Delete(roleCode)
+}
+
+func NewPermissionResolver(roleRepository repository.IRoleRepository) IPermissionResolver {
+	return &permissionResolver{
+		roleRepository: roleRepository,
+		cacheService:   gocache.New(permissionCacheTTL, permissionCacheTTL*2),
+	}
+}
@@ -0,0 +1,78 @@
+package service
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"strconv"
+	"time"
+
+	"github.com/aldngrha/ecommerce-be/internal/entity"
+	"github.com/aldngrha/ecommerce-be/internal/repository"
+)
+
+var errInvalidCursor = errors.New("invalid cursor")
+
+// productCursor is the keyset position of the last item on a page. It also records
+// the ordering it was issued for, so a cursor cannot be replayed against another sort.
+type productCursor struct {
+	SortBy   string `json:"s"`
+	SortDesc bool   `json:"d"`
+	Value    string `json:"v"`
+	Id       string `json:"i"`
+}
+
+func encodeProductCursor(sortBy string, sortDesc bool, last *entity.Product) string {
+	cursor := productCursor{
+		SortBy:   sortBy,
+		SortDesc: sortDesc,
+		Id:       last.Id,
+	}
+
+	switch sortBy {
+	case repository.ProductSortByPrice:
+		cursor.Value = strconv.FormatInt(last.Price.Amount, 10)
+	case repository.ProductSortByName:
+		cursor.Value = last.Name
+	default:
+		cursor.Value = last.CreatedAt.UTC().Format(time.RFC3339Nano)
+	}
+
+	raw, _ := json.Marshal(cursor)
+
+	return base64.RawURLEncoding.EncodeToString(raw)
+}
+
+// decodeProductCursor returns the typed keyset value and id stored in the cursor.
+func decodeProductCursor(encoded string, sortBy string, sortDesc bool) (any, string, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, "", errInvalidCursor
+	}
+
+	var cursor productCursor
+	if err := json.Unmarshal(raw, &cursor); err != nil {
+		return nil, "", errInvalidCursor
+	}
+
+	if cursor.Id == "" || cursor.SortBy != sortBy || cursor.SortDesc != sortDesc {
+		return nil, "", errInvalidCursor
+	}
+
+	switch sortBy {
+	case repository.ProductSortByPrice:
+		price, err := strconv.ParseInt(cursor.Value, 10, 64)
+		if err != nil {
+			return nil, "", errInvalidCursor
+		}
+		return price, cursor.Id, nil
+	case repository.ProductSortByName:
+		return cursor.Value, cursor.Id, nil
+	default:
+		createdAt, err := time.Parse(time.RFC3339Nano, cursor.Value)
+		if err != nil {
+			return nil, "", errInvalidCursor
+		}
+		return createdAt, cursor.Id, nil
+	}
+}
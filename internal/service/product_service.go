@@ -2,53 +2,81 @@ package service
 
 import (
 	"context"
+	"errors"
 	"fmt"
-	"os"
-	"path/filepath"
+	"log"
+	"strings"
 	"time"
 
+	"github.com/aldngrha/ecommerce-be/internal/apperror"
 	"github.com/aldngrha/ecommerce-be/internal/entity"
 	jwtentity "github.com/aldngrha/ecommerce-be/internal/entity/jwt"
 	"github.com/aldngrha/ecommerce-be/internal/repository"
+	"github.com/aldngrha/ecommerce-be/internal/storage"
 	"github.com/aldngrha/ecommerce-be/internal/utils"
 	"github.com/aldngrha/ecommerce-be/pb/product"
 	"github.com/google/uuid"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 type IProductService interface {
 	CreateProduct(ctx context.Context, req *product.CreateProductRequest) (*product.CreateProductResponse, error)
 	DetailProduct(ctx context.Context, request *product.DetailProductRequest) (*product.DetailProductResponse, error)
 	EditProduct(ctx context.Context, request *product.EditProductRequest) (*product.EditProductResponse, error)
+	ListProducts(ctx context.Context, request *product.ListProductsRequest) (*product.ListProductsResponse, error)
+	DeleteProduct(ctx context.Context, request *product.DeleteProductRequest) (*product.DeleteProductResponse, error)
+	RestoreProduct(ctx context.Context, request *product.RestoreProductRequest) (*product.RestoreProductResponse, error)
+	ListDeletedProducts(ctx context.Context, request *product.ListDeletedProductsRequest) (*product.ListDeletedProductsResponse, error)
+	SetProductVariants(ctx context.Context, request *product.SetProductVariantsRequest) (*product.SetProductVariantsResponse, error)
 }
 
+const defaultListProductsLimit = 20
+
+// errImageNotAvailable aborts the product transaction when the upload record of the
+// image is missing or the reaper already deleted it.
+var errImageNotAvailable = errors.New("image not available")
+
 type productService struct {
-	productRepository repository.IProductRepository
+	productRepository   repository.IProductRepository
+	categoryRepository  repository.ICategoryRepository
+	variantRepository   repository.IProductVariantRepository
+	inventoryRepository repository.IInventoryRepository
+	uploadRepository    repository.IUploadRepository
+	transactionManager  repository.ITransactionManager
+	blobStore           storage.BlobStore
+	// variants generated for every uploaded image, "original" first
+	imageVariants []string
+	// every price is in the store currency
+	currency string
 }
 
 func (ps *productService) CreateProduct(ctx context.Context, req *product.CreateProductRequest) (*product.CreateProductResponse, error) {
 
-	//check if role user is admin?
 	claims, err := jwtentity.GetClaimsFromContext(ctx)
 
 	if err != nil {
 		return nil, err
 	}
 
-	if claims.Role != entity.UserRoleAdmin {
-		return &product.CreateProductResponse{
-			Base: utils.BadRequestResponse("only admin can create product"),
-		}, nil
+	if req.Price.Currency != ps.currency {
+		return nil, apperror.InvalidArgument("UNSUPPORTED_CURRENCY", fmt.Sprintf("price must be in %s", ps.currency)).WithMetadata("currency", ps.currency)
 	}
 
 	// check if image exists
-	imagePath := filepath.Join("storage", "images", "products", req.ImageFileName)
-	_, err = os.Stat(imagePath)
+	imageExists, err := ps.blobStore.Exists(ctx, storage.ProductImageKey(req.ImageFileName))
 	if err != nil {
-		if os.IsNotExist(err) {
-			return &product.CreateProductResponse{
-				Base: utils.BadRequestResponse("image file not found"),
-			}, nil
-		}
+		return nil, err
+	}
+	if !imageExists {
+		return nil, apperror.NotFound("IMAGE_NOT_FOUND", "image file not found")
+	}
+
+	categoriesExist, err := ps.categoriesExist(ctx, req.CategoryIds)
+	if err != nil {
+		return nil, err
+	}
+	if !categoriesExist {
+		return nil, apperror.NotFound("CATEGORY_NOT_FOUND", "category not found")
 	}
 
 	// insert to db
@@ -59,14 +87,39 @@ func (ps *productService) CreateProduct(ctx context.Context, req *product.Create
 		Id:            uuid.NewString(),
 		Name:          req.Name,
 		Description:   req.Description,
-		Price:         req.Price,
+		Price:         fromMoneyProto(req.Price),
 		ImageFileName: req.ImageFileName,
 		CreatedAt:     time.Now(),
 		CreatedBy:     claims.FullName,
 	}
 
-	err = ps.productRepository.CreateNewProduct(ctx, &productEntity)
+	err = ps.transactionManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := ps.productRepository.CreateNewProduct(ctx, &productEntity); err != nil {
+			return err
+		}
+
+		if err := ps.categoryRepository.SetProductCategories(ctx, productEntity.Id, req.CategoryIds); err != nil {
+			return err
+		}
+
+		// new products start out of stock until it is set through InventoryService
+		if _, err := ps.inventoryRepository.SetStock(ctx, productEntity.Id, nil, 0, claims.FullName); err != nil {
+			return err
+		}
 
+		attached, err := ps.uploadRepository.AttachUpload(ctx, productEntity.ImageFileName)
+		if err != nil {
+			return err
+		}
+		if !attached {
+			return errImageNotAvailable
+		}
+
+		return nil
+	})
+	if errors.Is(err, errImageNotAvailable) {
+		return nil, apperror.NotFound("IMAGE_NOT_FOUND", "image file not found")
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -85,19 +138,90 @@ func (ps *productService) DetailProduct(ctx context.Context, req *product.Detail
 	}
 	// if null, return not found
 	if productEntity == nil {
-		return &product.DetailProductResponse{
-			Base: utils.NotFoundResponse("Product not found"),
-		}, nil
+		return nil, apperror.NotFound("PRODUCT_NOT_FOUND", "product not found")
+	}
+
+	categories, err := ps.categoryRepository.ListCategoriesByProductId(ctx, productEntity.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	productCategories := make([]*product.ProductCategory, 0, len(categories))
+	for _, categoryEntity := range categories {
+		productCategories = append(productCategories, &product.ProductCategory{
+			Id:   categoryEntity.Id,
+			Name: categoryEntity.Name,
+			Slug: categoryEntity.Slug,
+		})
+	}
+
+	options, err := ps.variantRepository.ListProductOptions(ctx, productEntity.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	productOptions := make([]*product.ProductOption, 0, len(options))
+	for _, option := range options {
+		values := make([]string, 0, len(option.Values))
+		for _, value := range option.Values {
+			values = append(values, value.Value)
+		}
+		productOptions = append(productOptions, &product.ProductOption{
+			Name:   option.Name,
+			Values: values,
+		})
+	}
+
+	variants, err := ps.variantRepository.ListProductVariants(ctx, productEntity.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	optionNames := make(map[string]string, len(options))
+	for _, option := range options {
+		optionNames[option.Id] = option.Name
+	}
+
+	stocks, err := ps.inventoryRepository.ListVariantInventory(ctx, productEntity.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	productVariants := make([]*product.ProductVariant, 0, len(variants))
+	for _, variant := range variants {
+		optionValues := make([]*product.ProductVariantOptionValue, 0, len(variant.OptionValues))
+		for _, value := range variant.OptionValues {
+			optionValues = append(optionValues, &product.ProductVariantOptionValue{
+				Option: optionNames[value.OptionId],
+				Value:  value.Value,
+			})
+		}
+
+		productVariant := &product.ProductVariant{
+			Id:           variant.Id,
+			Sku:          variant.Sku,
+			Name:         variant.Name(),
+			OptionValues: optionValues,
+			Price:        toMoneyProto(variant.EffectivePrice(productEntity)),
+			Images:       ps.imagesOf(variant.EffectiveImageFileName(productEntity)),
+		}
+		if stock, ok := stocks[variant.Id]; ok {
+			productVariant.Available = stock.Available()
+		}
+		productVariants = append(productVariants, productVariant)
 	}
 
 	// send response
 	return &product.DetailProductResponse{
-		Base:         utils.SuccessResponse("Get product detail successfully"),
-		Id:           productEntity.Id,
-		Name:         productEntity.Name,
-		Description:  productEntity.Description,
-		Price:        productEntity.Price,
-		ImageFileUrl: fmt.Sprintf("%s/images/products/%s", os.Getenv("STORAGE_SERVICE_URL"), productEntity.ImageFileName),
+		Base:        utils.SuccessResponse("Get product detail successfully"),
+		Id:          productEntity.Id,
+		Name:        productEntity.Name,
+		Description: productEntity.Description,
+		Price:       toMoneyProto(productEntity.Price),
+		Images:      ps.imagesOf(productEntity.ImageFileName),
+		Categories:  productCategories,
+		Options:     productOptions,
+		Variants:    productVariants,
 	}, nil
 }
 
@@ -108,60 +232,87 @@ func (ps *productService) EditProduct(ctx context.Context, request *product.Edit
 		return nil, err
 	}
 
-	if claims.Role != entity.UserRoleAdmin {
-		return &product.EditProductResponse{
-			Base: utils.BadRequestResponse("only admin can update product"),
-		}, nil
-	}
-
 	// validate if id available on db
 	productEntity, err := ps.productRepository.GetProductById(ctx, request.Id)
 	if err != nil {
 		return nil, err
 	}
 	if productEntity == nil {
-		return &product.EditProductResponse{
-			Base: utils.NotFoundResponse("Product not found"),
-		}, nil
+		return nil, apperror.NotFound("PRODUCT_NOT_FOUND", "product not found")
 	}
 
-	// if image change, remove old image
-	if productEntity.ImageFileName != request.ImageFileName {
-		newImagePath := filepath.Join("storage", "images", "products", request.ImageFileName)
-		_, err := os.Stat(newImagePath)
-		if err != nil {
-			if os.IsNotExist(err) {
-				return &product.EditProductResponse{
-					Base: utils.NotFoundResponse("Image not found"),
-				}, nil
-			}
-			return nil, err
-		}
+	if request.Price.Currency != ps.currency {
+		return nil, apperror.InvalidArgument("UNSUPPORTED_CURRENCY", fmt.Sprintf("price must be in %s", ps.currency)).WithMetadata("currency", ps.currency)
+	}
 
-		oldImagePath := filepath.Join("storage", "images", "products", productEntity.ImageFileName)
-		err = os.Remove(oldImagePath)
+	imageChanged := productEntity.ImageFileName != request.ImageFileName
+	if imageChanged {
+		imageExists, err := ps.blobStore.Exists(ctx, storage.ProductImageKey(request.ImageFileName))
 		if err != nil {
 			return nil, err
 		}
+		if !imageExists {
+			return nil, apperror.NotFound("IMAGE_NOT_FOUND", "image file not found")
+		}
+	}
+
+	categoriesExist, err := ps.categoriesExist(ctx, request.CategoryIds.GetIds())
+	if err != nil {
+		return nil, err
+	}
+	if !categoriesExist {
+		return nil, apperror.NotFound("CATEGORY_NOT_FOUND", "category not found")
 	}
 
 	// update db
+	now := time.Now()
 	newProduct := entity.Product{
 		Id:            request.Id,
 		Name:          request.Name,
 		Description:   request.Description,
-		Price:         request.Price,
+		Price:         fromMoneyProto(request.Price),
 		ImageFileName: request.ImageFileName,
-		UpdatedAt:     time.Now(),
+		UpdatedAt:     now,
 		UpdatedBy:     &claims.FullName,
 	}
 
-	err = ps.productRepository.UpdateProduct(ctx, &newProduct)
+	err = ps.transactionManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := ps.productRepository.UpdateProduct(ctx, &newProduct); err != nil {
+			return err
+		}
+		// categories are only replaced when the request carries them
+		if request.CategoryIds != nil {
+			if err := ps.categoryRepository.SetProductCategories(ctx, newProduct.Id, request.CategoryIds.Ids); err != nil {
+				return err
+			}
+		}
+		if !imageChanged {
+			return nil
+		}
+
+		attached, err := ps.uploadRepository.AttachUpload(ctx, newProduct.ImageFileName)
+		if err != nil {
+			return err
+		}
+		if !attached {
+			return errImageNotAvailable
+		}
 
+		return ps.uploadRepository.ReleaseUpload(ctx, productEntity.ImageFileName, now)
+	})
+	if errors.Is(err, errImageNotAvailable) {
+		return nil, apperror.NotFound("IMAGE_NOT_FOUND", "image file not found")
+	}
 	if err != nil {
 		return nil, err
 	}
 
+	// the old image is only removed once the product no longer points at it; if this
+	// fails the upload reaper picks it up later
+	if imageChanged {
+		ps.deleteReleasedImage(ctx, productEntity.ImageFileName, now)
+	}
+
 	// send response
 	return &product.EditProductResponse{
 		Base: utils.SuccessResponse("Edit product successfully"),
@@ -169,8 +320,466 @@ func (ps *productService) EditProduct(ctx context.Context, request *product.Edit
 	}, nil
 }
 
-func NewProductService(productRepository repository.IProductRepository) IProductService {
+func (ps *productService) ListProducts(ctx context.Context, request *product.ListProductsRequest) (*product.ListProductsResponse, error) {
+	if request.MinPrice != nil && request.MaxPrice != nil && *request.MinPrice > *request.MaxPrice {
+		return nil, apperror.InvalidArgument("INVALID_PRICE_RANGE", "min price must not be greater than max price")
+	}
+
+	sortBy := repository.ProductSortByCreatedAt
+	switch request.SortBy {
+	case product.ProductSortBy_PRODUCT_SORT_BY_PRICE:
+		sortBy = repository.ProductSortByPrice
+	case product.ProductSortBy_PRODUCT_SORT_BY_NAME:
+		sortBy = repository.ProductSortByName
+	}
+
+	// newest first by default, otherwise ascending unless asked otherwise
+	sortDesc := request.SortDirection == product.SortDirection_SORT_DIRECTION_DESC
+	if request.SortDirection == product.SortDirection_SORT_DIRECTION_UNSPECIFIED {
+		sortDesc = sortBy == repository.ProductSortByCreatedAt
+	}
+
+	limit := int(request.Limit)
+	if limit == 0 {
+		limit = defaultListProductsLimit
+	}
+
+	params := repository.ListProductsParams{
+		Name:       request.Name,
+		CategoryId: request.CategoryId,
+		MinPrice:   request.MinPrice,
+		MaxPrice:   request.MaxPrice,
+		SortBy:     sortBy,
+		SortDesc:   sortDesc,
+		// fetch one extra row to know whether there is a next page
+		Limit: limit + 1,
+	}
+
+	if request.Cursor != "" {
+		afterValue, afterId, err := decodeProductCursor(request.Cursor, sortBy, sortDesc)
+		if err != nil {
+			return nil, apperror.InvalidArgument("INVALID_CURSOR", "invalid cursor")
+		}
+		params.AfterValue = afterValue
+		params.AfterId = afterId
+	}
+
+	products, err := ps.productRepository.ListProducts(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+
+	hasNext := len(products) > limit
+	if hasNext {
+		products = products[:limit]
+	}
+
+	items := make([]*product.ListProductsItem, 0, len(products))
+	for _, productEntity := range products {
+		items = append(items, &product.ListProductsItem{
+			Id:           productEntity.Id,
+			Name:         productEntity.Name,
+			Description:  productEntity.Description,
+			Price:        toMoneyProto(productEntity.Price),
+			ImageFileUrl: ps.blobStore.URL(storage.ProductImageKey(productEntity.ImageFileName)),
+		})
+	}
+
+	nextCursor := ""
+	if hasNext {
+		nextCursor = encodeProductCursor(sortBy, sortDesc, products[len(products)-1])
+	}
+
+	return &product.ListProductsResponse{
+		Base:       utils.SuccessResponse("Get product list successfully"),
+		Items:      items,
+		NextCursor: nextCursor,
+		HasNext:    hasNext,
+	}, nil
+}
+
+func (ps *productService) DeleteProduct(ctx context.Context, request *product.DeleteProductRequest) (*product.DeleteProductResponse, error) {
+	claims, err := jwtentity.GetClaimsFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	productEntity, err := ps.productRepository.GetProductById(ctx, request.Id)
+	if err != nil {
+		return nil, err
+	}
+	if productEntity == nil {
+		return nil, apperror.NotFound("PRODUCT_NOT_FOUND", "product not found")
+	}
+
+	// soft delete, the image is kept so the product can be restored
+	now := time.Now()
+	productEntity.DeletedAt = &now
+	productEntity.DeletedBy = &claims.FullName
+	productEntity.IsDeleted = true
+
+	err = ps.productRepository.DeleteProduct(ctx, productEntity)
+	if err != nil {
+		return nil, err
+	}
+
+	return &product.DeleteProductResponse{
+		Base: utils.SuccessResponse("Delete product successfully"),
+		Id:   request.Id,
+	}, nil
+}
+
+func (ps *productService) RestoreProduct(ctx context.Context, request *product.RestoreProductRequest) (*product.RestoreProductResponse, error) {
+	claims, err := jwtentity.GetClaimsFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	productEntity, err := ps.productRepository.GetDeletedProductById(ctx, request.Id)
+	if err != nil {
+		return nil, err
+	}
+	if productEntity == nil {
+		return nil, apperror.NotFound("PRODUCT_NOT_FOUND", "deleted product not found")
+	}
+
+	productEntity.UpdatedAt = time.Now()
+	productEntity.UpdatedBy = &claims.FullName
+	productEntity.DeletedAt = nil
+	productEntity.DeletedBy = nil
+	productEntity.IsDeleted = false
+
+	err = ps.productRepository.RestoreProduct(ctx, productEntity)
+	if err != nil {
+		return nil, err
+	}
+
+	return &product.RestoreProductResponse{
+		Base: utils.SuccessResponse("Restore product successfully"),
+		Id:   request.Id,
+	}, nil
+}
+
+func (ps *productService) ListDeletedProducts(ctx context.Context, request *product.ListDeletedProductsRequest) (*product.ListDeletedProductsResponse, error) {
+	limit := int(request.Limit)
+	if limit == 0 {
+		limit = defaultListProductsLimit
+	}
+
+	params := repository.ListProductsParams{
+		Deleted:  true,
+		Name:     request.Name,
+		SortBy:   repository.ProductSortByCreatedAt,
+		SortDesc: true,
+		Limit:    limit + 1,
+	}
+
+	if request.Cursor != "" {
+		afterValue, afterId, err := decodeProductCursor(request.Cursor, params.SortBy, params.SortDesc)
+		if err != nil {
+			return nil, apperror.InvalidArgument("INVALID_CURSOR", "invalid cursor")
+		}
+		params.AfterValue = afterValue
+		params.AfterId = afterId
+	}
+
+	products, err := ps.productRepository.ListProducts(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+
+	hasNext := len(products) > limit
+	if hasNext {
+		products = products[:limit]
+	}
+
+	items := make([]*product.ListDeletedProductsItem, 0, len(products))
+	for _, productEntity := range products {
+		item := &product.ListDeletedProductsItem{
+			Id:           productEntity.Id,
+			Name:         productEntity.Name,
+			Description:  productEntity.Description,
+			Price:        toMoneyProto(productEntity.Price),
+			ImageFileUrl: ps.blobStore.URL(storage.ProductImageKey(productEntity.ImageFileName)),
+		}
+		if productEntity.DeletedAt != nil {
+			item.DeletedAt = timestamppb.New(*productEntity.DeletedAt)
+		}
+		if productEntity.DeletedBy != nil {
+			item.DeletedBy = *productEntity.DeletedBy
+		}
+		items = append(items, item)
+	}
+
+	nextCursor := ""
+	if hasNext {
+		nextCursor = encodeProductCursor(params.SortBy, params.SortDesc, products[len(products)-1])
+	}
+
+	return &product.ListDeletedProductsResponse{
+		Base:       utils.SuccessResponse("Get deleted product list successfully"),
+		Items:      items,
+		NextCursor: nextCursor,
+		HasNext:    hasNext,
+	}, nil
+}
+
+func (ps *productService) SetProductVariants(ctx context.Context, request *product.SetProductVariantsRequest) (*product.SetProductVariantsResponse, error) {
+	claims, err := jwtentity.GetClaimsFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	productEntity, err := ps.productRepository.GetProductById(ctx, request.ProductId)
+	if err != nil {
+		return nil, err
+	}
+	if productEntity == nil {
+		return nil, apperror.NotFound("PRODUCT_NOT_FOUND", "product not found")
+	}
+
+	options, variants, failure := buildProductVariants(request, ps.currency)
+	if failure != "" {
+		return nil, apperror.InvalidArgument("INVALID_PRODUCT_VARIANTS", failure)
+	}
+
+	current, err := ps.variantRepository.ListProductVariants(ctx, productEntity.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	currentImages := make(map[string]bool)
+	for _, variant := range current {
+		if variant.ImageFileName != nil {
+			currentImages[*variant.ImageFileName] = true
+		}
+	}
+	newImages := make(map[string]bool)
+	for _, variant := range variants {
+		if variant.ImageFileName != nil {
+			newImages[*variant.ImageFileName] = true
+		}
+	}
+
+	addedImages := make([]string, 0)
+	for fileName := range newImages {
+		if currentImages[fileName] {
+			continue
+		}
+
+		imageExists, err := ps.blobStore.Exists(ctx, storage.ProductImageKey(fileName))
+		if err != nil {
+			return nil, err
+		}
+		if !imageExists {
+			return nil, apperror.NotFound("IMAGE_NOT_FOUND", "image file not found")
+		}
+		addedImages = append(addedImages, fileName)
+	}
+	removedImages := make([]string, 0)
+	for fileName := range currentImages {
+		if !newImages[fileName] {
+			removedImages = append(removedImages, fileName)
+		}
+	}
+
+	now := time.Now()
+	err = ps.transactionManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		err := ps.variantRepository.ReplaceProductVariants(ctx, productEntity.Id, options, variants, claims.FullName, now)
+		if err != nil {
+			return err
+		}
+
+		for _, fileName := range addedImages {
+			attached, err := ps.uploadRepository.AttachUpload(ctx, fileName)
+			if err != nil {
+				return err
+			}
+			if !attached {
+				return errImageNotAvailable
+			}
+		}
+
+		for _, fileName := range removedImages {
+			if err := ps.uploadRepository.ReleaseUpload(ctx, fileName, now); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+	if errors.Is(err, errImageNotAvailable) {
+		return nil, apperror.NotFound("IMAGE_NOT_FOUND", "image file not found")
+	}
+	var skuTakenErr *repository.SkuTakenError
+	if errors.As(err, &skuTakenErr) {
+		return nil, apperror.AlreadyExists("SKU_TAKEN", fmt.Sprintf("SKU %s is already used by another product", skuTakenErr.Sku)).WithMetadata("sku", skuTakenErr.Sku)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	for _, fileName := range removedImages {
+		ps.deleteReleasedImage(ctx, fileName, now)
+	}
+
+	return &product.SetProductVariantsResponse{
+		Base:      utils.SuccessResponse("Product variants updated successfully"),
+		ProductId: productEntity.Id,
+	}, nil
+}
+
+// buildProductVariants turns the request into options and variants, checking that every
+// variant picks exactly one value of each option and that no combination or SKU repeats.
+// Price overrides must be in currency. It returns a message describing the first problem found.
+func buildProductVariants(request *product.SetProductVariantsRequest, currency string) ([]*entity.ProductOption, []*entity.ProductVariant, string) {
+	if (len(request.Options) == 0) != (len(request.Variants) == 0) {
+		return nil, nil, "Options and variants must be set together"
+	}
+
+	options := make([]*entity.ProductOption, 0, len(request.Options))
+	optionNames := make(map[string]bool, len(request.Options))
+	for i, optionInput := range request.Options {
+		key := strings.ToLower(optionInput.Name)
+		if optionNames[key] {
+			return nil, nil, fmt.Sprintf("Option %s is listed twice", optionInput.Name)
+		}
+		optionNames[key] = true
+
+		option := &entity.ProductOption{
+			Id:       uuid.NewString(),
+			Name:     optionInput.Name,
+			Position: int32(i),
+		}
+		for j, value := range optionInput.Values {
+			option.Values = append(option.Values, &entity.ProductOptionValue{
+				Id:       uuid.NewString(),
+				OptionId: option.Id,
+				Value:    value,
+				Position: int32(j),
+			})
+		}
+		options = append(options, option)
+	}
+
+	variants := make([]*entity.ProductVariant, 0, len(request.Variants))
+	skus := make(map[string]bool, len(request.Variants))
+	combinations := make(map[string]string, len(request.Variants))
+	for i, variantInput := range request.Variants {
+		if skus[variantInput.Sku] {
+			return nil, nil, fmt.Sprintf("SKU %s is listed twice", variantInput.Sku)
+		}
+		skus[variantInput.Sku] = true
+
+		if len(variantInput.OptionValues) != len(options) {
+			return nil, nil, fmt.Sprintf("Variant %s must have exactly one value for each option", variantInput.Sku)
+		}
+
+		variant := &entity.ProductVariant{
+			Id:       uuid.NewString(),
+			Sku:      variantInput.Sku,
+			Position: int32(i),
+		}
+		if variantInput.Price != nil {
+			if variantInput.Price.Currency != currency {
+				return nil, nil, fmt.Sprintf("Price of variant %s must be in %s", variantInput.Sku, currency)
+			}
+			price := fromMoneyProto(variantInput.Price)
+			variant.Price = &price
+		}
+		if variantInput.ImageFileName != "" {
+			variant.ImageFileName = &variantInput.ImageFileName
+		}
+
+		for j, value := range variantInput.OptionValues {
+			var optionValue *entity.ProductOptionValue
+			for _, candidate := range options[j].Values {
+				if candidate.Value == value {
+					optionValue = candidate
+				}
+			}
+			if optionValue == nil {
+				return nil, nil, fmt.Sprintf("%s is not a value of option %s", value, options[j].Name)
+			}
+			variant.OptionValues = append(variant.OptionValues, optionValue)
+		}
+
+		combination := strings.Join(variantInput.OptionValues, "\x00")
+		if other, ok := combinations[combination]; ok {
+			return nil, nil, fmt.Sprintf("Variants %s and %s have the same option values", other, variantInput.Sku)
+		}
+		combinations[combination] = variantInput.Sku
+
+		variants = append(variants, variant)
+	}
+
+	return options, variants, ""
+}
+
+// imagesOf returns the URLs of every configured variant of an uploaded image. Images older
+// than the imaging pipeline get their variants from cmd/backfill-images.
+func (ps *productService) imagesOf(fileName string) []*product.ImageVariant {
+	images := make([]*product.ImageVariant, 0, len(ps.imageVariants))
+	for _, variant := range ps.imageVariants {
+		images = append(images, &product.ImageVariant{
+			Name: variant,
+			Url:  ps.blobStore.URL(storage.ProductImageVariantKey(fileName, variant)),
+		})
+	}
+
+	return images
+}
+
+// categoriesExist reports whether every category id refers to an existing category.
+func (ps *productService) categoriesExist(ctx context.Context, categoryIds []string) (bool, error) {
+	if len(categoryIds) == 0 {
+		return true, nil
+	}
+
+	count, err := ps.categoryRepository.CountCategoriesByIds(ctx, categoryIds)
+	if err != nil {
+		return false, err
+	}
+
+	return count == len(categoryIds), nil
+}
+
+func (ps *productService) deleteReleasedImage(ctx context.Context, fileName string, now time.Time) {
+	claimed, err := ps.uploadRepository.ClaimUploadForDeletion(ctx, fileName, now)
+	if err != nil {
+		log.Printf("claim released image %s: %v", fileName, err)
+		return
+	}
+	// still referenced by another product
+	if !claimed {
+		return
+	}
+
+	if err = storage.DeleteProductImage(ctx, ps.blobStore, fileName, ps.imageVariants); err != nil {
+		log.Printf("delete released image %s: %v", fileName, err)
+	}
+}
+
+func NewProductService(
+	productRepository repository.IProductRepository,
+	categoryRepository repository.ICategoryRepository,
+	variantRepository repository.IProductVariantRepository,
+	inventoryRepository repository.IInventoryRepository,
+	uploadRepository repository.IUploadRepository,
+	transactionManager repository.ITransactionManager,
+	blobStore storage.BlobStore,
+	imageVariants []string,
+	currency string,
+) IProductService {
 	return &productService{
-		productRepository: productRepository,
+		productRepository:   productRepository,
+		categoryRepository:  categoryRepository,
+		variantRepository:   variantRepository,
+		inventoryRepository: inventoryRepository,
+		uploadRepository:    uploadRepository,
+		transactionManager:  transactionManager,
+		blobStore:           blobStore,
+		imageVariants:       imageVariants,
+		currency:            currency,
 	}
 }
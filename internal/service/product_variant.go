@@ -0,0 +1,58 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/aldngrha/ecommerce-be/internal/apperror"
+	"github.com/aldngrha/ecommerce-be/internal/entity"
+	"github.com/aldngrha/ecommerce-be/internal/repository"
+)
+
+// resolveVariant checks the variant chosen for a line of productEntity and returns it.
+// Products sold in variants need one of their live variants, other products must not get
+// one. An invalid choice is reported as an *apperror.Error.
+func resolveVariant(ctx context.Context, variantRepository repository.IProductVariantRepository, productEntity *entity.Product, variantId string) (*entity.ProductVariant, error) {
+	if variantId == "" {
+		hasVariants, err := variantRepository.HasProductVariants(ctx, productEntity.Id)
+		if err != nil {
+			return nil, err
+		}
+		if hasVariants {
+			return nil, apperror.InvalidArgument(
+				"VARIANT_REQUIRED",
+				fmt.Sprintf("choose a variant of %s", productEntity.Name),
+				apperror.FieldViolation{Field: "variant_id", Description: "required for products sold in variants"},
+			).WithMetadata("product_id", productEntity.Id)
+		}
+
+		return nil, nil
+	}
+
+	variant, err := variantRepository.GetProductVariantById(ctx, variantId)
+	if err != nil {
+		return nil, err
+	}
+	if variant == nil || variant.ProductId != productEntity.Id {
+		return nil, apperror.NotFound("VARIANT_NOT_FOUND", "variant not found").WithMetadata("variant_id", variantId)
+	}
+
+	return variant, nil
+}
+
+// optionalId maps an unset proto id to nil.
+func optionalId(id string) *string {
+	if id == "" {
+		return nil
+	}
+
+	return &id
+}
+
+func stringValue(value *string) string {
+	if value == nil {
+		return ""
+	}
+
+	return *value
+}
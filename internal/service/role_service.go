@@ -0,0 +1,255 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/aldngrha/ecommerce-be/internal/apperror"
+	"github.com/aldngrha/ecommerce-be/internal/entity"
+	jwtentity "github.com/aldngrha/ecommerce-be/internal/entity/jwt"
+	"github.com/aldngrha/ecommerce-be/internal/repository"
+	"github.com/aldngrha/ecommerce-be/internal/utils"
+	"github.com/aldngrha/ecommerce-be/pb/role"
+	"github.com/google/uuid"
+)
+
+type IRoleService interface {
+	CreateRole(ctx context.Context, req *role.CreateRoleRequest) (*role.CreateRoleResponse, error)
+	ListRoles(ctx context.Context, req *role.ListRolesRequest) (*role.ListRolesResponse, error)
+	SetRolePermissions(ctx context.Context, req *role.SetRolePermissionsRequest) (*role.SetRolePermissionsResponse, error)
+	AssignRole(ctx context.Context, req *role.AssignRoleRequest) (*role.AssignRoleResponse, error)
+	ListPermissions(ctx context.Context, req *role.ListPermissionsRequest) (*role.ListPermissionsResponse, error)
+}
+
+type roleService struct {
+	roleRepository     repository.IRoleRepository
+	authRepository     repository.IAuthRepository
+	permissionResolver IPermissionResolver
+	transactionManager repository.ITransactionManager
+}
+
+// ensureRoleManagerLeft fails when no active user could manage roles anymore, which would
+// leave nobody able to undo the change. Call it inside the transaction after the change.
+func (rs *roleService) ensureRoleManagerLeft(ctx context.Context) error {
+	count, err := rs.roleRepository.CountUsersWithPermission(ctx, entity.PermissionRoleManage)
+	if err != nil {
+		return err
+	}
+	if count == 0 {
+		return apperror.FailedPrecondition("LAST_ROLE_MANAGER", "at least one user must keep the role:manage permission")
+	}
+
+	return nil
+}
+
+// unknownPermission returns the first requested permission that is not defined, or "".
+func (rs *roleService) unknownPermission(ctx context.Context, requested []string) (string, error) {
+	known, err := rs.roleRepository.ListPermissions(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	knownCodes := make(map[string]bool, len(known))
+	for _, permission := range known {
+		knownCodes[permission.Code] = true
+	}
+
+	for _, permission := range requested {
+		if !knownCodes[permission] {
+			return permission, nil
+		}
+	}
+
+	return "", nil
+}
+
+func (rs *roleService) CreateRole(ctx context.Context, req *role.CreateRoleRequest) (*role.CreateRoleResponse, error) {
+	claims, err := jwtentity.GetClaimsFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	existing, err := rs.roleRepository.GetRoleByCode(ctx, req.Code)
+	if err != nil {
+		return nil, err
+	}
+	if existing != nil {
+		return nil, apperror.AlreadyExists("ROLE_ALREADY_EXISTS", "role with this code already exists")
+	}
+
+	unknown, err := rs.unknownPermission(ctx, req.Permissions)
+	if err != nil {
+		return nil, err
+	}
+	if unknown != "" {
+		return nil, apperror.InvalidArgument("UNKNOWN_PERMISSION", fmt.Sprintf("unknown permission %s", unknown)).WithMetadata("permission", unknown)
+	}
+
+	roleEntity := entity.Role{
+		Id:          uuid.NewString(),
+		Name:        req.Name,
+		Code:        req.Code,
+		Permissions: req.Permissions,
+		CreatedAt:   time.Now(),
+		CreatedBy:   &claims.FullName,
+	}
+
+	err = rs.roleRepository.InsertRole(ctx, &roleEntity)
+	if err != nil {
+		return nil, err
+	}
+
+	rs.permissionResolver.Invalidate(roleEntity.Code)
+
+	return &role.CreateRoleResponse{
+		Base: utils.SuccessResponse("Role created successfully"),
+		Code: roleEntity.Code,
+	}, nil
+}
+
+func (rs *roleService) ListRoles(ctx context.Context, req *role.ListRolesRequest) (*role.ListRolesResponse, error) {
+	roles, err := rs.roleRepository.ListRoles(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]*role.Role, 0, len(roles))
+	for _, roleEntity := range roles {
+		items = append(items, &role.Role{
+			Code:        roleEntity.Code,
+			Name:        roleEntity.Name,
+			Permissions: roleEntity.Permissions,
+		})
+	}
+
+	return &role.ListRolesResponse{
+		Base:  utils.SuccessResponse("Get role list successfully"),
+		Roles: items,
+	}, nil
+}
+
+func (rs *roleService) SetRolePermissions(ctx context.Context, req *role.SetRolePermissionsRequest) (*role.SetRolePermissionsResponse, error) {
+	claims, err := jwtentity.GetClaimsFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	roleEntity, err := rs.roleRepository.GetRoleByCode(ctx, req.RoleCode)
+	if err != nil {
+		return nil, err
+	}
+	if roleEntity == nil {
+		return nil, apperror.NotFound("ROLE_NOT_FOUND", "role not found")
+	}
+
+	unknown, err := rs.unknownPermission(ctx, req.Permissions)
+	if err != nil {
+		return nil, err
+	}
+	if unknown != "" {
+		return nil, apperror.InvalidArgument("UNKNOWN_PERMISSION", fmt.Sprintf("unknown permission %s", unknown)).WithMetadata("permission", unknown)
+	}
+
+	// nobody could manage roles anymore if the caller removed role:manage from their own role
+	if roleEntity.Code == claims.Role && !slices.Contains(req.Permissions, entity.PermissionRoleManage) {
+		return nil, apperror.FailedPrecondition("OWN_ROLE_MANAGE_REQUIRED", "you cannot remove role:manage from your own role")
+	}
+
+	err = rs.transactionManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := rs.roleRepository.LockRoleAssignments(ctx); err != nil {
+			return err
+		}
+
+		err := rs.roleRepository.SetRolePermissions(ctx, roleEntity.Code, req.Permissions, claims.FullName)
+		if err != nil {
+			return err
+		}
+
+		return rs.ensureRoleManagerLeft(ctx)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	rs.permissionResolver.Invalidate(roleEntity.Code)
+
+	return &role.SetRolePermissionsResponse{
+		Base: utils.SuccessResponse("Role permissions updated successfully"),
+	}, nil
+}
+
+// AssignRole changes the user's role. Access tokens carry the role, so the change
+// applies from the user's next login or token refresh.
+func (rs *roleService) AssignRole(ctx context.Context, req *role.AssignRoleRequest) (*role.AssignRoleResponse, error) {
+	claims, err := jwtentity.GetClaimsFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	roleEntity, err := rs.roleRepository.GetRoleByCode(ctx, req.RoleCode)
+	if err != nil {
+		return nil, err
+	}
+	if roleEntity == nil {
+		return nil, apperror.NotFound("ROLE_NOT_FOUND", "role not found")
+	}
+
+	user, err := rs.authRepository.GetUserById(ctx, req.UserId)
+	if err != nil {
+		return nil, err
+	}
+	if user == nil {
+		return nil, apperror.NotFound("USER_NOT_FOUND", "user not found")
+	}
+
+	err = rs.transactionManager.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := rs.roleRepository.LockRoleAssignments(ctx); err != nil {
+			return err
+		}
+
+		err := rs.authRepository.UpdateUserRole(ctx, user.Id, roleEntity.Code, claims.FullName)
+		if err != nil {
+			return err
+		}
+
+		return rs.ensureRoleManagerLeft(ctx)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &role.AssignRoleResponse{
+		Base: utils.SuccessResponse("Role assigned successfully"),
+	}, nil
+}
+
+func (rs *roleService) ListPermissions(ctx context.Context, req *role.ListPermissionsRequest) (*role.ListPermissionsResponse, error) {
+	permissions, err := rs.roleRepository.ListPermissions(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]*role.Permission, 0, len(permissions))
+	for _, permission := range permissions {
+		items = append(items, &role.Permission{
+			Code:        permission.Code,
+			Description: permission.Description,
+		})
+	}
+
+	return &role.ListPermissionsResponse{
+		Base:        utils.SuccessResponse("Get permission list successfully"),
+		Permissions: items,
+	}, nil
+}
+
+func NewRoleService(roleRepository repository.IRoleRepository, authRepository repository.IAuthRepository, permissionResolver IPermissionResolver, transactionManager repository.ITransactionManager) IRoleService {
+	return &roleService{
+		roleRepository:     roleRepository,
+		authRepository:     authRepository,
+		permissionResolver: permissionResolver,
+		transactionManager: transactionManager,
+	}
+}
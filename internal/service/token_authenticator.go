@@ -0,0 +1,60 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/aldngrha/ecommerce-be/internal/apperror"
+	jwtentity "github.com/aldngrha/ecommerce-be/internal/entity/jwt"
+	"github.com/aldngrha/ecommerce-be/internal/repository"
+)
+
+// ITokenAuthenticator verifies access tokens the same way for the gRPC and REST servers.
+type ITokenAuthenticator interface {
+	// Authenticate checks signature, expiry and revocation and fills in the permissions of
+	// the token's role.
+	Authenticate(ctx context.Context, token string) (*jwtentity.JwtClaims, error)
+}
+
+type tokenAuthenticator struct {
+	revocationStore    repository.TokenRevocationStore
+	permissionResolver IPermissionResolver
+}
+
+func (ta *tokenAuthenticator) Authenticate(ctx context.Context, token string) (*jwtentity.JwtClaims, error) {
+	claims, err := jwtentity.GetClaimsFromToken(token)
+	if err != nil {
+		return nil, err
+	}
+
+	if claims.ID == "" {
+		return nil, apperror.Unauthenticated("INVALID_TOKEN", "token has no id, please login again")
+	}
+
+	var issuedAt time.Time
+	if claims.IssuedAt != nil {
+		issuedAt = claims.IssuedAt.Time
+	}
+
+	revoked, err := ta.revocationStore.IsRevoked(ctx, claims.ID, claims.Subject, issuedAt)
+	if err != nil {
+		return nil, err
+	}
+	if revoked {
+		return nil, apperror.Unauthenticated("TOKEN_REVOKED", "token has been revoked, please login again")
+	}
+
+	claims.Permissions, err = ta.permissionResolver.PermissionsForRole(ctx, claims.Role)
+	if err != nil {
+		return nil, err
+	}
+
+	return claims, nil
+}
+
+func NewTokenAuthenticator(revocationStore repository.TokenRevocationStore, permissionResolver IPermissionResolver) ITokenAuthenticator {
+	return &tokenAuthenticator{
+		revocationStore:    revocationStore,
+		permissionResolver: permissionResolver,
+	}
+}
@@ -0,0 +1,132 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/aldngrha/ecommerce-be/internal/apperror"
+	jwtentity "github.com/aldngrha/ecommerce-be/internal/entity/jwt"
+	"github.com/aldngrha/ecommerce-be/internal/repository"
+	"github.com/golang-jwt/jwt/v5"
+	"google.golang.org/grpc/codes"
+)
+
+type staticPermissionResolver map[string][]string
+
+func (r staticPermissionResolver) PermissionsForRole(ctx context.Context, roleCode string) ([]string, error) {
+	return r[roleCode], nil
+}
+
+func (r staticPermissionResolver) Invalidate(roleCode string) {}
+
+func signTestToken(t *testing.T, jti string, userId string, issuedAt time.Time) string {
+	t.Helper()
+
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, jwtentity.JwtClaims{
+		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        jti,
+			Subject:   userId,
+			IssuedAt:  jwt.NewNumericDate(issuedAt),
+			ExpiresAt: jwt.NewNumericDate(issuedAt.Add(accessTokenTTL)),
+		},
+		Role: "admin",
+	}).SignedString([]byte("test-secret"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return token
+}
+
+func assertUnauthenticated(t *testing.T, err error, reason string) {
+	t.Helper()
+
+	var appErr *apperror.Error
+	if !errors.As(err, &appErr) || appErr.Code != codes.Unauthenticated || appErr.Reason != reason {
+		t.Fatalf("Authenticate() error = %v, want Unauthenticated %s", err, reason)
+	}
+}
+
+func TestTokenAuthenticator(t *testing.T) {
+	t.Setenv("JWT_SECRET", "test-secret")
+
+	ctx := context.Background()
+	now := time.Now()
+	store := repository.NewInMemoryTokenRevocationStore()
+	authenticator := NewTokenAuthenticator(store, staticPermissionResolver{"admin": {"role:manage"}})
+
+	t.Run("accepts a valid token and resolves permissions", func(t *testing.T) {
+		claims, err := authenticator.Authenticate(ctx, signTestToken(t, "jti-valid", "user-1", now))
+		if err != nil {
+			t.Fatalf("Authenticate() returned error %v", err)
+		}
+		if claims.Subject != "user-1" || !claims.HasPermission("role:manage") {
+			t.Errorf("Authenticate() = %+v, want user-1 with role:manage", claims)
+		}
+	})
+
+	t.Run("rejects a token without id", func(t *testing.T) {
+		_, err := authenticator.Authenticate(ctx, signTestToken(t, "", "user-1", now))
+		assertUnauthenticated(t, err, "INVALID_TOKEN")
+	})
+
+	t.Run("rejects a token revoked by id", func(t *testing.T) {
+		token := signTestToken(t, "jti-logout", "user-2", now)
+		if err := store.Revoke(ctx, "jti-logout", now.Add(accessTokenTTL)); err != nil {
+			t.Fatal(err)
+		}
+
+		_, err := authenticator.Authenticate(ctx, token)
+		assertUnauthenticated(t, err, "TOKEN_REVOKED")
+
+		// other tokens of the same user stay valid
+		if _, err = authenticator.Authenticate(ctx, signTestToken(t, "jti-other", "user-2", now)); err != nil {
+			t.Fatalf("Authenticate() of another token returned error %v", err)
+		}
+	})
+
+	t.Run("rejects tokens of a user issued before the revocation", func(t *testing.T) {
+		before := signTestToken(t, "jti-before", "user-3", now.Add(-time.Minute))
+		after := signTestToken(t, "jti-after", "user-3", now.Add(time.Minute))
+		if err := store.RevokeUserTokens(ctx, "user-3", now, now.Add(accessTokenTTL)); err != nil {
+			t.Fatal(err)
+		}
+
+		_, err := authenticator.Authenticate(ctx, before)
+		assertUnauthenticated(t, err, "TOKEN_REVOKED")
+
+		if _, err = authenticator.Authenticate(ctx, after); err != nil {
+			t.Fatalf("Authenticate() of a token issued after the revocation returned error %v", err)
+		}
+		if _, err = authenticator.Authenticate(ctx, signTestToken(t, "jti-user-1", "user-1", now.Add(-time.Minute))); err != nil {
+			t.Fatalf("Authenticate() of another user's token returned error %v", err)
+		}
+	})
+
+	t.Run("accepts a token issued in the same second as the revocation", func(t *testing.T) {
+		// a password reset revokes from the start of its second, as iat has whole seconds
+		resetAt := now.Truncate(time.Second).Add(500 * time.Millisecond)
+		if err := store.RevokeUserTokens(ctx, "user-4", resetAt.Truncate(time.Second), resetAt.Add(accessTokenTTL)); err != nil {
+			t.Fatal(err)
+		}
+
+		_, err := authenticator.Authenticate(ctx, signTestToken(t, "jti-previous-second", "user-4", resetAt.Add(-time.Second)))
+		assertUnauthenticated(t, err, "TOKEN_REVOKED")
+
+		if _, err = authenticator.Authenticate(ctx, signTestToken(t, "jti-same-second", "user-4", resetAt.Add(100*time.Millisecond))); err != nil {
+			t.Fatalf("Authenticate() of a token issued right after the reset returned error %v", err)
+		}
+	})
+
+	t.Run("forgets revocations once the tokens expired", func(t *testing.T) {
+		deleted, err := store.DeleteExpired(ctx, now.Add(accessTokenTTL+2*time.Second))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if deleted != 3 {
+			t.Errorf("DeleteExpired() = %d, want 3", deleted)
+		}
+	})
+}
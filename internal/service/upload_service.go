@@ -0,0 +1,136 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/aldngrha/ecommerce-be/internal/apperror"
+	"github.com/aldngrha/ecommerce-be/internal/entity"
+	jwtentity "github.com/aldngrha/ecommerce-be/internal/entity/jwt"
+	"github.com/aldngrha/ecommerce-be/internal/imaging"
+	"github.com/aldngrha/ecommerce-be/internal/repository"
+	"github.com/aldngrha/ecommerce-be/internal/storage"
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+)
+
+// uploadQuotaWindow is the rolling window the per-user upload quota applies to.
+const uploadQuotaWindow = 24 * time.Hour
+
+// allowedImageTypes are matched against the type sniffed from the file content; the file
+// name and the Content-Type sent by the client are not trusted.
+var allowedImageTypes = map[string]bool{
+	"image/jpeg": true,
+	"image/png":  true,
+	"image/webp": true,
+}
+
+type UploadLimits struct {
+	MaxBytes        int64
+	DailyCountQuota int
+	DailyBytesQuota int64
+}
+
+type IUploadService interface {
+	// UploadProductImage stores the image and its variants and returns the file name to
+	// reference from CreateProduct and EditProduct.
+	UploadProductImage(ctx context.Context, content []byte) (string, error)
+}
+
+type uploadService struct {
+	uploadRepository repository.IUploadRepository
+	blobStore        storage.BlobStore
+	pipeline         *imaging.Pipeline
+	limits           UploadLimits
+}
+
+func (us *uploadService) UploadProductImage(ctx context.Context, content []byte) (string, error) {
+	claims, err := jwtentity.GetClaimsFromContext(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	if int64(len(content)) > us.limits.MaxBytes {
+		return "", apperror.InvalidArgument("FILE_TOO_LARGE", fmt.Sprintf("file is larger than %d bytes", us.limits.MaxBytes))
+	}
+
+	contentType := http.DetectContentType(content)
+	if !allowedImageTypes[contentType] {
+		return "", apperror.InvalidArgument("UNSUPPORTED_IMAGE_TYPE", "only jpeg, png and webp images are allowed").
+			WithMetadata("detected_type", contentType)
+	}
+
+	now := time.Now()
+	quota := repository.UploadQuota{
+		Since:    now.Add(-uploadQuotaWindow),
+		MaxCount: us.limits.DailyCountQuota,
+		MaxBytes: us.limits.DailyBytesQuota,
+	}
+
+	// decoding and resizing is the expensive part, so a user over quota is turned away
+	// before it; the insert below repeats the check under a lock
+	withinQuota, err := us.uploadRepository.HasUploadQuota(ctx, claims.Subject, int64(len(content)), quota)
+	if err != nil {
+		return "", err
+	}
+	if !withinQuota {
+		return "", uploadQuotaExceeded()
+	}
+
+	images, err := us.pipeline.Process(bytes.NewReader(content))
+	if err != nil {
+		var dimensionsErr *imaging.DimensionsError
+		if errors.Is(err, imaging.ErrUnsupportedFormat) {
+			return "", apperror.InvalidArgument("UNSUPPORTED_IMAGE_TYPE", err.Error())
+		}
+		if errors.As(err, &dimensionsErr) {
+			return "", apperror.InvalidArgument("IMAGE_TOO_LARGE", err.Error())
+		}
+		return "", err
+	}
+
+	// the extension follows the re-encoded format, not the uploaded file name
+	upload := entity.Upload{
+		Id:          uuid.NewString(),
+		UserId:      claims.Subject,
+		FileName:    fmt.Sprintf("product_%d%s", now.UnixNano(), images[0].Extension),
+		ContentType: images[0].ContentType,
+		SizeBytes:   int64(len(content)),
+		CreatedAt:   now,
+	}
+
+	withinQuota, err = us.uploadRepository.InsertUploadWithinQuota(ctx, &upload, quota)
+	if err != nil {
+		return "", err
+	}
+	if !withinQuota {
+		return "", uploadQuotaExceeded()
+	}
+
+	for _, image := range images {
+		err = us.blobStore.Put(ctx, storage.ProductImageVariantKey(upload.FileName, image.Variant), bytes.NewReader(image.Content), image.ContentType)
+		if err != nil {
+			// the row stays so the upload reaper removes the variants already written
+			return "", errors.Join(err, us.uploadRepository.AbandonUpload(ctx, upload.Id, time.Now()))
+		}
+	}
+
+	return upload.FileName, nil
+}
+
+func uploadQuotaExceeded() error {
+	return apperror.New(codes.ResourceExhausted, "UPLOAD_QUOTA_EXCEEDED", "upload quota for the last 24 hours is used up")
+}
+
+func NewUploadService(uploadRepository repository.IUploadRepository, blobStore storage.BlobStore, pipeline *imaging.Pipeline, limits UploadLimits) IUploadService {
+	return &uploadService{
+		uploadRepository: uploadRepository,
+		blobStore:        blobStore,
+		pipeline:         pipeline,
+		limits:           limits,
+	}
+}
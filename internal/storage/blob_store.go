@@ -0,0 +1,50 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"io"
+	"path"
+	"strings"
+)
+
+var ErrNotFound = errors.New("blob not found")
+
+// BlobStore keeps uploaded files such as product images. Keys are slash separated paths
+// like "images/products/product_1.jpg" and are the same on every backend.
+type BlobStore interface {
+	Put(ctx context.Context, key string, content io.Reader, contentType string) error
+	// Get returns ErrNotFound when the key does not exist. The caller closes the reader.
+	Get(ctx context.Context, key string) (io.ReadCloser, error)
+	Exists(ctx context.Context, key string) (bool, error)
+	// Delete succeeds when the key does not exist.
+	Delete(ctx context.Context, key string) error
+	// URL is the public address clients download the blob from.
+	URL(key string) string
+}
+
+func ProductImageKey(fileName string) string {
+	return path.Join("images", "products", fileName)
+}
+
+// ProductImageVariantKey is where a resized variant of a product image is stored, next to
+// the original: "product_1.jpg" becomes "product_1_thumbnail.jpg".
+func ProductImageVariantKey(fileName string, variant string) string {
+	if variant == "" || variant == "original" {
+		return ProductImageKey(fileName)
+	}
+
+	extension := path.Ext(fileName)
+	return ProductImageKey(strings.TrimSuffix(fileName, extension) + "_" + variant + extension)
+}
+
+// DeleteProductImage removes a product image together with its variants.
+func DeleteProductImage(ctx context.Context, blobStore BlobStore, fileName string, variants []string) error {
+	for _, variant := range variants {
+		if err := blobStore.Delete(ctx, ProductImageVariantKey(fileName, variant)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,27 @@
+package storage
+
+import "os"
+
+// NewBlobStoreFromEnv picks the backend from STORAGE_DRIVER ("local" or "s3"). Public URLs
+// start with STORAGE_SERVICE_URL in both cases; for S3 it may be left empty to link the
+// bucket directly.
+func NewBlobStoreFromEnv() (BlobStore, error) {
+	if os.Getenv("STORAGE_DRIVER") == "s3" {
+		return NewS3BlobStore(S3Config{
+			Endpoint:        os.Getenv("S3_ENDPOINT"),
+			Region:          os.Getenv("S3_REGION"),
+			Bucket:          os.Getenv("S3_BUCKET"),
+			AccessKeyId:     os.Getenv("S3_ACCESS_KEY_ID"),
+			SecretAccessKey: os.Getenv("S3_SECRET_ACCESS_KEY"),
+			PathStyle:       os.Getenv("S3_FORCE_PATH_STYLE") == "true",
+			PublicBaseUrl:   os.Getenv("STORAGE_SERVICE_URL"),
+		})
+	}
+
+	root := os.Getenv("STORAGE_LOCAL_DIR")
+	if root == "" {
+		root = "storage"
+	}
+
+	return NewLocalBlobStore(root, os.Getenv("STORAGE_SERVICE_URL")), nil
+}
@@ -9,22 +9,6 @@ func SuccessResponse(message string) *common.BaseResponse {
 	}
 }
 
-func BadRequestResponse(message string) *common.BaseResponse {
-	return &common.BaseResponse{
-		StatusCode: 400,
-		Message:    message,
-		IsError:    true,
-	}
-}
-
-func NotFoundResponse(message string) *common.BaseResponse {
-	return &common.BaseResponse{
-		StatusCode: 404,
-		Message:    message,
-		IsError:    true,
-	}
-}
-
 func ValidationErrorResponse(validationErrors []*common.ValidationError) *common.BaseResponse {
 	return &common.BaseResponse{
 		StatusCode:       400,
@@ -0,0 +1,170 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        v5.29.3
+// source: auth/policy.proto
+
+package auth
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	descriptorpb "google.golang.org/protobuf/types/descriptorpb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// Policy declares who may call an RPC. It is enforced centrally by the gRPC auth
+// interceptor, so services no longer need to check roles themselves.
+//
+// RPCs without a policy require a valid access token and allow any role.
+type Policy struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// public RPCs skip authentication entirely
+	Public bool `protobuf:"varint,1,opt,name=public,proto3" json:"public,omitempty"`
+	// when non-empty, the caller's role must be one of these codes
+	Roles []string `protobuf:"bytes,2,rep,name=roles,proto3" json:"roles,omitempty"`
+	// when non-empty, the caller's role must grant every one of these permissions
+	Permissions   []string `protobuf:"bytes,3,rep,name=permissions,proto3" json:"permissions,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Policy) Reset() {
+	*x = Policy{}
+	mi := &file_auth_policy_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Policy) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Policy) ProtoMessage() {}
+
+func (x *Policy) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_policy_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Policy.ProtoReflect.Descriptor instead.
+func (*Policy) Descriptor() ([]byte, []int) {
+	return file_auth_policy_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Policy) GetPublic() bool {
+	if x != nil {
+		return x.Public
+	}
+	return false
+}
+
+func (x *Policy) GetRoles() []string {
+	if x != nil {
+		return x.Roles
+	}
+	return nil
+}
+
+func (x *Policy) GetPermissions() []string {
+	if x != nil {
+		return x.Permissions
+	}
+	return nil
+}
+
+var file_auth_policy_proto_extTypes = []protoimpl.ExtensionInfo{
+	{
+		ExtendedType:  (*descriptorpb.MethodOptions)(nil),
+		ExtensionType: (*Policy)(nil),
+		Field:         50001,
+		Name:          "auth.policy",
+		Tag:           "bytes,50001,opt,name=policy",
+		Filename:      "auth/policy.proto",
+	},
+}
+
+// Extension fields to descriptorpb.MethodOptions.
+var (
+	// optional auth.Policy policy = 50001;
+	E_Policy = &file_auth_policy_proto_extTypes[0]
+)
+
+var File_auth_policy_proto protoreflect.FileDescriptor
+
+const file_auth_policy_proto_rawDesc = "" +
+	"\n" +
+	"\x11auth/policy.proto\x12\x04auth\x1a google/protobuf/descriptor.proto\"X\n" +
+	"\x06Policy\x12\x16\n" +
+	"\x06public\x18\x01 \x01(\bR\x06public\x12\x14\n" +
+	"\x05roles\x18\x02 \x03(\tR\x05roles\x12 \n" +
+	"\vpermissions\x18\x03 \x03(\tR\vpermissions:F\n" +
+	"\x06policy\x12\x1e.google.protobuf.MethodOptions\x18ц\x03 \x01(\v2\f.auth.PolicyR\x06policyB*Z(github.com/aldngrha/ecommerce-be/pb/authb\x06proto3"
+
+var (
+	file_auth_policy_proto_rawDescOnce sync.Once
+	file_auth_policy_proto_rawDescData []byte
+)
+
+func file_auth_policy_proto_rawDescGZIP() []byte {
+	file_auth_policy_proto_rawDescOnce.Do(func() {
+		file_auth_policy_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_auth_policy_proto_rawDesc), len(file_auth_policy_proto_rawDesc)))
+	})
+	return file_auth_policy_proto_rawDescData
+}
+
+var file_auth_policy_proto_msgTypes = make([]protoimpl.MessageInfo, 1)
+var file_auth_policy_proto_goTypes = []any{
+	(*Policy)(nil),                     // 0: auth.Policy
+	(*descriptorpb.MethodOptions)(nil), // 1: google.protobuf.MethodOptions
+}
+var file_auth_policy_proto_depIdxs = []int32{
+	1, // 0: auth.policy:extendee -> google.protobuf.MethodOptions
+	0, // 1: auth.policy:type_name -> auth.Policy
+	2, // [2:2] is the sub-list for method output_type
+	2, // [2:2] is the sub-list for method input_type
+	1, // [1:2] is the sub-list for extension type_name
+	0, // [0:1] is the sub-list for extension extendee
+	0, // [0:0] is the sub-list for field type_name
+}
+
+func init() { file_auth_policy_proto_init() }
+func file_auth_policy_proto_init() {
+	if File_auth_policy_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_auth_policy_proto_rawDesc), len(file_auth_policy_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   1,
+			NumExtensions: 1,
+			NumServices:   0,
+		},
+		GoTypes:           file_auth_policy_proto_goTypes,
+		DependencyIndexes: file_auth_policy_proto_depIdxs,
+		MessageInfos:      file_auth_policy_proto_msgTypes,
+		ExtensionInfos:    file_auth_policy_proto_extTypes,
+	}.Build()
+	File_auth_policy_proto = out.File
+	file_auth_policy_proto_goTypes = nil
+	file_auth_policy_proto_depIdxs = nil
+}
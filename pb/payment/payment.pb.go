@@ -0,0 +1,554 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        v5.29.3
+// source: payment/payment.proto
+
+package payment
+
+import (
+	_ "buf.build/gen/go/bufbuild/protovalidate/protocolbuffers/go/buf/validate"
+	_ "github.com/aldngrha/ecommerce-be/pb/auth"
+	common "github.com/aldngrha/ecommerce-be/pb/common"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type PaymentIntentStatus int32
+
+const (
+	PaymentIntentStatus_PAYMENT_INTENT_STATUS_UNSPECIFIED PaymentIntentStatus = 0
+	PaymentIntentStatus_PAYMENT_INTENT_STATUS_PENDING     PaymentIntentStatus = 1
+	PaymentIntentStatus_PAYMENT_INTENT_STATUS_AUTHORIZED  PaymentIntentStatus = 2
+	PaymentIntentStatus_PAYMENT_INTENT_STATUS_CAPTURED    PaymentIntentStatus = 3
+	PaymentIntentStatus_PAYMENT_INTENT_STATUS_FAILED      PaymentIntentStatus = 4
+	PaymentIntentStatus_PAYMENT_INTENT_STATUS_REFUNDED    PaymentIntentStatus = 5
+	// the refund was requested from the provider but is not confirmed yet
+	PaymentIntentStatus_PAYMENT_INTENT_STATUS_REFUND_PENDING PaymentIntentStatus = 6
+)
+
+// Enum value maps for PaymentIntentStatus.
+var (
+	PaymentIntentStatus_name = map[int32]string{
+		0: "PAYMENT_INTENT_STATUS_UNSPECIFIED",
+		1: "PAYMENT_INTENT_STATUS_PENDING",
+		2: "PAYMENT_INTENT_STATUS_AUTHORIZED",
+		3: "PAYMENT_INTENT_STATUS_CAPTURED",
+		4: "PAYMENT_INTENT_STATUS_FAILED",
+		5: "PAYMENT_INTENT_STATUS_REFUNDED",
+		6: "PAYMENT_INTENT_STATUS_REFUND_PENDING",
+	}
+	PaymentIntentStatus_value = map[string]int32{
+		"PAYMENT_INTENT_STATUS_UNSPECIFIED":    0,
+		"PAYMENT_INTENT_STATUS_PENDING":        1,
+		"PAYMENT_INTENT_STATUS_AUTHORIZED":     2,
+		"PAYMENT_INTENT_STATUS_CAPTURED":       3,
+		"PAYMENT_INTENT_STATUS_FAILED":         4,
+		"PAYMENT_INTENT_STATUS_REFUNDED":       5,
+		"PAYMENT_INTENT_STATUS_REFUND_PENDING": 6,
+	}
+)
+
+func (x PaymentIntentStatus) Enum() *PaymentIntentStatus {
+	p := new(PaymentIntentStatus)
+	*p = x
+	return p
+}
+
+func (x PaymentIntentStatus) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (PaymentIntentStatus) Descriptor() protoreflect.EnumDescriptor {
+	return file_payment_payment_proto_enumTypes[0].Descriptor()
+}
+
+func (PaymentIntentStatus) Type() protoreflect.EnumType {
+	return &file_payment_payment_proto_enumTypes[0]
+}
+
+func (x PaymentIntentStatus) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use PaymentIntentStatus.Descriptor instead.
+func (PaymentIntentStatus) EnumDescriptor() ([]byte, []int) {
+	return file_payment_payment_proto_rawDescGZIP(), []int{0}
+}
+
+type PaymentIntent struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	OrderId       string                 `protobuf:"bytes,2,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	Status        PaymentIntentStatus    `protobuf:"varint,3,opt,name=status,proto3,enum=payment.PaymentIntentStatus" json:"status,omitempty"`
+	FailureReason string                 `protobuf:"bytes,6,opt,name=failure_reason,json=failureReason,proto3" json:"failure_reason,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	Amount        *common.Money          `protobuf:"bytes,8,opt,name=amount,proto3" json:"amount,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PaymentIntent) Reset() {
+	*x = PaymentIntent{}
+	mi := &file_payment_payment_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PaymentIntent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PaymentIntent) ProtoMessage() {}
+
+func (x *PaymentIntent) ProtoReflect() protoreflect.Message {
+	mi := &file_payment_payment_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PaymentIntent.ProtoReflect.Descriptor instead.
+func (*PaymentIntent) Descriptor() ([]byte, []int) {
+	return file_payment_payment_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *PaymentIntent) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *PaymentIntent) GetOrderId() string {
+	if x != nil {
+		return x.OrderId
+	}
+	return ""
+}
+
+func (x *PaymentIntent) GetStatus() PaymentIntentStatus {
+	if x != nil {
+		return x.Status
+	}
+	return PaymentIntentStatus_PAYMENT_INTENT_STATUS_UNSPECIFIED
+}
+
+func (x *PaymentIntent) GetFailureReason() string {
+	if x != nil {
+		return x.FailureReason
+	}
+	return ""
+}
+
+func (x *PaymentIntent) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *PaymentIntent) GetAmount() *common.Money {
+	if x != nil {
+		return x.Amount
+	}
+	return nil
+}
+
+type PaymentCard struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Number        string                 `protobuf:"bytes,1,opt,name=number,proto3" json:"number,omitempty"`
+	ExpMonth      int32                  `protobuf:"varint,2,opt,name=exp_month,json=expMonth,proto3" json:"exp_month,omitempty"`
+	ExpYear       int32                  `protobuf:"varint,3,opt,name=exp_year,json=expYear,proto3" json:"exp_year,omitempty"`
+	Cvc           string                 `protobuf:"bytes,4,opt,name=cvc,proto3" json:"cvc,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PaymentCard) Reset() {
+	*x = PaymentCard{}
+	mi := &file_payment_payment_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PaymentCard) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PaymentCard) ProtoMessage() {}
+
+func (x *PaymentCard) ProtoReflect() protoreflect.Message {
+	mi := &file_payment_payment_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PaymentCard.ProtoReflect.Descriptor instead.
+func (*PaymentCard) Descriptor() ([]byte, []int) {
+	return file_payment_payment_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *PaymentCard) GetNumber() string {
+	if x != nil {
+		return x.Number
+	}
+	return ""
+}
+
+func (x *PaymentCard) GetExpMonth() int32 {
+	if x != nil {
+		return x.ExpMonth
+	}
+	return 0
+}
+
+func (x *PaymentCard) GetExpYear() int32 {
+	if x != nil {
+		return x.ExpYear
+	}
+	return 0
+}
+
+func (x *PaymentCard) GetCvc() string {
+	if x != nil {
+		return x.Cvc
+	}
+	return ""
+}
+
+type PayOrderRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	OrderId       string                 `protobuf:"bytes,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	Card          *PaymentCard           `protobuf:"bytes,2,opt,name=card,proto3" json:"card,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PayOrderRequest) Reset() {
+	*x = PayOrderRequest{}
+	mi := &file_payment_payment_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PayOrderRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PayOrderRequest) ProtoMessage() {}
+
+func (x *PayOrderRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_payment_payment_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PayOrderRequest.ProtoReflect.Descriptor instead.
+func (*PayOrderRequest) Descriptor() ([]byte, []int) {
+	return file_payment_payment_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *PayOrderRequest) GetOrderId() string {
+	if x != nil {
+		return x.OrderId
+	}
+	return ""
+}
+
+func (x *PayOrderRequest) GetCard() *PaymentCard {
+	if x != nil {
+		return x.Card
+	}
+	return nil
+}
+
+type PayOrderResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Base          *common.BaseResponse   `protobuf:"bytes,1,opt,name=base,proto3" json:"base,omitempty"`
+	PaymentIntent *PaymentIntent         `protobuf:"bytes,2,opt,name=payment_intent,json=paymentIntent,proto3" json:"payment_intent,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PayOrderResponse) Reset() {
+	*x = PayOrderResponse{}
+	mi := &file_payment_payment_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PayOrderResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PayOrderResponse) ProtoMessage() {}
+
+func (x *PayOrderResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_payment_payment_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PayOrderResponse.ProtoReflect.Descriptor instead.
+func (*PayOrderResponse) Descriptor() ([]byte, []int) {
+	return file_payment_payment_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *PayOrderResponse) GetBase() *common.BaseResponse {
+	if x != nil {
+		return x.Base
+	}
+	return nil
+}
+
+func (x *PayOrderResponse) GetPaymentIntent() *PaymentIntent {
+	if x != nil {
+		return x.PaymentIntent
+	}
+	return nil
+}
+
+type RefundOrderRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	OrderId       string                 `protobuf:"bytes,1,opt,name=order_id,json=orderId,proto3" json:"order_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RefundOrderRequest) Reset() {
+	*x = RefundOrderRequest{}
+	mi := &file_payment_payment_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RefundOrderRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RefundOrderRequest) ProtoMessage() {}
+
+func (x *RefundOrderRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_payment_payment_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RefundOrderRequest.ProtoReflect.Descriptor instead.
+func (*RefundOrderRequest) Descriptor() ([]byte, []int) {
+	return file_payment_payment_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *RefundOrderRequest) GetOrderId() string {
+	if x != nil {
+		return x.OrderId
+	}
+	return ""
+}
+
+type RefundOrderResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Base          *common.BaseResponse   `protobuf:"bytes,1,opt,name=base,proto3" json:"base,omitempty"`
+	PaymentIntent *PaymentIntent         `protobuf:"bytes,2,opt,name=payment_intent,json=paymentIntent,proto3" json:"payment_intent,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RefundOrderResponse) Reset() {
+	*x = RefundOrderResponse{}
+	mi := &file_payment_payment_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RefundOrderResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RefundOrderResponse) ProtoMessage() {}
+
+func (x *RefundOrderResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_payment_payment_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RefundOrderResponse.ProtoReflect.Descriptor instead.
+func (*RefundOrderResponse) Descriptor() ([]byte, []int) {
+	return file_payment_payment_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *RefundOrderResponse) GetBase() *common.BaseResponse {
+	if x != nil {
+		return x.Base
+	}
+	return nil
+}
+
+func (x *RefundOrderResponse) GetPaymentIntent() *PaymentIntent {
+	if x != nil {
+		return x.PaymentIntent
+	}
+	return nil
+}
+
+var File_payment_payment_proto protoreflect.FileDescriptor
+
+const file_payment_payment_proto_rawDesc = "" +
+	"\n" +
+	"\x15payment/payment.proto\x12\apayment\x1a\x1acommon/base_response.proto\x1a\x12common/money.proto\x1a\x1bbuf/validate/validate.proto\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x11auth/policy.proto\"\x8f\x02\n" +
+	"\rPaymentIntent\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x19\n" +
+	"\border_id\x18\x02 \x01(\tR\aorderId\x124\n" +
+	"\x06status\x18\x03 \x01(\x0e2\x1c.payment.PaymentIntentStatusR\x06status\x12%\n" +
+	"\x0efailure_reason\x18\x06 \x01(\tR\rfailureReason\x129\n" +
+	"\n" +
+	"created_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x12%\n" +
+	"\x06amount\x18\b \x01(\v2\r.common.MoneyR\x06amountJ\x04\b\x04\x10\x05J\x04\b\x05\x10\x06R\bcurrency\"\xb3\x01\n" +
+	"\vPaymentCard\x12-\n" +
+	"\x06number\x18\x01 \x01(\tB\x15\xbaH\x12r\x102\x0e^[0-9]{12,19}$R\x06number\x12&\n" +
+	"\texp_month\x18\x02 \x01(\x05B\t\xbaH\x06\x1a\x04\x18\f(\x01R\bexpMonth\x12&\n" +
+	"\bexp_year\x18\x03 \x01(\x05B\v\xbaH\b\x1a\x06\x18\xb4\x10(\xd0\x0fR\aexpYear\x12%\n" +
+	"\x03cvc\x18\x04 \x01(\tB\x13\xbaH\x10r\x0e2\f^[0-9]{3,4}$R\x03cvc\"j\n" +
+	"\x0fPayOrderRequest\x12%\n" +
+	"\border_id\x18\x01 \x01(\tB\n" +
+	"\xbaH\ar\x05\x10\x01\x18\xff\x01R\aorderId\x120\n" +
+	"\x04card\x18\x02 \x01(\v2\x14.payment.PaymentCardB\x06\xbaH\x03\xc8\x01\x01R\x04card\"{\n" +
+	"\x10PayOrderResponse\x12(\n" +
+	"\x04base\x18\x01 \x01(\v2\x14.common.BaseResponseR\x04base\x12=\n" +
+	"\x0epayment_intent\x18\x02 \x01(\v2\x16.payment.PaymentIntentR\rpaymentIntent\";\n" +
+	"\x12RefundOrderRequest\x12%\n" +
+	"\border_id\x18\x01 \x01(\tB\n" +
+	"\xbaH\ar\x05\x10\x01\x18\xff\x01R\aorderId\"~\n" +
+	"\x13RefundOrderResponse\x12(\n" +
+	"\x04base\x18\x01 \x01(\v2\x14.common.BaseResponseR\x04base\x12=\n" +
+	"\x0epayment_intent\x18\x02 \x01(\v2\x16.payment.PaymentIntentR\rpaymentIntent*\x99\x02\n" +
+	"\x13PaymentIntentStatus\x12%\n" +
+	"!PAYMENT_INTENT_STATUS_UNSPECIFIED\x10\x00\x12!\n" +
+	"\x1dPAYMENT_INTENT_STATUS_PENDING\x10\x01\x12$\n" +
+	" PAYMENT_INTENT_STATUS_AUTHORIZED\x10\x02\x12\"\n" +
+	"\x1ePAYMENT_INTENT_STATUS_CAPTURED\x10\x03\x12 \n" +
+	"\x1cPAYMENT_INTENT_STATUS_FAILED\x10\x04\x12\"\n" +
+	"\x1ePAYMENT_INTENT_STATUS_REFUNDED\x10\x05\x12(\n" +
+	"$PAYMENT_INTENT_STATUS_REFUND_PENDING\x10\x062\xaf\x01\n" +
+	"\x0ePaymentService\x12?\n" +
+	"\bPayOrder\x12\x18.payment.PayOrderRequest\x1a\x19.payment.PayOrderResponse\x12\\\n" +
+	"\vRefundOrder\x12\x1b.payment.RefundOrderRequest\x1a\x1c.payment.RefundOrderResponse\"\x12\x8a\xb5\x18\x0e\x1a\forder:refundB-Z+github.com/aldngrha/ecommerce-be/pb/paymentb\x06proto3"
+
+var (
+	file_payment_payment_proto_rawDescOnce sync.Once
+	file_payment_payment_proto_rawDescData []byte
+)
+
+func file_payment_payment_proto_rawDescGZIP() []byte {
+	file_payment_payment_proto_rawDescOnce.Do(func() {
+		file_payment_payment_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_payment_payment_proto_rawDesc), len(file_payment_payment_proto_rawDesc)))
+	})
+	return file_payment_payment_proto_rawDescData
+}
+
+var file_payment_payment_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_payment_payment_proto_msgTypes = make([]protoimpl.MessageInfo, 6)
+var file_payment_payment_proto_goTypes = []any{
+	(PaymentIntentStatus)(0),      // 0: payment.PaymentIntentStatus
+	(*PaymentIntent)(nil),         // 1: payment.PaymentIntent
+	(*PaymentCard)(nil),           // 2: payment.PaymentCard
+	(*PayOrderRequest)(nil),       // 3: payment.PayOrderRequest
+	(*PayOrderResponse)(nil),      // 4: payment.PayOrderResponse
+	(*RefundOrderRequest)(nil),    // 5: payment.RefundOrderRequest
+	(*RefundOrderResponse)(nil),   // 6: payment.RefundOrderResponse
+	(*timestamppb.Timestamp)(nil), // 7: google.protobuf.Timestamp
+	(*common.Money)(nil),          // 8: common.Money
+	(*common.BaseResponse)(nil),   // 9: common.BaseResponse
+}
+var file_payment_payment_proto_depIdxs = []int32{
+	0,  // 0: payment.PaymentIntent.status:type_name -> payment.PaymentIntentStatus
+	7,  // 1: payment.PaymentIntent.created_at:type_name -> google.protobuf.Timestamp
+	8,  // 2: payment.PaymentIntent.amount:type_name -> common.Money
+	2,  // 3: payment.PayOrderRequest.card:type_name -> payment.PaymentCard
+	9,  // 4: payment.PayOrderResponse.base:type_name -> common.BaseResponse
+	1,  // 5: payment.PayOrderResponse.payment_intent:type_name -> payment.PaymentIntent
+	9,  // 6: payment.RefundOrderResponse.base:type_name -> common.BaseResponse
+	1,  // 7: payment.RefundOrderResponse.payment_intent:type_name -> payment.PaymentIntent
+	3,  // 8: payment.PaymentService.PayOrder:input_type -> payment.PayOrderRequest
+	5,  // 9: payment.PaymentService.RefundOrder:input_type -> payment.RefundOrderRequest
+	4,  // 10: payment.PaymentService.PayOrder:output_type -> payment.PayOrderResponse
+	6,  // 11: payment.PaymentService.RefundOrder:output_type -> payment.RefundOrderResponse
+	10, // [10:12] is the sub-list for method output_type
+	8,  // [8:10] is the sub-list for method input_type
+	8,  // [8:8] is the sub-list for extension type_name
+	8,  // [8:8] is the sub-list for extension extendee
+	0,  // [0:8] is the sub-list for field type_name
+}
+
+func init() { file_payment_payment_proto_init() }
+func file_payment_payment_proto_init() {
+	if File_payment_payment_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_payment_payment_proto_rawDesc), len(file_payment_payment_proto_rawDesc)),
+			NumEnums:      1,
+			NumMessages:   6,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_payment_payment_proto_goTypes,
+		DependencyIndexes: file_payment_payment_proto_depIdxs,
+		EnumInfos:         file_payment_payment_proto_enumTypes,
+		MessageInfos:      file_payment_payment_proto_msgTypes,
+	}.Build()
+	File_payment_payment_proto = out.File
+	file_payment_payment_proto_goTypes = nil
+	file_payment_payment_proto_depIdxs = nil
+}
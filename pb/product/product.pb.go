@@ -8,9 +8,11 @@ package product
 
 import (
 	_ "buf.build/gen/go/bufbuild/protovalidate/protocolbuffers/go/buf/validate"
+	_ "github.com/aldngrha/ecommerce-be/pb/auth"
 	common "github.com/aldngrha/ecommerce-be/pb/common"
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
 	reflect "reflect"
 	sync "sync"
 	unsafe "unsafe"
@@ -23,12 +25,115 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+type ProductSortBy int32
+
+const (
+	ProductSortBy_PRODUCT_SORT_BY_UNSPECIFIED ProductSortBy = 0
+	ProductSortBy_PRODUCT_SORT_BY_CREATED_AT  ProductSortBy = 1
+	ProductSortBy_PRODUCT_SORT_BY_PRICE       ProductSortBy = 2
+	ProductSortBy_PRODUCT_SORT_BY_NAME        ProductSortBy = 3
+)
+
+// Enum value maps for ProductSortBy.
+var (
+	ProductSortBy_name = map[int32]string{
+		0: "PRODUCT_SORT_BY_UNSPECIFIED",
+		1: "PRODUCT_SORT_BY_CREATED_AT",
+		2: "PRODUCT_SORT_BY_PRICE",
+		3: "PRODUCT_SORT_BY_NAME",
+	}
+	ProductSortBy_value = map[string]int32{
+		"PRODUCT_SORT_BY_UNSPECIFIED": 0,
+		"PRODUCT_SORT_BY_CREATED_AT":  1,
+		"PRODUCT_SORT_BY_PRICE":       2,
+		"PRODUCT_SORT_BY_NAME":        3,
+	}
+)
+
+func (x ProductSortBy) Enum() *ProductSortBy {
+	p := new(ProductSortBy)
+	*p = x
+	return p
+}
+
+func (x ProductSortBy) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ProductSortBy) Descriptor() protoreflect.EnumDescriptor {
+	return file_product_product_proto_enumTypes[0].Descriptor()
+}
+
+func (ProductSortBy) Type() protoreflect.EnumType {
+	return &file_product_product_proto_enumTypes[0]
+}
+
+func (x ProductSortBy) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ProductSortBy.Descriptor instead.
+func (ProductSortBy) EnumDescriptor() ([]byte, []int) {
+	return file_product_product_proto_rawDescGZIP(), []int{0}
+}
+
+type SortDirection int32
+
+const (
+	SortDirection_SORT_DIRECTION_UNSPECIFIED SortDirection = 0
+	SortDirection_SORT_DIRECTION_ASC         SortDirection = 1
+	SortDirection_SORT_DIRECTION_DESC        SortDirection = 2
+)
+
+// Enum value maps for SortDirection.
+var (
+	SortDirection_name = map[int32]string{
+		0: "SORT_DIRECTION_UNSPECIFIED",
+		1: "SORT_DIRECTION_ASC",
+		2: "SORT_DIRECTION_DESC",
+	}
+	SortDirection_value = map[string]int32{
+		"SORT_DIRECTION_UNSPECIFIED": 0,
+		"SORT_DIRECTION_ASC":         1,
+		"SORT_DIRECTION_DESC":        2,
+	}
+)
+
+func (x SortDirection) Enum() *SortDirection {
+	p := new(SortDirection)
+	*p = x
+	return p
+}
+
+func (x SortDirection) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (SortDirection) Descriptor() protoreflect.EnumDescriptor {
+	return file_product_product_proto_enumTypes[1].Descriptor()
+}
+
+func (SortDirection) Type() protoreflect.EnumType {
+	return &file_product_product_proto_enumTypes[1]
+}
+
+func (x SortDirection) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use SortDirection.Descriptor instead.
+func (SortDirection) EnumDescriptor() ([]byte, []int) {
+	return file_product_product_proto_rawDescGZIP(), []int{1}
+}
+
 type CreateProductRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
 	Description   string                 `protobuf:"bytes,2,opt,name=description,proto3" json:"description,omitempty"`
-	Price         float64                `protobuf:"fixed64,3,opt,name=price,proto3" json:"price,omitempty"`
 	ImageFileName string                 `protobuf:"bytes,4,opt,name=image_file_name,json=imageFileName,proto3" json:"image_file_name,omitempty"`
+	CategoryIds   []string               `protobuf:"bytes,5,rep,name=category_ids,json=categoryIds,proto3" json:"category_ids,omitempty"`
+	// in the store currency
+	Price         *common.Money `protobuf:"bytes,6,opt,name=price,proto3" json:"price,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -77,18 +182,25 @@ func (x *CreateProductRequest) GetDescription() string {
 	return ""
 }
 
-func (x *CreateProductRequest) GetPrice() float64 {
+func (x *CreateProductRequest) GetImageFileName() string {
 	if x != nil {
-		return x.Price
+		return x.ImageFileName
 	}
-	return 0
+	return ""
 }
 
-func (x *CreateProductRequest) GetImageFileName() string {
+func (x *CreateProductRequest) GetCategoryIds() []string {
 	if x != nil {
-		return x.ImageFileName
+		return x.CategoryIds
 	}
-	return ""
+	return nil
+}
+
+func (x *CreateProductRequest) GetPrice() *common.Money {
+	if x != nil {
+		return x.Price
+	}
+	return nil
 }
 
 type CreateProductResponse struct {
@@ -187,32 +299,29 @@ func (x *DetailProductRequest) GetId() string {
 	return ""
 }
 
-type DetailProductResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Base          *common.BaseResponse   `protobuf:"bytes,1,opt,name=base,proto3" json:"base,omitempty"`
-	Id            string                 `protobuf:"bytes,2,opt,name=id,proto3" json:"id,omitempty"`
-	Name          string                 `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
-	Description   string                 `protobuf:"bytes,4,opt,name=description,proto3" json:"description,omitempty"`
-	Price         float64                `protobuf:"fixed64,5,opt,name=price,proto3" json:"price,omitempty"`
-	ImageFileUrl  string                 `protobuf:"bytes,6,opt,name=image_file_url,json=imageFileUrl,proto3" json:"image_file_url,omitempty"`
+type ImageVariant struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// "original" or a configured variant such as "thumbnail" or "medium"
+	Name          string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Url           string `protobuf:"bytes,2,opt,name=url,proto3" json:"url,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *DetailProductResponse) Reset() {
-	*x = DetailProductResponse{}
+func (x *ImageVariant) Reset() {
+	*x = ImageVariant{}
 	mi := &file_product_product_proto_msgTypes[3]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *DetailProductResponse) String() string {
+func (x *ImageVariant) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*DetailProductResponse) ProtoMessage() {}
+func (*ImageVariant) ProtoMessage() {}
 
-func (x *DetailProductResponse) ProtoReflect() protoreflect.Message {
+func (x *ImageVariant) ProtoReflect() protoreflect.Message {
 	mi := &file_product_product_proto_msgTypes[3]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -224,79 +333,160 @@ func (x *DetailProductResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use DetailProductResponse.ProtoReflect.Descriptor instead.
-func (*DetailProductResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use ImageVariant.ProtoReflect.Descriptor instead.
+func (*ImageVariant) Descriptor() ([]byte, []int) {
 	return file_product_product_proto_rawDescGZIP(), []int{3}
 }
 
-func (x *DetailProductResponse) GetBase() *common.BaseResponse {
+func (x *ImageVariant) GetName() string {
 	if x != nil {
-		return x.Base
+		return x.Name
 	}
-	return nil
+	return ""
 }
 
-func (x *DetailProductResponse) GetId() string {
+func (x *ImageVariant) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
+type ProductCategory struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Slug          string                 `protobuf:"bytes,3,opt,name=slug,proto3" json:"slug,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ProductCategory) Reset() {
+	*x = ProductCategory{}
+	mi := &file_product_product_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ProductCategory) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProductCategory) ProtoMessage() {}
+
+func (x *ProductCategory) ProtoReflect() protoreflect.Message {
+	mi := &file_product_product_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProductCategory.ProtoReflect.Descriptor instead.
+func (*ProductCategory) Descriptor() ([]byte, []int) {
+	return file_product_product_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *ProductCategory) GetId() string {
 	if x != nil {
 		return x.Id
 	}
 	return ""
 }
 
-func (x *DetailProductResponse) GetName() string {
+func (x *ProductCategory) GetName() string {
 	if x != nil {
 		return x.Name
 	}
 	return ""
 }
 
-func (x *DetailProductResponse) GetDescription() string {
+func (x *ProductCategory) GetSlug() string {
 	if x != nil {
-		return x.Description
+		return x.Slug
 	}
 	return ""
 }
 
-func (x *DetailProductResponse) GetPrice() float64 {
+type ProductOption struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Values        []string               `protobuf:"bytes,2,rep,name=values,proto3" json:"values,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ProductOption) Reset() {
+	*x = ProductOption{}
+	mi := &file_product_product_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ProductOption) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProductOption) ProtoMessage() {}
+
+func (x *ProductOption) ProtoReflect() protoreflect.Message {
+	mi := &file_product_product_proto_msgTypes[5]
 	if x != nil {
-		return x.Price
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
 	}
-	return 0
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProductOption.ProtoReflect.Descriptor instead.
+func (*ProductOption) Descriptor() ([]byte, []int) {
+	return file_product_product_proto_rawDescGZIP(), []int{5}
 }
 
-func (x *DetailProductResponse) GetImageFileUrl() string {
+func (x *ProductOption) GetName() string {
 	if x != nil {
-		return x.ImageFileUrl
+		return x.Name
 	}
 	return ""
 }
 
-type EditProductRequest struct {
+func (x *ProductOption) GetValues() []string {
+	if x != nil {
+		return x.Values
+	}
+	return nil
+}
+
+type ProductVariantOptionValue struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
-	Description   string                 `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
-	Price         float64                `protobuf:"fixed64,4,opt,name=price,proto3" json:"price,omitempty"`
-	ImageFileName string                 `protobuf:"bytes,5,opt,name=image_file_name,json=imageFileName,proto3" json:"image_file_name,omitempty"`
+	Option        string                 `protobuf:"bytes,1,opt,name=option,proto3" json:"option,omitempty"`
+	Value         string                 `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *EditProductRequest) Reset() {
-	*x = EditProductRequest{}
-	mi := &file_product_product_proto_msgTypes[4]
+func (x *ProductVariantOptionValue) Reset() {
+	*x = ProductVariantOptionValue{}
+	mi := &file_product_product_proto_msgTypes[6]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *EditProductRequest) String() string {
+func (x *ProductVariantOptionValue) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*EditProductRequest) ProtoMessage() {}
+func (*ProductVariantOptionValue) ProtoMessage() {}
 
-func (x *EditProductRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_product_product_proto_msgTypes[4]
+func (x *ProductVariantOptionValue) ProtoReflect() protoreflect.Message {
+	mi := &file_product_product_proto_msgTypes[6]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -307,69 +497,151 @@ func (x *EditProductRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use EditProductRequest.ProtoReflect.Descriptor instead.
-func (*EditProductRequest) Descriptor() ([]byte, []int) {
-	return file_product_product_proto_rawDescGZIP(), []int{4}
+// Deprecated: Use ProductVariantOptionValue.ProtoReflect.Descriptor instead.
+func (*ProductVariantOptionValue) Descriptor() ([]byte, []int) {
+	return file_product_product_proto_rawDescGZIP(), []int{6}
 }
 
-func (x *EditProductRequest) GetId() string {
+func (x *ProductVariantOptionValue) GetOption() string {
+	if x != nil {
+		return x.Option
+	}
+	return ""
+}
+
+func (x *ProductVariantOptionValue) GetValue() string {
+	if x != nil {
+		return x.Value
+	}
+	return ""
+}
+
+type ProductVariant struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Id    string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Sku   string                 `protobuf:"bytes,2,opt,name=sku,proto3" json:"sku,omitempty"`
+	// option values joined, e.g. "M / Red"
+	Name         string                       `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	OptionValues []*ProductVariantOptionValue `protobuf:"bytes,4,rep,name=option_values,json=optionValues,proto3" json:"option_values,omitempty"`
+	// the variant's own price, or the product price when it has none
+	Price *common.Money `protobuf:"bytes,8,opt,name=price,proto3" json:"price,omitempty"`
+	// the variant's own image, or the product image when it has none
+	Images        []*ImageVariant `protobuf:"bytes,6,rep,name=images,proto3" json:"images,omitempty"`
+	Available     int32           `protobuf:"varint,7,opt,name=available,proto3" json:"available,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ProductVariant) Reset() {
+	*x = ProductVariant{}
+	mi := &file_product_product_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ProductVariant) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProductVariant) ProtoMessage() {}
+
+func (x *ProductVariant) ProtoReflect() protoreflect.Message {
+	mi := &file_product_product_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProductVariant.ProtoReflect.Descriptor instead.
+func (*ProductVariant) Descriptor() ([]byte, []int) {
+	return file_product_product_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *ProductVariant) GetId() string {
 	if x != nil {
 		return x.Id
 	}
 	return ""
 }
 
-func (x *EditProductRequest) GetName() string {
+func (x *ProductVariant) GetSku() string {
 	if x != nil {
-		return x.Name
+		return x.Sku
 	}
 	return ""
 }
 
-func (x *EditProductRequest) GetDescription() string {
+func (x *ProductVariant) GetName() string {
 	if x != nil {
-		return x.Description
+		return x.Name
 	}
 	return ""
 }
 
-func (x *EditProductRequest) GetPrice() float64 {
+func (x *ProductVariant) GetOptionValues() []*ProductVariantOptionValue {
+	if x != nil {
+		return x.OptionValues
+	}
+	return nil
+}
+
+func (x *ProductVariant) GetPrice() *common.Money {
 	if x != nil {
 		return x.Price
 	}
-	return 0
+	return nil
 }
 
-func (x *EditProductRequest) GetImageFileName() string {
+func (x *ProductVariant) GetImages() []*ImageVariant {
 	if x != nil {
-		return x.ImageFileName
+		return x.Images
 	}
-	return ""
+	return nil
 }
 
-type EditProductResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Base          *common.BaseResponse   `protobuf:"bytes,1,opt,name=base,proto3" json:"base,omitempty"`
-	Id            string                 `protobuf:"bytes,2,opt,name=id,proto3" json:"id,omitempty"`
+func (x *ProductVariant) GetAvailable() int32 {
+	if x != nil {
+		return x.Available
+	}
+	return 0
+}
+
+type DetailProductResponse struct {
+	state       protoimpl.MessageState `protogen:"open.v1"`
+	Base        *common.BaseResponse   `protobuf:"bytes,1,opt,name=base,proto3" json:"base,omitempty"`
+	Id          string                 `protobuf:"bytes,2,opt,name=id,proto3" json:"id,omitempty"`
+	Name        string                 `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	Description string                 `protobuf:"bytes,4,opt,name=description,proto3" json:"description,omitempty"`
+	Price       *common.Money          `protobuf:"bytes,11,opt,name=price,proto3" json:"price,omitempty"`
+	Images      []*ImageVariant        `protobuf:"bytes,7,rep,name=images,proto3" json:"images,omitempty"`
+	Categories  []*ProductCategory     `protobuf:"bytes,8,rep,name=categories,proto3" json:"categories,omitempty"`
+	Options     []*ProductOption       `protobuf:"bytes,9,rep,name=options,proto3" json:"options,omitempty"`
+	// empty when the product is not sold in variants
+	Variants      []*ProductVariant `protobuf:"bytes,10,rep,name=variants,proto3" json:"variants,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *EditProductResponse) Reset() {
-	*x = EditProductResponse{}
-	mi := &file_product_product_proto_msgTypes[5]
+func (x *DetailProductResponse) Reset() {
+	*x = DetailProductResponse{}
+	mi := &file_product_product_proto_msgTypes[8]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *EditProductResponse) String() string {
+func (x *DetailProductResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*EditProductResponse) ProtoMessage() {}
+func (*DetailProductResponse) ProtoMessage() {}
 
-func (x *EditProductResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_product_product_proto_msgTypes[5]
+func (x *DetailProductResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_product_product_proto_msgTypes[8]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -380,68 +652,1322 @@ func (x *EditProductResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use EditProductResponse.ProtoReflect.Descriptor instead.
-func (*EditProductResponse) Descriptor() ([]byte, []int) {
-	return file_product_product_proto_rawDescGZIP(), []int{5}
+// Deprecated: Use DetailProductResponse.ProtoReflect.Descriptor instead.
+func (*DetailProductResponse) Descriptor() ([]byte, []int) {
+	return file_product_product_proto_rawDescGZIP(), []int{8}
 }
 
-func (x *EditProductResponse) GetBase() *common.BaseResponse {
+func (x *DetailProductResponse) GetBase() *common.BaseResponse {
 	if x != nil {
 		return x.Base
 	}
 	return nil
 }
 
-func (x *EditProductResponse) GetId() string {
+func (x *DetailProductResponse) GetId() string {
 	if x != nil {
 		return x.Id
 	}
 	return ""
 }
 
-var File_product_product_proto protoreflect.FileDescriptor
+func (x *DetailProductResponse) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
 
-const file_product_product_proto_rawDesc = "" +
-	"\n" +
-	"\x15product/product.proto\x12\aproduct\x1a\x1acommon/base_response.proto\x1a\x1bbuf/validate/validate.proto\"\xbe\x01\n" +
-	"\x14CreateProductRequest\x12\x1e\n" +
+func (x *DetailProductResponse) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *DetailProductResponse) GetPrice() *common.Money {
+	if x != nil {
+		return x.Price
+	}
+	return nil
+}
+
+func (x *DetailProductResponse) GetImages() []*ImageVariant {
+	if x != nil {
+		return x.Images
+	}
+	return nil
+}
+
+func (x *DetailProductResponse) GetCategories() []*ProductCategory {
+	if x != nil {
+		return x.Categories
+	}
+	return nil
+}
+
+func (x *DetailProductResponse) GetOptions() []*ProductOption {
+	if x != nil {
+		return x.Options
+	}
+	return nil
+}
+
+func (x *DetailProductResponse) GetVariants() []*ProductVariant {
+	if x != nil {
+		return x.Variants
+	}
+	return nil
+}
+
+type CategoryIdList struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Ids           []string               `protobuf:"bytes,1,rep,name=ids,proto3" json:"ids,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CategoryIdList) Reset() {
+	*x = CategoryIdList{}
+	mi := &file_product_product_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CategoryIdList) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CategoryIdList) ProtoMessage() {}
+
+func (x *CategoryIdList) ProtoReflect() protoreflect.Message {
+	mi := &file_product_product_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CategoryIdList.ProtoReflect.Descriptor instead.
+func (*CategoryIdList) Descriptor() ([]byte, []int) {
+	return file_product_product_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *CategoryIdList) GetIds() []string {
+	if x != nil {
+		return x.Ids
+	}
+	return nil
+}
+
+type EditProductRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Description   string                 `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
+	ImageFileName string                 `protobuf:"bytes,5,opt,name=image_file_name,json=imageFileName,proto3" json:"image_file_name,omitempty"`
+	// in the store currency
+	Price *common.Money `protobuf:"bytes,7,opt,name=price,proto3" json:"price,omitempty"`
+	// replaces the product's categories when set, an empty list removes them all; left
+	// out, the categories are kept
+	CategoryIds   *CategoryIdList `protobuf:"bytes,8,opt,name=category_ids,json=categoryIds,proto3" json:"category_ids,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EditProductRequest) Reset() {
+	*x = EditProductRequest{}
+	mi := &file_product_product_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EditProductRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EditProductRequest) ProtoMessage() {}
+
+func (x *EditProductRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_product_product_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EditProductRequest.ProtoReflect.Descriptor instead.
+func (*EditProductRequest) Descriptor() ([]byte, []int) {
+	return file_product_product_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *EditProductRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *EditProductRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *EditProductRequest) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *EditProductRequest) GetImageFileName() string {
+	if x != nil {
+		return x.ImageFileName
+	}
+	return ""
+}
+
+func (x *EditProductRequest) GetPrice() *common.Money {
+	if x != nil {
+		return x.Price
+	}
+	return nil
+}
+
+func (x *EditProductRequest) GetCategoryIds() *CategoryIdList {
+	if x != nil {
+		return x.CategoryIds
+	}
+	return nil
+}
+
+type EditProductResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Base          *common.BaseResponse   `protobuf:"bytes,1,opt,name=base,proto3" json:"base,omitempty"`
+	Id            string                 `protobuf:"bytes,2,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EditProductResponse) Reset() {
+	*x = EditProductResponse{}
+	mi := &file_product_product_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EditProductResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EditProductResponse) ProtoMessage() {}
+
+func (x *EditProductResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_product_product_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EditProductResponse.ProtoReflect.Descriptor instead.
+func (*EditProductResponse) Descriptor() ([]byte, []int) {
+	return file_product_product_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *EditProductResponse) GetBase() *common.BaseResponse {
+	if x != nil {
+		return x.Base
+	}
+	return nil
+}
+
+func (x *EditProductResponse) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type ListProductsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// opaque cursor from the previous page's next_cursor, empty for the first page
+	Cursor string `protobuf:"bytes,1,opt,name=cursor,proto3" json:"cursor,omitempty"`
+	Limit  int32  `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
+	Name   string `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	// price bounds in minor units of the store currency
+	MinPrice      *int64        `protobuf:"varint,9,opt,name=min_price,json=minPrice,proto3,oneof" json:"min_price,omitempty"`
+	MaxPrice      *int64        `protobuf:"varint,10,opt,name=max_price,json=maxPrice,proto3,oneof" json:"max_price,omitempty"`
+	SortBy        ProductSortBy `protobuf:"varint,6,opt,name=sort_by,json=sortBy,proto3,enum=product.ProductSortBy" json:"sort_by,omitempty"`
+	SortDirection SortDirection `protobuf:"varint,7,opt,name=sort_direction,json=sortDirection,proto3,enum=product.SortDirection" json:"sort_direction,omitempty"`
+	// only products in this category or any of its descendants
+	CategoryId    string `protobuf:"bytes,8,opt,name=category_id,json=categoryId,proto3" json:"category_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListProductsRequest) Reset() {
+	*x = ListProductsRequest{}
+	mi := &file_product_product_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListProductsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListProductsRequest) ProtoMessage() {}
+
+func (x *ListProductsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_product_product_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListProductsRequest.ProtoReflect.Descriptor instead.
+func (*ListProductsRequest) Descriptor() ([]byte, []int) {
+	return file_product_product_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *ListProductsRequest) GetCursor() string {
+	if x != nil {
+		return x.Cursor
+	}
+	return ""
+}
+
+func (x *ListProductsRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *ListProductsRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *ListProductsRequest) GetMinPrice() int64 {
+	if x != nil && x.MinPrice != nil {
+		return *x.MinPrice
+	}
+	return 0
+}
+
+func (x *ListProductsRequest) GetMaxPrice() int64 {
+	if x != nil && x.MaxPrice != nil {
+		return *x.MaxPrice
+	}
+	return 0
+}
+
+func (x *ListProductsRequest) GetSortBy() ProductSortBy {
+	if x != nil {
+		return x.SortBy
+	}
+	return ProductSortBy_PRODUCT_SORT_BY_UNSPECIFIED
+}
+
+func (x *ListProductsRequest) GetSortDirection() SortDirection {
+	if x != nil {
+		return x.SortDirection
+	}
+	return SortDirection_SORT_DIRECTION_UNSPECIFIED
+}
+
+func (x *ListProductsRequest) GetCategoryId() string {
+	if x != nil {
+		return x.CategoryId
+	}
+	return ""
+}
+
+type ListProductsItem struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Description   string                 `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
+	ImageFileUrl  string                 `protobuf:"bytes,5,opt,name=image_file_url,json=imageFileUrl,proto3" json:"image_file_url,omitempty"`
+	Price         *common.Money          `protobuf:"bytes,6,opt,name=price,proto3" json:"price,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListProductsItem) Reset() {
+	*x = ListProductsItem{}
+	mi := &file_product_product_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListProductsItem) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListProductsItem) ProtoMessage() {}
+
+func (x *ListProductsItem) ProtoReflect() protoreflect.Message {
+	mi := &file_product_product_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListProductsItem.ProtoReflect.Descriptor instead.
+func (*ListProductsItem) Descriptor() ([]byte, []int) {
+	return file_product_product_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *ListProductsItem) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *ListProductsItem) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *ListProductsItem) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *ListProductsItem) GetImageFileUrl() string {
+	if x != nil {
+		return x.ImageFileUrl
+	}
+	return ""
+}
+
+func (x *ListProductsItem) GetPrice() *common.Money {
+	if x != nil {
+		return x.Price
+	}
+	return nil
+}
+
+type ListProductsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Base          *common.BaseResponse   `protobuf:"bytes,1,opt,name=base,proto3" json:"base,omitempty"`
+	Items         []*ListProductsItem    `protobuf:"bytes,2,rep,name=items,proto3" json:"items,omitempty"`
+	NextCursor    string                 `protobuf:"bytes,3,opt,name=next_cursor,json=nextCursor,proto3" json:"next_cursor,omitempty"`
+	HasNext       bool                   `protobuf:"varint,4,opt,name=has_next,json=hasNext,proto3" json:"has_next,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListProductsResponse) Reset() {
+	*x = ListProductsResponse{}
+	mi := &file_product_product_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListProductsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListProductsResponse) ProtoMessage() {}
+
+func (x *ListProductsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_product_product_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListProductsResponse.ProtoReflect.Descriptor instead.
+func (*ListProductsResponse) Descriptor() ([]byte, []int) {
+	return file_product_product_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *ListProductsResponse) GetBase() *common.BaseResponse {
+	if x != nil {
+		return x.Base
+	}
+	return nil
+}
+
+func (x *ListProductsResponse) GetItems() []*ListProductsItem {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+func (x *ListProductsResponse) GetNextCursor() string {
+	if x != nil {
+		return x.NextCursor
+	}
+	return ""
+}
+
+func (x *ListProductsResponse) GetHasNext() bool {
+	if x != nil {
+		return x.HasNext
+	}
+	return false
+}
+
+type DeleteProductRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteProductRequest) Reset() {
+	*x = DeleteProductRequest{}
+	mi := &file_product_product_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteProductRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteProductRequest) ProtoMessage() {}
+
+func (x *DeleteProductRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_product_product_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteProductRequest.ProtoReflect.Descriptor instead.
+func (*DeleteProductRequest) Descriptor() ([]byte, []int) {
+	return file_product_product_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *DeleteProductRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type DeleteProductResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Base          *common.BaseResponse   `protobuf:"bytes,1,opt,name=base,proto3" json:"base,omitempty"`
+	Id            string                 `protobuf:"bytes,2,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteProductResponse) Reset() {
+	*x = DeleteProductResponse{}
+	mi := &file_product_product_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteProductResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteProductResponse) ProtoMessage() {}
+
+func (x *DeleteProductResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_product_product_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteProductResponse.ProtoReflect.Descriptor instead.
+func (*DeleteProductResponse) Descriptor() ([]byte, []int) {
+	return file_product_product_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *DeleteProductResponse) GetBase() *common.BaseResponse {
+	if x != nil {
+		return x.Base
+	}
+	return nil
+}
+
+func (x *DeleteProductResponse) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type RestoreProductRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RestoreProductRequest) Reset() {
+	*x = RestoreProductRequest{}
+	mi := &file_product_product_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RestoreProductRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RestoreProductRequest) ProtoMessage() {}
+
+func (x *RestoreProductRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_product_product_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RestoreProductRequest.ProtoReflect.Descriptor instead.
+func (*RestoreProductRequest) Descriptor() ([]byte, []int) {
+	return file_product_product_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *RestoreProductRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type RestoreProductResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Base          *common.BaseResponse   `protobuf:"bytes,1,opt,name=base,proto3" json:"base,omitempty"`
+	Id            string                 `protobuf:"bytes,2,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RestoreProductResponse) Reset() {
+	*x = RestoreProductResponse{}
+	mi := &file_product_product_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RestoreProductResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RestoreProductResponse) ProtoMessage() {}
+
+func (x *RestoreProductResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_product_product_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RestoreProductResponse.ProtoReflect.Descriptor instead.
+func (*RestoreProductResponse) Descriptor() ([]byte, []int) {
+	return file_product_product_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *RestoreProductResponse) GetBase() *common.BaseResponse {
+	if x != nil {
+		return x.Base
+	}
+	return nil
+}
+
+func (x *RestoreProductResponse) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type ListDeletedProductsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Cursor        string                 `protobuf:"bytes,1,opt,name=cursor,proto3" json:"cursor,omitempty"`
+	Limit         int32                  `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
+	Name          string                 `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListDeletedProductsRequest) Reset() {
+	*x = ListDeletedProductsRequest{}
+	mi := &file_product_product_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListDeletedProductsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListDeletedProductsRequest) ProtoMessage() {}
+
+func (x *ListDeletedProductsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_product_product_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListDeletedProductsRequest.ProtoReflect.Descriptor instead.
+func (*ListDeletedProductsRequest) Descriptor() ([]byte, []int) {
+	return file_product_product_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *ListDeletedProductsRequest) GetCursor() string {
+	if x != nil {
+		return x.Cursor
+	}
+	return ""
+}
+
+func (x *ListDeletedProductsRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *ListDeletedProductsRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+type ListDeletedProductsItem struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Description   string                 `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
+	ImageFileUrl  string                 `protobuf:"bytes,5,opt,name=image_file_url,json=imageFileUrl,proto3" json:"image_file_url,omitempty"`
+	DeletedAt     *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=deleted_at,json=deletedAt,proto3" json:"deleted_at,omitempty"`
+	DeletedBy     string                 `protobuf:"bytes,7,opt,name=deleted_by,json=deletedBy,proto3" json:"deleted_by,omitempty"`
+	Price         *common.Money          `protobuf:"bytes,8,opt,name=price,proto3" json:"price,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListDeletedProductsItem) Reset() {
+	*x = ListDeletedProductsItem{}
+	mi := &file_product_product_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListDeletedProductsItem) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListDeletedProductsItem) ProtoMessage() {}
+
+func (x *ListDeletedProductsItem) ProtoReflect() protoreflect.Message {
+	mi := &file_product_product_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListDeletedProductsItem.ProtoReflect.Descriptor instead.
+func (*ListDeletedProductsItem) Descriptor() ([]byte, []int) {
+	return file_product_product_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *ListDeletedProductsItem) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *ListDeletedProductsItem) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *ListDeletedProductsItem) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *ListDeletedProductsItem) GetImageFileUrl() string {
+	if x != nil {
+		return x.ImageFileUrl
+	}
+	return ""
+}
+
+func (x *ListDeletedProductsItem) GetDeletedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.DeletedAt
+	}
+	return nil
+}
+
+func (x *ListDeletedProductsItem) GetDeletedBy() string {
+	if x != nil {
+		return x.DeletedBy
+	}
+	return ""
+}
+
+func (x *ListDeletedProductsItem) GetPrice() *common.Money {
+	if x != nil {
+		return x.Price
+	}
+	return nil
+}
+
+type ListDeletedProductsResponse struct {
+	state         protoimpl.MessageState     `protogen:"open.v1"`
+	Base          *common.BaseResponse       `protobuf:"bytes,1,opt,name=base,proto3" json:"base,omitempty"`
+	Items         []*ListDeletedProductsItem `protobuf:"bytes,2,rep,name=items,proto3" json:"items,omitempty"`
+	NextCursor    string                     `protobuf:"bytes,3,opt,name=next_cursor,json=nextCursor,proto3" json:"next_cursor,omitempty"`
+	HasNext       bool                       `protobuf:"varint,4,opt,name=has_next,json=hasNext,proto3" json:"has_next,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListDeletedProductsResponse) Reset() {
+	*x = ListDeletedProductsResponse{}
+	mi := &file_product_product_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListDeletedProductsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListDeletedProductsResponse) ProtoMessage() {}
+
+func (x *ListDeletedProductsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_product_product_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListDeletedProductsResponse.ProtoReflect.Descriptor instead.
+func (*ListDeletedProductsResponse) Descriptor() ([]byte, []int) {
+	return file_product_product_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *ListDeletedProductsResponse) GetBase() *common.BaseResponse {
+	if x != nil {
+		return x.Base
+	}
+	return nil
+}
+
+func (x *ListDeletedProductsResponse) GetItems() []*ListDeletedProductsItem {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+func (x *ListDeletedProductsResponse) GetNextCursor() string {
+	if x != nil {
+		return x.NextCursor
+	}
+	return ""
+}
+
+func (x *ListDeletedProductsResponse) GetHasNext() bool {
+	if x != nil {
+		return x.HasNext
+	}
+	return false
+}
+
+type ProductOptionInput struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Values        []string               `protobuf:"bytes,2,rep,name=values,proto3" json:"values,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ProductOptionInput) Reset() {
+	*x = ProductOptionInput{}
+	mi := &file_product_product_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ProductOptionInput) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProductOptionInput) ProtoMessage() {}
+
+func (x *ProductOptionInput) ProtoReflect() protoreflect.Message {
+	mi := &file_product_product_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProductOptionInput.ProtoReflect.Descriptor instead.
+func (*ProductOptionInput) Descriptor() ([]byte, []int) {
+	return file_product_product_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *ProductOptionInput) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *ProductOptionInput) GetValues() []string {
+	if x != nil {
+		return x.Values
+	}
+	return nil
+}
+
+type ProductVariantInput struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Sku   string                 `protobuf:"bytes,1,opt,name=sku,proto3" json:"sku,omitempty"`
+	// one value per option, in the order of the options
+	OptionValues []string `protobuf:"bytes,2,rep,name=option_values,json=optionValues,proto3" json:"option_values,omitempty"`
+	// overrides the product image when set
+	ImageFileName string `protobuf:"bytes,4,opt,name=image_file_name,json=imageFileName,proto3" json:"image_file_name,omitempty"`
+	// overrides the product price when set, in the store currency
+	Price         *common.Money `protobuf:"bytes,5,opt,name=price,proto3" json:"price,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ProductVariantInput) Reset() {
+	*x = ProductVariantInput{}
+	mi := &file_product_product_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ProductVariantInput) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProductVariantInput) ProtoMessage() {}
+
+func (x *ProductVariantInput) ProtoReflect() protoreflect.Message {
+	mi := &file_product_product_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProductVariantInput.ProtoReflect.Descriptor instead.
+func (*ProductVariantInput) Descriptor() ([]byte, []int) {
+	return file_product_product_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *ProductVariantInput) GetSku() string {
+	if x != nil {
+		return x.Sku
+	}
+	return ""
+}
+
+func (x *ProductVariantInput) GetOptionValues() []string {
+	if x != nil {
+		return x.OptionValues
+	}
+	return nil
+}
+
+func (x *ProductVariantInput) GetImageFileName() string {
+	if x != nil {
+		return x.ImageFileName
+	}
+	return ""
+}
+
+func (x *ProductVariantInput) GetPrice() *common.Money {
+	if x != nil {
+		return x.Price
+	}
+	return nil
+}
+
+// SetProductVariantsRequest replaces the options and variants of a product. Variants are
+// matched by SKU, so existing variants keep their id and stock; variants left out are
+// removed. Empty options and variants turn the product back into a single item.
+type SetProductVariantsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ProductId     string                 `protobuf:"bytes,1,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	Options       []*ProductOptionInput  `protobuf:"bytes,2,rep,name=options,proto3" json:"options,omitempty"`
+	Variants      []*ProductVariantInput `protobuf:"bytes,3,rep,name=variants,proto3" json:"variants,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetProductVariantsRequest) Reset() {
+	*x = SetProductVariantsRequest{}
+	mi := &file_product_product_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetProductVariantsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetProductVariantsRequest) ProtoMessage() {}
+
+func (x *SetProductVariantsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_product_product_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetProductVariantsRequest.ProtoReflect.Descriptor instead.
+func (*SetProductVariantsRequest) Descriptor() ([]byte, []int) {
+	return file_product_product_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *SetProductVariantsRequest) GetProductId() string {
+	if x != nil {
+		return x.ProductId
+	}
+	return ""
+}
+
+func (x *SetProductVariantsRequest) GetOptions() []*ProductOptionInput {
+	if x != nil {
+		return x.Options
+	}
+	return nil
+}
+
+func (x *SetProductVariantsRequest) GetVariants() []*ProductVariantInput {
+	if x != nil {
+		return x.Variants
+	}
+	return nil
+}
+
+type SetProductVariantsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Base          *common.BaseResponse   `protobuf:"bytes,1,opt,name=base,proto3" json:"base,omitempty"`
+	ProductId     string                 `protobuf:"bytes,2,opt,name=product_id,json=productId,proto3" json:"product_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetProductVariantsResponse) Reset() {
+	*x = SetProductVariantsResponse{}
+	mi := &file_product_product_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetProductVariantsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetProductVariantsResponse) ProtoMessage() {}
+
+func (x *SetProductVariantsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_product_product_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetProductVariantsResponse.ProtoReflect.Descriptor instead.
+func (*SetProductVariantsResponse) Descriptor() ([]byte, []int) {
+	return file_product_product_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *SetProductVariantsResponse) GetBase() *common.BaseResponse {
+	if x != nil {
+		return x.Base
+	}
+	return nil
+}
+
+func (x *SetProductVariantsResponse) GetProductId() string {
+	if x != nil {
+		return x.ProductId
+	}
+	return ""
+}
+
+var File_product_product_proto protoreflect.FileDescriptor
+
+const file_product_product_proto_rawDesc = "" +
+	"\n" +
+	"\x15product/product.proto\x12\aproduct\x1a\x1acommon/base_response.proto\x1a\x12common/money.proto\x1a\x1bbuf/validate/validate.proto\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x11auth/policy.proto\"\xc3\x02\n" +
+	"\x14CreateProductRequest\x12\x1e\n" +
 	"\x04name\x18\x01 \x01(\tB\n" +
 	"\xbaH\ar\x05\x10\x01\x18\xff\x01R\x04name\x12,\n" +
 	"\vdescription\x18\x02 \x01(\tB\n" +
-	"\xbaH\ar\x05\x10\x01\x18\xff\x01R\vdescription\x12$\n" +
-	"\x05price\x18\x03 \x01(\x01B\x0e\xbaH\v\x12\t!\x00\x00\x00\x00\x00\x00\x00\x00R\x05price\x122\n" +
+	"\xbaH\ar\x05\x10\x01\x18\xff\x01R\vdescription\x122\n" +
 	"\x0fimage_file_name\x18\x04 \x01(\tB\n" +
-	"\xbaH\ar\x05\x10\x01\x18\xff\x01R\rimageFileName\"Q\n" +
+	"\xbaH\ar\x05\x10\x01\x18\xff\x01R\rimageFileName\x124\n" +
+	"\fcategory_ids\x18\x05 \x03(\tB\x11\xbaH\x0e\x92\x01\v\x10\x14\x18\x01\"\x05r\x03\xb0\x01\x01R\vcategoryIds\x12m\n" +
+	"\x05price\x18\x06 \x01(\v2\r.common.MoneyBH\xbaHE\xba\x01?\n" +
+	"\x0eprice.positive\x12\x1cprice must be greater than 0\x1a\x0fthis.amount > 0\xc8\x01\x01R\x05priceJ\x04\b\x03\x10\x04\"Q\n" +
 	"\x15CreateProductResponse\x12(\n" +
 	"\x04base\x18\x01 \x01(\v2\x14.common.BaseResponseR\x04base\x12\x0e\n" +
 	"\x02id\x18\x02 \x01(\tR\x02id\"2\n" +
 	"\x14DetailProductRequest\x12\x1a\n" +
 	"\x02id\x18\x01 \x01(\tB\n" +
-	"\xbaH\ar\x05\x10\x01\x18\xff\x01R\x02id\"\xc3\x01\n" +
+	"\xbaH\ar\x05\x10\x01\x18\xff\x01R\x02id\"4\n" +
+	"\fImageVariant\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x10\n" +
+	"\x03url\x18\x02 \x01(\tR\x03url\"I\n" +
+	"\x0fProductCategory\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x12\n" +
+	"\x04slug\x18\x03 \x01(\tR\x04slug\";\n" +
+	"\rProductOption\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x16\n" +
+	"\x06values\x18\x02 \x03(\tR\x06values\"I\n" +
+	"\x19ProductVariantOptionValue\x12\x16\n" +
+	"\x06option\x18\x01 \x01(\tR\x06option\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value\"\x87\x02\n" +
+	"\x0eProductVariant\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x10\n" +
+	"\x03sku\x18\x02 \x01(\tR\x03sku\x12\x12\n" +
+	"\x04name\x18\x03 \x01(\tR\x04name\x12G\n" +
+	"\roption_values\x18\x04 \x03(\v2\".product.ProductVariantOptionValueR\foptionValues\x12#\n" +
+	"\x05price\x18\b \x01(\v2\r.common.MoneyR\x05price\x12-\n" +
+	"\x06images\x18\x06 \x03(\v2\x15.product.ImageVariantR\x06images\x12\x1c\n" +
+	"\tavailable\x18\a \x01(\x05R\tavailableJ\x04\b\x05\x10\x06\"\x98\x03\n" +
 	"\x15DetailProductResponse\x12(\n" +
 	"\x04base\x18\x01 \x01(\v2\x14.common.BaseResponseR\x04base\x12\x0e\n" +
 	"\x02id\x18\x02 \x01(\tR\x02id\x12\x12\n" +
 	"\x04name\x18\x03 \x01(\tR\x04name\x12 \n" +
-	"\vdescription\x18\x04 \x01(\tR\vdescription\x12\x14\n" +
-	"\x05price\x18\x05 \x01(\x01R\x05price\x12$\n" +
-	"\x0eimage_file_url\x18\x06 \x01(\tR\fimageFileUrl\"\xd8\x01\n" +
+	"\vdescription\x18\x04 \x01(\tR\vdescription\x12#\n" +
+	"\x05price\x18\v \x01(\v2\r.common.MoneyR\x05price\x12-\n" +
+	"\x06images\x18\a \x03(\v2\x15.product.ImageVariantR\x06images\x128\n" +
+	"\n" +
+	"categories\x18\b \x03(\v2\x18.product.ProductCategoryR\n" +
+	"categories\x120\n" +
+	"\aoptions\x18\t \x03(\v2\x16.product.ProductOptionR\aoptions\x123\n" +
+	"\bvariants\x18\n" +
+	" \x03(\v2\x17.product.ProductVariantR\bvariantsJ\x04\b\x05\x10\x06J\x04\b\x06\x10\aR\x0eimage_file_url\"5\n" +
+	"\x0eCategoryIdList\x12#\n" +
+	"\x03ids\x18\x01 \x03(\tB\x11\xbaH\x0e\x92\x01\v\x10\x14\x18\x01\"\x05r\x03\xb0\x01\x01R\x03ids\"\xe9\x02\n" +
 	"\x12EditProductRequest\x12\x1a\n" +
 	"\x02id\x18\x01 \x01(\tB\n" +
 	"\xbaH\ar\x05\x10\x01\x18\xff\x01R\x02id\x12\x1e\n" +
 	"\x04name\x18\x02 \x01(\tB\n" +
 	"\xbaH\ar\x05\x10\x01\x18\xff\x01R\x04name\x12,\n" +
 	"\vdescription\x18\x03 \x01(\tB\n" +
-	"\xbaH\ar\x05\x10\x01\x18\xff\x01R\vdescription\x12$\n" +
-	"\x05price\x18\x04 \x01(\x01B\x0e\xbaH\v\x12\t!\x00\x00\x00\x00\x00\x00\x00\x00R\x05price\x122\n" +
+	"\xbaH\ar\x05\x10\x01\x18\xff\x01R\vdescription\x122\n" +
 	"\x0fimage_file_name\x18\x05 \x01(\tB\n" +
-	"\xbaH\ar\x05\x10\x01\x18\xff\x01R\rimageFileName\"O\n" +
+	"\xbaH\ar\x05\x10\x01\x18\xff\x01R\rimageFileName\x12m\n" +
+	"\x05price\x18\a \x01(\v2\r.common.MoneyBH\xbaHE\xba\x01?\n" +
+	"\x0eprice.positive\x12\x1cprice must be greater than 0\x1a\x0fthis.amount > 0\xc8\x01\x01R\x05price\x12:\n" +
+	"\fcategory_ids\x18\b \x01(\v2\x17.product.CategoryIdListR\vcategoryIdsJ\x04\b\x04\x10\x05J\x04\b\x06\x10\a\"O\n" +
 	"\x13EditProductResponse\x12(\n" +
 	"\x04base\x18\x01 \x01(\v2\x14.common.BaseResponseR\x04base\x12\x0e\n" +
-	"\x02id\x18\x02 \x01(\tR\x02id2\xfa\x01\n" +
-	"\x0eProductService\x12N\n" +
-	"\rCreateProduct\x12\x1d.product.CreateProductRequest\x1a\x1e.product.CreateProductResponse\x12N\n" +
-	"\rDetailProduct\x12\x1d.product.DetailProductRequest\x1a\x1e.product.DetailProductResponse\x12H\n" +
-	"\vEditProduct\x12\x1b.product.EditProductRequest\x1a\x1c.product.EditProductResponseB-Z+github.com/aldngrha/ecommerce-be/pb/productb\x06proto3"
+	"\x02id\x18\x02 \x01(\tR\x02id\"\xa6\x03\n" +
+	"\x13ListProductsRequest\x12 \n" +
+	"\x06cursor\x18\x01 \x01(\tB\b\xbaH\x05r\x03\x18\x80\x04R\x06cursor\x12\x1f\n" +
+	"\x05limit\x18\x02 \x01(\x05B\t\xbaH\x06\x1a\x04\x18d(\x00R\x05limit\x12\x1c\n" +
+	"\x04name\x18\x03 \x01(\tB\b\xbaH\x05r\x03\x18\xff\x01R\x04name\x12)\n" +
+	"\tmin_price\x18\t \x01(\x03B\a\xbaH\x04\"\x02(\x00H\x00R\bminPrice\x88\x01\x01\x12)\n" +
+	"\tmax_price\x18\n" +
+	" \x01(\x03B\a\xbaH\x04\"\x02(\x00H\x01R\bmaxPrice\x88\x01\x01\x129\n" +
+	"\asort_by\x18\x06 \x01(\x0e2\x16.product.ProductSortByB\b\xbaH\x05\x82\x01\x02\x10\x01R\x06sortBy\x12G\n" +
+	"\x0esort_direction\x18\a \x01(\x0e2\x16.product.SortDirectionB\b\xbaH\x05\x82\x01\x02\x10\x01R\rsortDirection\x12,\n" +
+	"\vcategory_id\x18\b \x01(\tB\v\xbaH\b\xd8\x01\x01r\x03\xb0\x01\x01R\n" +
+	"categoryIdB\f\n" +
+	"\n" +
+	"_min_priceB\f\n" +
+	"\n" +
+	"_max_priceJ\x04\b\x04\x10\x05J\x04\b\x05\x10\x06\"\xa9\x01\n" +
+	"\x10ListProductsItem\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12 \n" +
+	"\vdescription\x18\x03 \x01(\tR\vdescription\x12$\n" +
+	"\x0eimage_file_url\x18\x05 \x01(\tR\fimageFileUrl\x12#\n" +
+	"\x05price\x18\x06 \x01(\v2\r.common.MoneyR\x05priceJ\x04\b\x04\x10\x05\"\xad\x01\n" +
+	"\x14ListProductsResponse\x12(\n" +
+	"\x04base\x18\x01 \x01(\v2\x14.common.BaseResponseR\x04base\x12/\n" +
+	"\x05items\x18\x02 \x03(\v2\x19.product.ListProductsItemR\x05items\x12\x1f\n" +
+	"\vnext_cursor\x18\x03 \x01(\tR\n" +
+	"nextCursor\x12\x19\n" +
+	"\bhas_next\x18\x04 \x01(\bR\ahasNext\"2\n" +
+	"\x14DeleteProductRequest\x12\x1a\n" +
+	"\x02id\x18\x01 \x01(\tB\n" +
+	"\xbaH\ar\x05\x10\x01\x18\xff\x01R\x02id\"Q\n" +
+	"\x15DeleteProductResponse\x12(\n" +
+	"\x04base\x18\x01 \x01(\v2\x14.common.BaseResponseR\x04base\x12\x0e\n" +
+	"\x02id\x18\x02 \x01(\tR\x02id\"3\n" +
+	"\x15RestoreProductRequest\x12\x1a\n" +
+	"\x02id\x18\x01 \x01(\tB\n" +
+	"\xbaH\ar\x05\x10\x01\x18\xff\x01R\x02id\"R\n" +
+	"\x16RestoreProductResponse\x12(\n" +
+	"\x04base\x18\x01 \x01(\v2\x14.common.BaseResponseR\x04base\x12\x0e\n" +
+	"\x02id\x18\x02 \x01(\tR\x02id\"}\n" +
+	"\x1aListDeletedProductsRequest\x12 \n" +
+	"\x06cursor\x18\x01 \x01(\tB\b\xbaH\x05r\x03\x18\x80\x04R\x06cursor\x12\x1f\n" +
+	"\x05limit\x18\x02 \x01(\x05B\t\xbaH\x06\x1a\x04\x18d(\x00R\x05limit\x12\x1c\n" +
+	"\x04name\x18\x03 \x01(\tB\b\xbaH\x05r\x03\x18\xff\x01R\x04name\"\x8a\x02\n" +
+	"\x17ListDeletedProductsItem\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12 \n" +
+	"\vdescription\x18\x03 \x01(\tR\vdescription\x12$\n" +
+	"\x0eimage_file_url\x18\x05 \x01(\tR\fimageFileUrl\x129\n" +
+	"\n" +
+	"deleted_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\tdeletedAt\x12\x1d\n" +
+	"\n" +
+	"deleted_by\x18\a \x01(\tR\tdeletedBy\x12#\n" +
+	"\x05price\x18\b \x01(\v2\r.common.MoneyR\x05priceJ\x04\b\x04\x10\x05\"\xbb\x01\n" +
+	"\x1bListDeletedProductsResponse\x12(\n" +
+	"\x04base\x18\x01 \x01(\v2\x14.common.BaseResponseR\x04base\x126\n" +
+	"\x05items\x18\x02 \x03(\v2 .product.ListDeletedProductsItemR\x05items\x12\x1f\n" +
+	"\vnext_cursor\x18\x03 \x01(\tR\n" +
+	"nextCursor\x12\x19\n" +
+	"\bhas_next\x18\x04 \x01(\bR\ahasNext\"a\n" +
+	"\x12ProductOptionInput\x12\x1d\n" +
+	"\x04name\x18\x01 \x01(\tB\t\xbaH\x06r\x04\x10\x01\x182R\x04name\x12,\n" +
+	"\x06values\x18\x02 \x03(\tB\x14\xbaH\x11\x92\x01\x0e\b\x01\x102\x18\x01\"\x06r\x04\x10\x01\x182R\x06values\"\xae\x02\n" +
+	"\x13ProductVariantInput\x12:\n" +
+	"\x03sku\x18\x01 \x01(\tB(\xbaH%r#2!^[A-Za-z0-9][A-Za-z0-9._-]{0,63}$R\x03sku\x127\n" +
+	"\roption_values\x18\x02 \x03(\tB\x12\xbaH\x0f\x92\x01\f\b\x01\x10\x03\"\x06r\x04\x10\x01\x182R\foptionValues\x120\n" +
+	"\x0fimage_file_name\x18\x04 \x01(\tB\b\xbaH\x05r\x03\x18\xff\x01R\rimageFileName\x12j\n" +
+	"\x05price\x18\x05 \x01(\v2\r.common.MoneyBE\xbaHB\xba\x01?\n" +
+	"\x0eprice.positive\x12\x1cprice must be greater than 0\x1a\x0fthis.amount > 0R\x05priceJ\x04\b\x03\x10\x04\"\xcb\x01\n" +
+	"\x19SetProductVariantsRequest\x12)\n" +
+	"\n" +
+	"product_id\x18\x01 \x01(\tB\n" +
+	"\xbaH\ar\x05\x10\x01\x18\xff\x01R\tproductId\x12?\n" +
+	"\aoptions\x18\x02 \x03(\v2\x1b.product.ProductOptionInputB\b\xbaH\x05\x92\x01\x02\x10\x03R\aoptions\x12B\n" +
+	"\bvariants\x18\x03 \x03(\v2\x1c.product.ProductVariantInputB\b\xbaH\x05\x92\x01\x02\x10dR\bvariants\"e\n" +
+	"\x1aSetProductVariantsResponse\x12(\n" +
+	"\x04base\x18\x01 \x01(\v2\x14.common.BaseResponseR\x04base\x12\x1d\n" +
+	"\n" +
+	"product_id\x18\x02 \x01(\tR\tproductId*\x85\x01\n" +
+	"\rProductSortBy\x12\x1f\n" +
+	"\x1bPRODUCT_SORT_BY_UNSPECIFIED\x10\x00\x12\x1e\n" +
+	"\x1aPRODUCT_SORT_BY_CREATED_AT\x10\x01\x12\x19\n" +
+	"\x15PRODUCT_SORT_BY_PRICE\x10\x02\x12\x18\n" +
+	"\x14PRODUCT_SORT_BY_NAME\x10\x03*`\n" +
+	"\rSortDirection\x12\x1e\n" +
+	"\x1aSORT_DIRECTION_UNSPECIFIED\x10\x00\x12\x16\n" +
+	"\x12SORT_DIRECTION_ASC\x10\x01\x12\x17\n" +
+	"\x13SORT_DIRECTION_DESC\x10\x022\xc2\x06\n" +
+	"\x0eProductService\x12c\n" +
+	"\rCreateProduct\x12\x1d.product.CreateProductRequest\x1a\x1e.product.CreateProductResponse\"\x13\x8a\xb5\x18\x0f\x1a\rproduct:write\x12S\n" +
+	"\fListProducts\x12\x1c.product.ListProductsRequest\x1a\x1d.product.ListProductsResponse\"\x06\x8a\xb5\x18\x02\b\x01\x12V\n" +
+	"\rDetailProduct\x12\x1d.product.DetailProductRequest\x1a\x1e.product.DetailProductResponse\"\x06\x8a\xb5\x18\x02\b\x01\x12]\n" +
+	"\vEditProduct\x12\x1b.product.EditProductRequest\x1a\x1c.product.EditProductResponse\"\x13\x8a\xb5\x18\x0f\x1a\rproduct:write\x12d\n" +
+	"\rDeleteProduct\x12\x1d.product.DeleteProductRequest\x1a\x1e.product.DeleteProductResponse\"\x14\x8a\xb5\x18\x10\x1a\x0eproduct:delete\x12g\n" +
+	"\x0eRestoreProduct\x12\x1e.product.RestoreProductRequest\x1a\x1f.product.RestoreProductResponse\"\x14\x8a\xb5\x18\x10\x1a\x0eproduct:delete\x12|\n" +
+	"\x13ListDeletedProducts\x12#.product.ListDeletedProductsRequest\x1a$.product.ListDeletedProductsResponse\"\x1a\x8a\xb5\x18\x16\x1a\x14product:read_deleted\x12r\n" +
+	"\x12SetProductVariants\x12\".product.SetProductVariantsRequest\x1a#.product.SetProductVariantsResponse\"\x13\x8a\xb5\x18\x0f\x1a\rproduct:writeB-Z+github.com/aldngrha/ecommerce-be/pb/productb\x06proto3"
 
 var (
 	file_product_product_proto_rawDescOnce sync.Once
@@ -455,31 +1981,92 @@ func file_product_product_proto_rawDescGZIP() []byte {
 	return file_product_product_proto_rawDescData
 }
 
-var file_product_product_proto_msgTypes = make([]protoimpl.MessageInfo, 6)
+var file_product_product_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
+var file_product_product_proto_msgTypes = make([]protoimpl.MessageInfo, 26)
 var file_product_product_proto_goTypes = []any{
-	(*CreateProductRequest)(nil),  // 0: product.CreateProductRequest
-	(*CreateProductResponse)(nil), // 1: product.CreateProductResponse
-	(*DetailProductRequest)(nil),  // 2: product.DetailProductRequest
-	(*DetailProductResponse)(nil), // 3: product.DetailProductResponse
-	(*EditProductRequest)(nil),    // 4: product.EditProductRequest
-	(*EditProductResponse)(nil),   // 5: product.EditProductResponse
-	(*common.BaseResponse)(nil),   // 6: common.BaseResponse
+	(ProductSortBy)(0),                  // 0: product.ProductSortBy
+	(SortDirection)(0),                  // 1: product.SortDirection
+	(*CreateProductRequest)(nil),        // 2: product.CreateProductRequest
+	(*CreateProductResponse)(nil),       // 3: product.CreateProductResponse
+	(*DetailProductRequest)(nil),        // 4: product.DetailProductRequest
+	(*ImageVariant)(nil),                // 5: product.ImageVariant
+	(*ProductCategory)(nil),             // 6: product.ProductCategory
+	(*ProductOption)(nil),               // 7: product.ProductOption
+	(*ProductVariantOptionValue)(nil),   // 8: product.ProductVariantOptionValue
+	(*ProductVariant)(nil),              // 9: product.ProductVariant
+	(*DetailProductResponse)(nil),       // 10: product.DetailProductResponse
+	(*CategoryIdList)(nil),              // 11: product.CategoryIdList
+	(*EditProductRequest)(nil),          // 12: product.EditProductRequest
+	(*EditProductResponse)(nil),         // 13: product.EditProductResponse
+	(*ListProductsRequest)(nil),         // 14: product.ListProductsRequest
+	(*ListProductsItem)(nil),            // 15: product.ListProductsItem
+	(*ListProductsResponse)(nil),        // 16: product.ListProductsResponse
+	(*DeleteProductRequest)(nil),        // 17: product.DeleteProductRequest
+	(*DeleteProductResponse)(nil),       // 18: product.DeleteProductResponse
+	(*RestoreProductRequest)(nil),       // 19: product.RestoreProductRequest
+	(*RestoreProductResponse)(nil),      // 20: product.RestoreProductResponse
+	(*ListDeletedProductsRequest)(nil),  // 21: product.ListDeletedProductsRequest
+	(*ListDeletedProductsItem)(nil),     // 22: product.ListDeletedProductsItem
+	(*ListDeletedProductsResponse)(nil), // 23: product.ListDeletedProductsResponse
+	(*ProductOptionInput)(nil),          // 24: product.ProductOptionInput
+	(*ProductVariantInput)(nil),         // 25: product.ProductVariantInput
+	(*SetProductVariantsRequest)(nil),   // 26: product.SetProductVariantsRequest
+	(*SetProductVariantsResponse)(nil),  // 27: product.SetProductVariantsResponse
+	(*common.Money)(nil),                // 28: common.Money
+	(*common.BaseResponse)(nil),         // 29: common.BaseResponse
+	(*timestamppb.Timestamp)(nil),       // 30: google.protobuf.Timestamp
 }
 var file_product_product_proto_depIdxs = []int32{
-	6, // 0: product.CreateProductResponse.base:type_name -> common.BaseResponse
-	6, // 1: product.DetailProductResponse.base:type_name -> common.BaseResponse
-	6, // 2: product.EditProductResponse.base:type_name -> common.BaseResponse
-	0, // 3: product.ProductService.CreateProduct:input_type -> product.CreateProductRequest
-	2, // 4: product.ProductService.DetailProduct:input_type -> product.DetailProductRequest
-	4, // 5: product.ProductService.EditProduct:input_type -> product.EditProductRequest
-	1, // 6: product.ProductService.CreateProduct:output_type -> product.CreateProductResponse
-	3, // 7: product.ProductService.DetailProduct:output_type -> product.DetailProductResponse
-	5, // 8: product.ProductService.EditProduct:output_type -> product.EditProductResponse
-	6, // [6:9] is the sub-list for method output_type
-	3, // [3:6] is the sub-list for method input_type
-	3, // [3:3] is the sub-list for extension type_name
-	3, // [3:3] is the sub-list for extension extendee
-	0, // [0:3] is the sub-list for field type_name
+	28, // 0: product.CreateProductRequest.price:type_name -> common.Money
+	29, // 1: product.CreateProductResponse.base:type_name -> common.BaseResponse
+	8,  // 2: product.ProductVariant.option_values:type_name -> product.ProductVariantOptionValue
+	28, // 3: product.ProductVariant.price:type_name -> common.Money
+	5,  // 4: product.ProductVariant.images:type_name -> product.ImageVariant
+	29, // 5: product.DetailProductResponse.base:type_name -> common.BaseResponse
+	28, // 6: product.DetailProductResponse.price:type_name -> common.Money
+	5,  // 7: product.DetailProductResponse.images:type_name -> product.ImageVariant
+	6,  // 8: product.DetailProductResponse.categories:type_name -> product.ProductCategory
+	7,  // 9: product.DetailProductResponse.options:type_name -> product.ProductOption
+	9,  // 10: product.DetailProductResponse.variants:type_name -> product.ProductVariant
+	28, // 11: product.EditProductRequest.price:type_name -> common.Money
+	11, // 12: product.EditProductRequest.category_ids:type_name -> product.CategoryIdList
+	29, // 13: product.EditProductResponse.base:type_name -> common.BaseResponse
+	0,  // 14: product.ListProductsRequest.sort_by:type_name -> product.ProductSortBy
+	1,  // 15: product.ListProductsRequest.sort_direction:type_name -> product.SortDirection
+	28, // 16: product.ListProductsItem.price:type_name -> common.Money
+	29, // 17: product.ListProductsResponse.base:type_name -> common.BaseResponse
+	15, // 18: product.ListProductsResponse.items:type_name -> product.ListProductsItem
+	29, // 19: product.DeleteProductResponse.base:type_name -> common.BaseResponse
+	29, // 20: product.RestoreProductResponse.base:type_name -> common.BaseResponse
+	30, // 21: product.ListDeletedProductsItem.deleted_at:type_name -> google.protobuf.Timestamp
+	28, // 22: product.ListDeletedProductsItem.price:type_name -> common.Money
+	29, // 23: product.ListDeletedProductsResponse.base:type_name -> common.BaseResponse
+	22, // 24: product.ListDeletedProductsResponse.items:type_name -> product.ListDeletedProductsItem
+	28, // 25: product.ProductVariantInput.price:type_name -> common.Money
+	24, // 26: product.SetProductVariantsRequest.options:type_name -> product.ProductOptionInput
+	25, // 27: product.SetProductVariantsRequest.variants:type_name -> product.ProductVariantInput
+	29, // 28: product.SetProductVariantsResponse.base:type_name -> common.BaseResponse
+	2,  // 29: product.ProductService.CreateProduct:input_type -> product.CreateProductRequest
+	14, // 30: product.ProductService.ListProducts:input_type -> product.ListProductsRequest
+	4,  // 31: product.ProductService.DetailProduct:input_type -> product.DetailProductRequest
+	12, // 32: product.ProductService.EditProduct:input_type -> product.EditProductRequest
+	17, // 33: product.ProductService.DeleteProduct:input_type -> product.DeleteProductRequest
+	19, // 34: product.ProductService.RestoreProduct:input_type -> product.RestoreProductRequest
+	21, // 35: product.ProductService.ListDeletedProducts:input_type -> product.ListDeletedProductsRequest
+	26, // 36: product.ProductService.SetProductVariants:input_type -> product.SetProductVariantsRequest
+	3,  // 37: product.ProductService.CreateProduct:output_type -> product.CreateProductResponse
+	16, // 38: product.ProductService.ListProducts:output_type -> product.ListProductsResponse
+	10, // 39: product.ProductService.DetailProduct:output_type -> product.DetailProductResponse
+	13, // 40: product.ProductService.EditProduct:output_type -> product.EditProductResponse
+	18, // 41: product.ProductService.DeleteProduct:output_type -> product.DeleteProductResponse
+	20, // 42: product.ProductService.RestoreProduct:output_type -> product.RestoreProductResponse
+	23, // 43: product.ProductService.ListDeletedProducts:output_type -> product.ListDeletedProductsResponse
+	27, // 44: product.ProductService.SetProductVariants:output_type -> product.SetProductVariantsResponse
+	37, // [37:45] is the sub-list for method output_type
+	29, // [29:37] is the sub-list for method input_type
+	29, // [29:29] is the sub-list for extension type_name
+	29, // [29:29] is the sub-list for extension extendee
+	0,  // [0:29] is the sub-list for field type_name
 }
 
 func init() { file_product_product_proto_init() }
@@ -487,18 +2074,20 @@ func file_product_product_proto_init() {
 	if File_product_product_proto != nil {
 		return
 	}
+	file_product_product_proto_msgTypes[12].OneofWrappers = []any{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_product_product_proto_rawDesc), len(file_product_product_proto_rawDesc)),
-			NumEnums:      0,
-			NumMessages:   6,
+			NumEnums:      2,
+			NumMessages:   26,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
 		GoTypes:           file_product_product_proto_goTypes,
 		DependencyIndexes: file_product_product_proto_depIdxs,
+		EnumInfos:         file_product_product_proto_enumTypes,
 		MessageInfos:      file_product_product_proto_msgTypes,
 	}.Build()
 	File_product_product_proto = out.File
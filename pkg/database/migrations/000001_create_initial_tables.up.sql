@@ -0,0 +1,49 @@
+CREATE TABLE IF NOT EXISTS roles (
+    id         UUID PRIMARY KEY,
+    name       VARCHAR(100) NOT NULL,
+    code       VARCHAR(50)  NOT NULL UNIQUE,
+    created_at TIMESTAMPTZ  NOT NULL DEFAULT now(),
+    created_by VARCHAR(255),
+    updated_at TIMESTAMPTZ,
+    updated_by VARCHAR(255),
+    deleted_at TIMESTAMPTZ,
+    deleted_by VARCHAR(255),
+    is_deleted BOOLEAN      NOT NULL DEFAULT false
+);
+
+INSERT INTO roles (id, name, code, created_by)
+VALUES ('6b1c1f3e-6c2a-4a39-9b55-0f5b7a0c1a01', 'Administrator', 'admin', 'system'),
+       ('6b1c1f3e-6c2a-4a39-9b55-0f5b7a0c1a02', 'Customer', 'customer', 'system')
+ON CONFLICT (code) DO NOTHING;
+
+CREATE TABLE IF NOT EXISTS users (
+    id         UUID PRIMARY KEY,
+    full_name  VARCHAR(100) NOT NULL,
+    email      VARCHAR(100) NOT NULL UNIQUE,
+    role_code  VARCHAR(50)  NOT NULL REFERENCES roles (code),
+    password   VARCHAR(255) NOT NULL,
+    created_at TIMESTAMPTZ  NOT NULL DEFAULT now(),
+    created_by VARCHAR(255),
+    updated_at TIMESTAMPTZ,
+    updated_by VARCHAR(255),
+    deleted_at TIMESTAMPTZ,
+    deleted_by VARCHAR(255),
+    is_deleted BOOLEAN      NOT NULL DEFAULT false
+);
+
+CREATE TABLE IF NOT EXISTS products (
+    id              UUID PRIMARY KEY,
+    name            VARCHAR(255)   NOT NULL,
+    description     VARCHAR(255)   NOT NULL,
+    price           NUMERIC(15, 2) NOT NULL,
+    image_file_name VARCHAR(255)   NOT NULL,
+    created_at      TIMESTAMPTZ    NOT NULL DEFAULT now(),
+    created_by      VARCHAR(255),
+    updated_at      TIMESTAMPTZ,
+    updated_by      VARCHAR(255),
+    deleted_at      TIMESTAMPTZ,
+    deleted_by      VARCHAR(255),
+    is_deleted      BOOLEAN        NOT NULL DEFAULT false
+);
+
+CREATE INDEX IF NOT EXISTS idx_products_created_at ON products (created_at, id) WHERE is_deleted = false;
@@ -0,0 +1,25 @@
+CREATE TABLE IF NOT EXISTS permissions (
+    code        VARCHAR(100) PRIMARY KEY,
+    description VARCHAR(255) NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS role_permissions (
+    role_code       VARCHAR(50)  NOT NULL REFERENCES roles (code),
+    permission_code VARCHAR(100) NOT NULL REFERENCES permissions (code),
+    created_at      TIMESTAMPTZ  NOT NULL DEFAULT now(),
+    created_by      VARCHAR(255),
+    PRIMARY KEY (role_code, permission_code)
+);
+
+INSERT INTO permissions (code, description)
+VALUES ('product:write', 'Create and edit products'),
+       ('product:delete', 'Delete and restore products'),
+       ('product:read_deleted', 'List deleted products'),
+       ('order:refund', 'Refund orders'),
+       ('role:manage', 'Create roles, change their permissions and assign them to users')
+ON CONFLICT (code) DO NOTHING;
+
+INSERT INTO role_permissions (role_code, permission_code, created_by)
+SELECT 'admin', code, 'system'
+FROM permissions
+ON CONFLICT DO NOTHING;
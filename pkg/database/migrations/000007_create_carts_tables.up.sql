@@ -0,0 +1,16 @@
+CREATE TABLE IF NOT EXISTS carts (
+    id         UUID PRIMARY KEY,
+    user_id    UUID        NOT NULL UNIQUE REFERENCES users (id),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE TABLE IF NOT EXISTS cart_items (
+    id         UUID PRIMARY KEY,
+    cart_id    UUID        NOT NULL REFERENCES carts (id) ON DELETE CASCADE,
+    product_id UUID        NOT NULL REFERENCES products (id),
+    quantity   INTEGER     NOT NULL CHECK (quantity > 0),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    UNIQUE (cart_id, product_id)
+);
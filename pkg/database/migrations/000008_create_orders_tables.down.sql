@@ -0,0 +1,5 @@
+DELETE FROM role_permissions WHERE permission_code = 'order:manage';
+DELETE FROM permissions WHERE code = 'order:manage';
+
+DROP TABLE IF EXISTS order_items;
+DROP TABLE IF EXISTS orders;
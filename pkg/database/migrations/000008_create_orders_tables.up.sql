@@ -0,0 +1,34 @@
+CREATE TABLE IF NOT EXISTS orders (
+    id            UUID PRIMARY KEY,
+    user_id       UUID           NOT NULL REFERENCES users (id),
+    status        VARCHAR(32)    NOT NULL,
+    total         NUMERIC(15, 2) NOT NULL,
+    cancel_reason VARCHAR(255),
+    created_at    TIMESTAMPTZ    NOT NULL DEFAULT now(),
+    created_by    VARCHAR(255),
+    updated_at    TIMESTAMPTZ,
+    updated_by    VARCHAR(255),
+    CONSTRAINT orders_status_check CHECK (status IN ('pending_payment', 'paid', 'fulfilled', 'delivered', 'cancelled', 'refunded'))
+);
+
+CREATE INDEX IF NOT EXISTS idx_orders_user_id_created_at ON orders (user_id, created_at DESC, id DESC);
+
+CREATE TABLE IF NOT EXISTS order_items (
+    id           UUID PRIMARY KEY,
+    order_id     UUID           NOT NULL REFERENCES orders (id) ON DELETE CASCADE,
+    product_id   UUID           NOT NULL REFERENCES products (id),
+    product_name VARCHAR(255)   NOT NULL,
+    unit_price   NUMERIC(15, 2) NOT NULL,
+    quantity     INTEGER        NOT NULL CHECK (quantity > 0),
+    subtotal     NUMERIC(15, 2) NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_order_items_order_id ON order_items (order_id);
+
+INSERT INTO permissions (code, description)
+VALUES ('order:manage', 'Move orders through fulfilment and delivery')
+ON CONFLICT (code) DO NOTHING;
+
+INSERT INTO role_permissions (role_code, permission_code, created_by)
+VALUES ('admin', 'order:manage', 'system')
+ON CONFLICT DO NOTHING;
@@ -0,0 +1,5 @@
+DELETE FROM role_permissions WHERE permission_code = 'inventory:manage';
+DELETE FROM permissions WHERE code = 'inventory:manage';
+
+DROP TABLE IF EXISTS stock_reservations;
+DROP TABLE IF EXISTS inventory;
@@ -0,0 +1,40 @@
+CREATE TABLE IF NOT EXISTS inventory (
+    product_id UUID PRIMARY KEY REFERENCES products (id),
+    on_hand    INTEGER     NOT NULL DEFAULT 0 CHECK (on_hand >= 0),
+    reserved   INTEGER     NOT NULL DEFAULT 0 CHECK (reserved >= 0),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    updated_by VARCHAR(255),
+    CONSTRAINT inventory_reserved_within_on_hand CHECK (reserved <= on_hand)
+);
+
+-- Existing products start with no stock, since the real counts are not known here. They
+-- cannot be bought until stock is loaded: after migrating and before opening checkout, an
+-- admin with inventory:manage sets the counted stock of every product with
+-- InventoryService.SetStock.
+INSERT INTO inventory (product_id, on_hand, reserved, updated_by)
+SELECT id, 0, 0, 'system'
+FROM products
+ON CONFLICT (product_id) DO NOTHING;
+
+CREATE TABLE IF NOT EXISTS stock_reservations (
+    id          UUID PRIMARY KEY,
+    order_id    UUID        NOT NULL,
+    product_id  UUID        NOT NULL REFERENCES products (id),
+    quantity    INTEGER     NOT NULL CHECK (quantity > 0),
+    status      VARCHAR(16) NOT NULL,
+    expires_at  TIMESTAMPTZ NOT NULL,
+    created_at  TIMESTAMPTZ NOT NULL DEFAULT now(),
+    resolved_at TIMESTAMPTZ,
+    CONSTRAINT stock_reservations_status_check CHECK (status IN ('active', 'committed', 'released'))
+);
+
+CREATE INDEX IF NOT EXISTS idx_stock_reservations_order_id ON stock_reservations (order_id);
+CREATE INDEX IF NOT EXISTS idx_stock_reservations_active_expires_at ON stock_reservations (expires_at) WHERE status = 'active';
+
+INSERT INTO permissions (code, description)
+VALUES ('inventory:manage', 'Set and adjust product stock')
+ON CONFLICT (code) DO NOTHING;
+
+INSERT INTO role_permissions (role_code, permission_code, created_by)
+VALUES ('admin', 'inventory:manage', 'system')
+ON CONFLICT DO NOTHING;
@@ -0,0 +1,22 @@
+CREATE TABLE IF NOT EXISTS payment_intents (
+    id                 UUID PRIMARY KEY,
+    order_id           UUID           NOT NULL REFERENCES orders (id),
+    amount             NUMERIC(15, 2) NOT NULL,
+    currency           VARCHAR(3)     NOT NULL,
+    status             VARCHAR(32)    NOT NULL,
+    provider           VARCHAR(50)    NOT NULL,
+    provider_reference VARCHAR(255) UNIQUE,
+    failure_reason     VARCHAR(255),
+    created_at         TIMESTAMPTZ    NOT NULL DEFAULT now(),
+    created_by         VARCHAR(255),
+    updated_at         TIMESTAMPTZ,
+    updated_by         VARCHAR(255),
+    CONSTRAINT payment_intents_status_check CHECK (status IN ('pending', 'authorized', 'captured', 'failed', 'refunded'))
+);
+
+CREATE INDEX IF NOT EXISTS idx_payment_intents_order_id ON payment_intents (order_id);
+
+-- at most one payment attempt per order may be in flight or succeeded, so a double
+-- submit cannot charge twice
+CREATE UNIQUE INDEX IF NOT EXISTS uq_payment_intents_order_id_open ON payment_intents (order_id)
+    WHERE status IN ('pending', 'authorized', 'captured');
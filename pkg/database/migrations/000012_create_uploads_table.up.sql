@@ -0,0 +1,11 @@
+CREATE TABLE IF NOT EXISTS uploads (
+    id           UUID PRIMARY KEY,
+    user_id      UUID         NOT NULL REFERENCES users (id),
+    file_name    VARCHAR(255) NOT NULL UNIQUE,
+    content_type VARCHAR(100) NOT NULL,
+    -- size of the file as uploaded, counted against the user's quota
+    size_bytes   BIGINT       NOT NULL,
+    created_at   TIMESTAMPTZ  NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS idx_uploads_user_id_created_at ON uploads (user_id, created_at);
@@ -0,0 +1,11 @@
+DROP INDEX IF EXISTS idx_uploads_status;
+
+DELETE FROM uploads WHERE user_id IS NULL;
+
+ALTER TABLE uploads ALTER COLUMN user_id SET NOT NULL;
+
+ALTER TABLE uploads
+    DROP CONSTRAINT IF EXISTS uploads_status_check,
+    DROP COLUMN IF EXISTS deleted_at,
+    DROP COLUMN IF EXISTS released_at,
+    DROP COLUMN IF EXISTS status;
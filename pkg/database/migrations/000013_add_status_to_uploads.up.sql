@@ -0,0 +1,20 @@
+ALTER TABLE uploads
+    ADD COLUMN IF NOT EXISTS status      VARCHAR(16) NOT NULL DEFAULT 'pending',
+    ADD COLUMN IF NOT EXISTS released_at TIMESTAMPTZ,
+    ADD COLUMN IF NOT EXISTS deleted_at  TIMESTAMPTZ,
+    ADD CONSTRAINT uploads_status_check CHECK (status IN ('pending', 'attached', 'orphaned', 'deleted'));
+
+-- images referenced before uploads were tracked have no uploader
+ALTER TABLE uploads ALTER COLUMN user_id DROP NOT NULL;
+
+UPDATE uploads
+SET status = 'attached'
+WHERE EXISTS (SELECT 1 FROM products WHERE products.image_file_name = uploads.file_name);
+
+INSERT INTO uploads (id, user_id, file_name, content_type, size_bytes, status, created_at)
+SELECT md5(image_file_name)::uuid, NULL, image_file_name, '', 0, 'attached', min(created_at)
+FROM products
+GROUP BY image_file_name
+ON CONFLICT (file_name) DO NOTHING;
+
+CREATE INDEX IF NOT EXISTS idx_uploads_status ON uploads (status);
@@ -0,0 +1,5 @@
+DELETE FROM role_permissions WHERE permission_code = 'category:manage';
+DELETE FROM permissions WHERE code = 'category:manage';
+
+DROP TABLE IF EXISTS product_categories;
+DROP TABLE IF EXISTS categories;
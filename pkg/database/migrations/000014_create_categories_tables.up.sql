@@ -0,0 +1,29 @@
+CREATE TABLE IF NOT EXISTS categories (
+    id         UUID PRIMARY KEY,
+    parent_id  UUID REFERENCES categories (id),
+    name       VARCHAR(100) NOT NULL,
+    slug       VARCHAR(100) NOT NULL UNIQUE,
+    created_at TIMESTAMPTZ  NOT NULL DEFAULT now(),
+    created_by VARCHAR(255) NOT NULL,
+    updated_at TIMESTAMPTZ,
+    updated_by VARCHAR(255),
+    CONSTRAINT categories_not_own_parent CHECK (parent_id <> id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_categories_parent_id ON categories (parent_id);
+
+CREATE TABLE IF NOT EXISTS product_categories (
+    product_id  UUID NOT NULL REFERENCES products (id),
+    category_id UUID NOT NULL REFERENCES categories (id) ON DELETE CASCADE,
+    PRIMARY KEY (product_id, category_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_product_categories_category_id ON product_categories (category_id);
+
+INSERT INTO permissions (code, description)
+VALUES ('category:manage', 'Create, edit and delete product categories')
+ON CONFLICT (code) DO NOTHING;
+
+INSERT INTO role_permissions (role_code, permission_code, created_by)
+VALUES ('admin', 'category:manage', 'system')
+ON CONFLICT DO NOTHING;
@@ -0,0 +1,18 @@
+ALTER TABLE order_items
+    DROP COLUMN IF EXISTS variant_name,
+    DROP COLUMN IF EXISTS sku,
+    DROP COLUMN IF EXISTS variant_id;
+
+-- the old constraint allows one line per product
+DELETE FROM cart_items WHERE variant_id IS NOT NULL;
+DROP INDEX IF EXISTS idx_cart_items_cart_product_variant;
+ALTER TABLE cart_items DROP COLUMN IF EXISTS variant_id;
+ALTER TABLE cart_items ADD CONSTRAINT cart_items_cart_id_product_id_key UNIQUE (cart_id, product_id);
+
+ALTER TABLE stock_reservations DROP COLUMN IF EXISTS variant_id;
+
+DROP TABLE IF EXISTS variant_inventory;
+DROP TABLE IF EXISTS product_variant_values;
+DROP TABLE IF EXISTS product_variants;
+DROP TABLE IF EXISTS product_option_values;
+DROP TABLE IF EXISTS product_options;
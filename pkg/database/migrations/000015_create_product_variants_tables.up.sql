@@ -0,0 +1,61 @@
+CREATE TABLE IF NOT EXISTS product_options (
+    id         UUID PRIMARY KEY,
+    product_id UUID        NOT NULL REFERENCES products (id),
+    name       VARCHAR(50) NOT NULL,
+    position   INTEGER     NOT NULL,
+    UNIQUE (product_id, name)
+);
+
+CREATE TABLE IF NOT EXISTS product_option_values (
+    id        UUID PRIMARY KEY,
+    option_id UUID        NOT NULL REFERENCES product_options (id) ON DELETE CASCADE,
+    value     VARCHAR(50) NOT NULL,
+    position  INTEGER     NOT NULL,
+    UNIQUE (option_id, value)
+);
+
+-- variants are soft deleted so order lines and reservations keep pointing at them
+CREATE TABLE IF NOT EXISTS product_variants (
+    id              UUID PRIMARY KEY,
+    product_id      UUID         NOT NULL REFERENCES products (id),
+    sku             VARCHAR(64)  NOT NULL UNIQUE,
+    price           NUMERIC(15, 2),
+    image_file_name VARCHAR(255),
+    position        INTEGER      NOT NULL,
+    created_at      TIMESTAMPTZ  NOT NULL DEFAULT now(),
+    created_by      VARCHAR(255) NOT NULL,
+    updated_at      TIMESTAMPTZ,
+    updated_by      VARCHAR(255),
+    is_deleted      BOOLEAN      NOT NULL DEFAULT false
+);
+
+CREATE INDEX IF NOT EXISTS idx_product_variants_product_id ON product_variants (product_id) WHERE is_deleted = false;
+CREATE INDEX IF NOT EXISTS idx_product_variants_image_file_name ON product_variants (image_file_name) WHERE is_deleted = false;
+
+CREATE TABLE IF NOT EXISTS product_variant_values (
+    variant_id      UUID NOT NULL REFERENCES product_variants (id) ON DELETE CASCADE,
+    option_value_id UUID NOT NULL REFERENCES product_option_values (id) ON DELETE CASCADE,
+    PRIMARY KEY (variant_id, option_value_id)
+);
+
+-- stock of products with variants is kept per variant
+CREATE TABLE IF NOT EXISTS variant_inventory (
+    variant_id UUID PRIMARY KEY REFERENCES product_variants (id),
+    on_hand    INTEGER     NOT NULL DEFAULT 0 CHECK (on_hand >= 0),
+    reserved   INTEGER     NOT NULL DEFAULT 0 CHECK (reserved >= 0),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    updated_by VARCHAR(255),
+    CONSTRAINT variant_inventory_reserved_within_on_hand CHECK (reserved <= on_hand)
+);
+
+ALTER TABLE stock_reservations ADD COLUMN IF NOT EXISTS variant_id UUID REFERENCES product_variants (id);
+
+ALTER TABLE cart_items ADD COLUMN IF NOT EXISTS variant_id UUID REFERENCES product_variants (id);
+ALTER TABLE cart_items DROP CONSTRAINT IF EXISTS cart_items_cart_id_product_id_key;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_cart_items_cart_product_variant
+    ON cart_items (cart_id, product_id, COALESCE(variant_id, '00000000-0000-0000-0000-000000000000'::uuid));
+
+ALTER TABLE order_items
+    ADD COLUMN IF NOT EXISTS variant_id   UUID REFERENCES product_variants (id),
+    ADD COLUMN IF NOT EXISTS sku          VARCHAR(64),
+    ADD COLUMN IF NOT EXISTS variant_name VARCHAR(255);
@@ -0,0 +1,20 @@
+-- every supported currency has a minor unit of a hundredth (see money.supportedCurrencies),
+-- so dividing by 100 restores the NUMERIC(15, 2) amounts exactly
+ALTER TABLE payment_intents RENAME COLUMN amount_minor TO amount;
+ALTER TABLE payment_intents ALTER COLUMN amount TYPE NUMERIC(15, 2) USING amount / 100.0;
+
+ALTER TABLE order_items RENAME COLUMN subtotal_minor TO subtotal;
+ALTER TABLE order_items ALTER COLUMN subtotal TYPE NUMERIC(15, 2) USING subtotal / 100.0;
+ALTER TABLE order_items RENAME COLUMN unit_price_minor TO unit_price;
+ALTER TABLE order_items ALTER COLUMN unit_price TYPE NUMERIC(15, 2) USING unit_price / 100.0;
+
+ALTER TABLE orders RENAME COLUMN total_minor TO total;
+ALTER TABLE orders ALTER COLUMN total TYPE NUMERIC(15, 2) USING total / 100.0;
+ALTER TABLE orders DROP COLUMN IF EXISTS currency;
+
+ALTER TABLE product_variants RENAME COLUMN price_minor TO price;
+ALTER TABLE product_variants ALTER COLUMN price TYPE NUMERIC(15, 2) USING price / 100.0;
+
+ALTER TABLE products RENAME COLUMN price_minor TO price;
+ALTER TABLE products ALTER COLUMN price TYPE NUMERIC(15, 2) USING price / 100.0;
+ALTER TABLE products DROP COLUMN IF EXISTS currency;
@@ -0,0 +1,28 @@
+-- Money moves from NUMERIC to integer minor units with an explicit ISO 4217 currency.
+-- Every amount stored so far is IDR, whose minor unit is a hundredth, so the existing
+-- NUMERIC(15, 2) values convert exactly.
+
+ALTER TABLE products ADD COLUMN IF NOT EXISTS currency VARCHAR(3);
+UPDATE products SET currency = 'IDR' WHERE currency IS NULL;
+ALTER TABLE products ALTER COLUMN currency SET NOT NULL;
+ALTER TABLE products ALTER COLUMN price TYPE BIGINT USING ROUND(price * 100)::BIGINT;
+ALTER TABLE products RENAME COLUMN price TO price_minor;
+
+-- variant prices are in the currency of their product
+ALTER TABLE product_variants ALTER COLUMN price TYPE BIGINT USING ROUND(price * 100)::BIGINT;
+ALTER TABLE product_variants RENAME COLUMN price TO price_minor;
+
+-- order items are in the currency of their order
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS currency VARCHAR(3);
+UPDATE orders SET currency = 'IDR' WHERE currency IS NULL;
+ALTER TABLE orders ALTER COLUMN currency SET NOT NULL;
+ALTER TABLE orders ALTER COLUMN total TYPE BIGINT USING ROUND(total * 100)::BIGINT;
+ALTER TABLE orders RENAME COLUMN total TO total_minor;
+
+ALTER TABLE order_items ALTER COLUMN unit_price TYPE BIGINT USING ROUND(unit_price * 100)::BIGINT;
+ALTER TABLE order_items RENAME COLUMN unit_price TO unit_price_minor;
+ALTER TABLE order_items ALTER COLUMN subtotal TYPE BIGINT USING ROUND(subtotal * 100)::BIGINT;
+ALTER TABLE order_items RENAME COLUMN subtotal TO subtotal_minor;
+
+ALTER TABLE payment_intents ALTER COLUMN amount TYPE BIGINT USING ROUND(amount * 100)::BIGINT;
+ALTER TABLE payment_intents RENAME COLUMN amount TO amount_minor;
@@ -0,0 +1,5 @@
+-- the stock stays on hand, the reservations only lose the distinction
+UPDATE stock_reservations SET status = 'released' WHERE status = 'restocked';
+ALTER TABLE stock_reservations DROP CONSTRAINT IF EXISTS stock_reservations_status_check;
+ALTER TABLE stock_reservations ADD CONSTRAINT stock_reservations_status_check
+    CHECK (status IN ('active', 'committed', 'released'));
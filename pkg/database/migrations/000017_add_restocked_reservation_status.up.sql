@@ -0,0 +1,4 @@
+-- committed units of an order refunded before fulfilment go back on hand
+ALTER TABLE stock_reservations DROP CONSTRAINT IF EXISTS stock_reservations_status_check;
+ALTER TABLE stock_reservations ADD CONSTRAINT stock_reservations_status_check
+    CHECK (status IN ('active', 'committed', 'released', 'restocked'));
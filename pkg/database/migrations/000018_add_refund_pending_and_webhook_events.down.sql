@@ -0,0 +1,12 @@
+DROP TABLE IF EXISTS payment_webhook_events;
+
+-- an unconfirmed refund may not have been paid out
+UPDATE payment_intents SET status = 'captured' WHERE status = 'refund_pending';
+
+DROP INDEX IF EXISTS uq_payment_intents_order_id_open;
+CREATE UNIQUE INDEX IF NOT EXISTS uq_payment_intents_order_id_open ON payment_intents (order_id)
+    WHERE status IN ('pending', 'authorized', 'captured');
+
+ALTER TABLE payment_intents DROP CONSTRAINT IF EXISTS payment_intents_status_check;
+ALTER TABLE payment_intents ADD CONSTRAINT payment_intents_status_check
+    CHECK (status IN ('pending', 'authorized', 'captured', 'failed', 'refunded'));
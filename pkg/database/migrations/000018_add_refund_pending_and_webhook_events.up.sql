@@ -0,0 +1,18 @@
+-- a refund is marked before the provider is called, so a failure afterwards can be retried
+-- without paying out twice
+ALTER TABLE payment_intents DROP CONSTRAINT IF EXISTS payment_intents_status_check;
+ALTER TABLE payment_intents ADD CONSTRAINT payment_intents_status_check
+    CHECK (status IN ('pending', 'authorized', 'captured', 'failed', 'refund_pending', 'refunded'));
+
+DROP INDEX IF EXISTS uq_payment_intents_order_id_open;
+CREATE UNIQUE INDEX IF NOT EXISTS uq_payment_intents_order_id_open ON payment_intents (order_id)
+    WHERE status IN ('pending', 'authorized', 'captured', 'refund_pending');
+
+-- provider webhook events already applied, redeliveries are skipped
+CREATE TABLE IF NOT EXISTS payment_webhook_events (
+    provider    VARCHAR(50)  NOT NULL,
+    event_id    VARCHAR(255) NOT NULL,
+    type        VARCHAR(64)  NOT NULL,
+    received_at TIMESTAMPTZ  NOT NULL DEFAULT now(),
+    PRIMARY KEY (provider, event_id)
+);
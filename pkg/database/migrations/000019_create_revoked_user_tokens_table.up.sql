@@ -0,0 +1,9 @@
+-- every access token of the user issued before revoked_before is rejected, the row
+-- only needs to live until the last of those tokens expires
+CREATE TABLE IF NOT EXISTS revoked_user_tokens (
+    user_id        UUID PRIMARY KEY REFERENCES users (id),
+    revoked_before TIMESTAMPTZ NOT NULL,
+    expires_at     TIMESTAMPTZ NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_revoked_user_tokens_expires_at ON revoked_user_tokens (expires_at);
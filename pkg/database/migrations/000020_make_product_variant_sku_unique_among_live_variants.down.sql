@@ -0,0 +1,3 @@
+-- fails while a SKU is shared by a deleted and another variant
+DROP INDEX IF EXISTS uq_product_variants_sku_live;
+ALTER TABLE product_variants ADD CONSTRAINT product_variants_sku_key UNIQUE (sku);
@@ -0,0 +1,5 @@
+-- a deleted variant keeps its SKU for order lines, but the SKU may be used again by a
+-- variant of another product
+ALTER TABLE product_variants DROP CONSTRAINT IF EXISTS product_variants_sku_key;
+CREATE UNIQUE INDEX IF NOT EXISTS uq_product_variants_sku_live ON product_variants (sku)
+    WHERE is_deleted = false;